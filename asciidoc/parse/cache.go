@@ -0,0 +1,147 @@
+package parse
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/project-chip/alchemy/asciidoc"
+)
+
+// Cache stores parsed documents on disk, keyed by the hash of their contents, so unchanged files
+// need not be parsed again on later runs.
+//
+// Entries live in a subdirectory named for the running executable, since any change to the parser
+// or the element types can change the tree a document parses to.
+type Cache struct {
+	dir string
+}
+
+// Subdirectories left behind by other builds are removed once they have gone unused this long
+const cacheExpiry = 30 * 24 * time.Hour
+
+var executableHash = sync.OnceValues(func() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+})
+
+func NewCache(dir string) (*Cache, error) {
+	version, err := executableHash()
+	if err != nil {
+		return nil, fmt.Errorf("error identifying executable for parse cache: %w", err)
+	}
+	c := &Cache{dir: filepath.Join(dir, version)}
+	err = os.MkdirAll(c.dir, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	err = os.Chtimes(c.dir, now, now)
+	if err != nil {
+		return nil, err
+	}
+	pruneCache(dir, version, now)
+	return c, nil
+}
+
+func pruneCache(dir string, version string, now time.Time) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == version {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) < cacheExpiry {
+			continue
+		}
+		err = os.RemoveAll(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			slog.Warn("error removing stale parse cache", slog.String("path", filepath.Join(dir, entry.Name())), slog.Any("error", err))
+		}
+	}
+}
+
+// Raw behaves like the package-level Raw, returning the cached parse of b if there is one, and caching
+// the result if not; a nil Cache always parses
+func (c *Cache) Raw(path asciidoc.Path, b []byte) (*asciidoc.Document, error) {
+	if c == nil {
+		return Raw(path, b)
+	}
+	sum := sha256.Sum256(b)
+	key := hex.EncodeToString(sum[:])
+	cachePath := filepath.Join(c.dir, key[:2], key)
+	doc, err := c.load(cachePath, path)
+	if err == nil {
+		return doc, nil
+	}
+	if !os.IsNotExist(err) {
+		slog.Debug("ignoring unreadable parse cache entry", slog.String("path", path.Absolute), slog.Any("error", err))
+	}
+	doc, err = Raw(path, b)
+	if err != nil {
+		return nil, err
+	}
+	err = c.store(cachePath, doc)
+	if err != nil {
+		slog.Debug("unable to cache parsed document", slog.String("path", path.Absolute), slog.Any("error", err))
+	}
+	return doc, nil
+}
+
+func (c *Cache) load(cachePath string, path asciidoc.Path) (*asciidoc.Document, error) {
+	f, err := os.Open(cachePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return DecodeDocument(f, path)
+}
+
+func (c *Cache) store(cachePath string, doc *asciidoc.Document) (err error) {
+	dir := filepath.Dir(cachePath)
+	err = os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return
+	}
+	// Write to a temporary file first, so concurrent runs never see a partial entry
+	var f *os.File
+	f, err = os.CreateTemp(dir, filepath.Base(cachePath)+".*")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	err = EncodeDocument(f, doc)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return
+	}
+	return os.Rename(f.Name(), cachePath)
+}
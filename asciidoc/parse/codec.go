@@ -0,0 +1,639 @@
+package parse
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sync"
+
+	"github.com/project-chip/alchemy/asciidoc"
+)
+
+// The codec serializes parsed document trees so they can be cached between runs.
+// It walks the exported fields of each element with reflection, and restores the
+// unexported position, raw text and parent of each element through the setters
+// asciidoc exposes for them.
+
+const codecMagic = "ADOC"
+
+// Bump codecVersion whenever the encoding changes
+const codecVersion = 1
+
+var codecTypes = []any{
+	asciidoc.AnchorAttribute{},
+	asciidoc.Anchor{},
+	asciidoc.AttributeEntry{},
+	asciidoc.AttributeReset{},
+	asciidoc.NamedAttribute{},
+	asciidoc.PositionalAttribute{},
+	asciidoc.TitleAttribute{},
+	asciidoc.UserAttributeReference{},
+	asciidoc.CharacterReplacementReference{},
+	asciidoc.AttributeType(0),
+	asciidoc.AttributeName(""),
+	asciidoc.AttributeNames{},
+	asciidoc.AttributeQuoteType(0),
+	asciidoc.AttributeList{},
+	asciidoc.BlockAttributes{},
+	asciidoc.Bold{},
+	asciidoc.DoubleBold{},
+	asciidoc.ThematicBreak{},
+	asciidoc.PageBreak{},
+	asciidoc.TableColumnWidth(0),
+	asciidoc.TableColumn{},
+	asciidoc.TableColumnsAttribute{},
+	asciidoc.SingleLineComment{},
+	asciidoc.MultiLineComment{},
+	asciidoc.IfDef{},
+	asciidoc.IfNDef{},
+	asciidoc.InlineIfDef{},
+	asciidoc.InlineIfNDef{},
+	asciidoc.EndIf{},
+	asciidoc.ConditionalOperator(0),
+	asciidoc.ConditionalUnion(0),
+	asciidoc.IfEval{},
+	asciidoc.IfDefBlock{},
+	asciidoc.IfNDefBlock{},
+	asciidoc.IfEvalValue{},
+	asciidoc.IfEvalBlock{},
+	asciidoc.Counter{},
+	asciidoc.CounterType(0),
+	asciidoc.CounterVisibility(0),
+	asciidoc.CounterState{},
+	asciidoc.DelimitedBlockType(0),
+	asciidoc.Delimiter{},
+	asciidoc.Document{},
+	asciidoc.ElementType(0),
+	asciidoc.Elements{},
+	asciidoc.Email{},
+	asciidoc.AlchemyEscape{},
+	asciidoc.ExampleBlock{},
+	asciidoc.FencedDelimiter{},
+	asciidoc.FencedBlock{},
+	asciidoc.Footnote{},
+	asciidoc.TextFormat(0),
+	asciidoc.Icon{},
+	asciidoc.BlockImage{},
+	asciidoc.InlineImage{},
+	asciidoc.FileInclude{},
+	asciidoc.Italic{},
+	asciidoc.DoubleItalic{},
+	asciidoc.LineList{},
+	asciidoc.EmptyLine{},
+	asciidoc.Link{},
+	asciidoc.LinkMacro{},
+	asciidoc.Checklist(0),
+	asciidoc.UnorderedList{},
+	asciidoc.OrderedListItem{},
+	asciidoc.UnorderedListItem{},
+	asciidoc.DescriptionListItem{},
+	asciidoc.ListContinuation{},
+	asciidoc.Listing{},
+	asciidoc.LiteralBlock{},
+	asciidoc.Marked{},
+	asciidoc.DoubleMarked{},
+	asciidoc.Monospace{},
+	asciidoc.DoubleMonospace{},
+	asciidoc.OpenBlock{},
+	asciidoc.AdmonitionType(0),
+	asciidoc.Admonition{},
+	asciidoc.Paragraph{},
+	asciidoc.InlinePassthrough{},
+	asciidoc.InlineDoublePassthrough{},
+	asciidoc.Path{},
+	asciidoc.QuoteBlock{},
+	asciidoc.CrossReferenceFormat(0),
+	asciidoc.CrossReference{},
+	asciidoc.DocumentCrossReference{},
+	asciidoc.Section{},
+	asciidoc.ShorthandStyle{},
+	asciidoc.ShorthandID{},
+	asciidoc.ShorthandRole{},
+	asciidoc.ShorthandOption{},
+	asciidoc.ShorthandAttribute{},
+	asciidoc.SidebarBlock{},
+	asciidoc.SourceBlock{},
+	asciidoc.StemBlock{},
+	asciidoc.Subscript{},
+	asciidoc.Superscript{},
+	asciidoc.TableCellHorizontalAlign(0),
+	asciidoc.TableCellVerticalAlign(0),
+	asciidoc.TableCellStyle(0),
+	asciidoc.TableCellSpan{},
+	asciidoc.TableCellFormat{},
+	asciidoc.TableCell{},
+	asciidoc.TableCells{},
+	asciidoc.TableRow{},
+	asciidoc.TableRows{},
+	asciidoc.Table{},
+	asciidoc.String{},
+	asciidoc.SpecialCharacter{},
+	asciidoc.LineContinuation{},
+	asciidoc.LineBreak{},
+	asciidoc.NewLine{},
+	asciidoc.URL{},
+	"",
+	int(0),
+	bool(false),
+	[]any{},
+	[]string{},
+}
+
+var codecRegistry = func() map[string]reflect.Type {
+	registry := make(map[string]reflect.Type, len(codecTypes)*2)
+	for _, v := range codecTypes {
+		t := reflect.TypeOf(v)
+		registry[t.String()] = t
+		registry[reflect.PointerTo(t).String()] = reflect.PointerTo(t)
+	}
+	return registry
+}()
+
+var errCodecUnsupported = errors.New("unsupported type")
+
+type codecStruct struct {
+	// Exported fields; anonymous unexported fields carry state which is only reachable through setters
+	fields   []int
+	position bool
+	raw      bool
+	parent   bool
+}
+
+var codecStructs sync.Map
+
+var (
+	hasPositionType = reflect.TypeFor[asciidoc.HasPosition]()
+	hasRawType      = reflect.TypeFor[asciidoc.HasRaw]()
+	hasParentType   = reflect.TypeFor[asciidoc.HasParent]()
+)
+
+func codecStructOf(t reflect.Type) (*codecStruct, error) {
+	if cs, ok := codecStructs.Load(t); ok {
+		return cs.(*codecStruct), nil
+	}
+	cs := &codecStruct{}
+	var hidden bool
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.IsExported() {
+			cs.fields = append(cs.fields, i)
+			continue
+		}
+		if !f.Anonymous {
+			return nil, fmt.Errorf("%w: %s has unexported field %s", errCodecUnsupported, t.String(), f.Name)
+		}
+		switch f.Type.Name() {
+		case "position", "raw", "child", "attribute":
+			hidden = true
+		default:
+			return nil, fmt.Errorf("%w: %s embeds unexported %s", errCodecUnsupported, t.String(), f.Type.String())
+		}
+	}
+	if hidden {
+		pt := reflect.PointerTo(t)
+		cs.position = pt.Implements(hasPositionType)
+		cs.raw = pt.Implements(hasRawType)
+		cs.parent = pt.Implements(hasParentType)
+	}
+	codecStructs.Store(t, cs)
+	return cs, nil
+}
+
+// EncodeDocument writes a compact binary form of a parsed document to w; the document's path is not included
+func EncodeDocument(w io.Writer, doc *asciidoc.Document) error {
+	bw := bufio.NewWriter(w)
+	e := &encoder{w: bw, pointers: make(map[codecPointer]uint64), types: make(map[reflect.Type]uint64)}
+	e.writeString(codecMagic)
+	e.writeUint(codecVersion)
+	e.pointers[codecPointerOf(reflect.ValueOf(doc))] = 0
+	e.nextPointer = 1
+	err := e.value(reflect.ValueOf(&doc.Elements).Elem())
+	if err != nil {
+		return err
+	}
+	if e.err != nil {
+		return e.err
+	}
+	return bw.Flush()
+}
+
+// DecodeDocument reads a document written by EncodeDocument, giving it the supplied path
+func DecodeDocument(r io.Reader, path asciidoc.Path) (doc *asciidoc.Document, err error) {
+	d := &decoder{r: bufio.NewReader(r)}
+	magic, err := d.readString()
+	if err != nil {
+		return
+	}
+	if magic != codecMagic {
+		return nil, fmt.Errorf("invalid document cache header")
+	}
+	version, err := d.readUint()
+	if err != nil {
+		return
+	}
+	if version != codecVersion {
+		return nil, fmt.Errorf("unsupported document cache version %d", version)
+	}
+	doc = &asciidoc.Document{Path: path}
+	d.pointers = append(d.pointers, reflect.ValueOf(doc))
+	err = d.value(reflect.ValueOf(&doc.Elements).Elem())
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Pointers are keyed by type as well as address, since a struct and its first field share an address
+type codecPointer struct {
+	t       reflect.Type
+	address uintptr
+}
+
+func codecPointerOf(v reflect.Value) codecPointer {
+	return codecPointer{t: v.Type(), address: v.Pointer()}
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+	buf [binary.MaxVarintLen64]byte
+
+	pointers    map[codecPointer]uint64
+	nextPointer uint64
+	types       map[reflect.Type]uint64
+}
+
+func (e *encoder) writeUint(u uint64) {
+	if e.err != nil {
+		return
+	}
+	n := binary.PutUvarint(e.buf[:], u)
+	_, e.err = e.w.Write(e.buf[:n])
+}
+
+func (e *encoder) writeInt(i int64) {
+	if e.err != nil {
+		return
+	}
+	n := binary.PutVarint(e.buf[:], i)
+	_, e.err = e.w.Write(e.buf[:n])
+}
+
+func (e *encoder) writeString(s string) {
+	e.writeUint(uint64(len(s)))
+	if e.err != nil {
+		return
+	}
+	_, e.err = e.w.WriteString(s)
+}
+
+func (e *encoder) value(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			e.writeUint(1)
+		} else {
+			e.writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.writeInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		e.writeUint(math.Float64bits(v.Float()))
+	case reflect.String:
+		e.writeString(v.String())
+	case reflect.Slice:
+		if v.IsNil() {
+			e.writeUint(0)
+			return nil
+		}
+		e.writeUint(uint64(v.Len()) + 1)
+		for i := range v.Len() {
+			if err := e.value(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Array:
+		for i := range v.Len() {
+			if err := e.value(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Pointer:
+		return e.pointer(v)
+	case reflect.Interface:
+		if v.IsNil() {
+			e.writeUint(0)
+			return nil
+		}
+		v = v.Elem()
+		if err := e.typeTag(v.Type()); err != nil {
+			return err
+		}
+		return e.value(v)
+	case reflect.Struct:
+		return e.structValue(v)
+	default:
+		return fmt.Errorf("%w: %s", errCodecUnsupported, v.Type().String())
+	}
+	return e.err
+}
+
+// Pointers are written once and referred to by index afterwards, which preserves the back-pointers in tables
+func (e *encoder) pointer(v reflect.Value) error {
+	if v.IsNil() {
+		e.writeUint(0)
+		return nil
+	}
+	key := codecPointerOf(v)
+	if index, ok := e.pointers[key]; ok {
+		e.writeUint(index + 2)
+		return nil
+	}
+	e.pointers[key] = e.nextPointer
+	e.nextPointer++
+	e.writeUint(1)
+	return e.value(v.Elem())
+}
+
+func (e *encoder) typeTag(t reflect.Type) error {
+	if index, ok := e.types[t]; ok {
+		e.writeUint(index + 2)
+		return nil
+	}
+	name := t.String()
+	if _, ok := codecRegistry[name]; !ok {
+		return fmt.Errorf("%w: %s", errCodecUnsupported, name)
+	}
+	e.types[t] = uint64(len(e.types))
+	e.writeUint(1)
+	e.writeString(name)
+	return nil
+}
+
+func (e *encoder) structValue(v reflect.Value) error {
+	cs, err := codecStructOf(v.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range cs.fields {
+		if err = e.value(v.Field(f)); err != nil {
+			return err
+		}
+	}
+	if !cs.position && !cs.raw && !cs.parent {
+		return nil
+	}
+	if !v.CanAddr() {
+		c := reflect.New(v.Type())
+		c.Elem().Set(v)
+		v = c.Elem()
+	}
+	p := v.Addr().Interface()
+	if cs.position {
+		hp := p.(asciidoc.HasPosition)
+		// Cells in AsciiDoc-style table cells belong to documents of their own
+		if err = e.pointer(reflect.ValueOf(hp.Document())); err != nil {
+			return err
+		}
+		line, column, offset := hp.Position()
+		e.writeInt(int64(line))
+		e.writeInt(int64(column))
+		e.writeInt(int64(offset))
+	}
+	if cs.raw {
+		e.writeString(p.(asciidoc.HasRaw).Raw())
+	}
+	if cs.parent {
+		parent := p.(asciidoc.HasParent).Parent()
+		if err = e.value(reflect.ValueOf(&parent).Elem()); err != nil {
+			return err
+		}
+	}
+	return e.err
+}
+
+type decoder struct {
+	r *bufio.Reader
+
+	pointers []reflect.Value
+	types    []reflect.Type
+}
+
+func (d *decoder) readUint() (uint64, error) {
+	return binary.ReadUvarint(d.r)
+}
+
+func (d *decoder) readInt() (int64, error) {
+	return binary.ReadVarint(d.r)
+}
+
+func (d *decoder) readString() (string, error) {
+	l, err := d.readUint()
+	if err != nil {
+		return "", err
+	}
+	if l > 1<<30 {
+		return "", fmt.Errorf("invalid string length %d", l)
+	}
+	b := make([]byte, l)
+	_, err = io.ReadFull(d.r, b)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (d *decoder) value(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		u, err := d.readUint()
+		if err != nil {
+			return err
+		}
+		v.SetBool(u != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := d.readInt()
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := d.readUint()
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		u, err := d.readUint()
+		if err != nil {
+			return err
+		}
+		v.SetFloat(math.Float64frombits(u))
+	case reflect.String:
+		s, err := d.readString()
+		if err != nil {
+			return err
+		}
+		v.SetString(s)
+	case reflect.Slice:
+		l, err := d.readUint()
+		if err != nil {
+			return err
+		}
+		if l == 0 {
+			return nil
+		}
+		l--
+		if l > 1<<24 {
+			return fmt.Errorf("invalid slice length %d", l)
+		}
+		s := reflect.MakeSlice(v.Type(), int(l), int(l))
+		for i := range int(l) {
+			if err = d.value(s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	case reflect.Array:
+		for i := range v.Len() {
+			if err := d.value(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Pointer:
+		return d.pointer(v)
+	case reflect.Interface:
+		t, err := d.typeTag()
+		if err != nil || t == nil {
+			return err
+		}
+		if !t.AssignableTo(v.Type()) {
+			return fmt.Errorf("type %s is not assignable to %s", t.String(), v.Type().String())
+		}
+		c := reflect.New(t).Elem()
+		if err = d.value(c); err != nil {
+			return err
+		}
+		v.Set(c)
+	case reflect.Struct:
+		return d.structValue(v)
+	default:
+		return fmt.Errorf("%w: %s", errCodecUnsupported, v.Type().String())
+	}
+	return nil
+}
+
+func (d *decoder) pointer(v reflect.Value) error {
+	tag, err := d.readUint()
+	if err != nil {
+		return err
+	}
+	switch tag {
+	case 0:
+		return nil
+	case 1:
+		p := reflect.New(v.Type().Elem())
+		d.pointers = append(d.pointers, p)
+		v.Set(p)
+		return d.value(p.Elem())
+	default:
+		index := tag - 2
+		if index >= uint64(len(d.pointers)) {
+			return fmt.Errorf("invalid pointer reference %d", index)
+		}
+		p := d.pointers[index]
+		if !p.Type().AssignableTo(v.Type()) {
+			return fmt.Errorf("pointer of type %s is not assignable to %s", p.Type().String(), v.Type().String())
+		}
+		v.Set(p)
+		return nil
+	}
+}
+
+func (d *decoder) typeTag() (reflect.Type, error) {
+	tag, err := d.readUint()
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case 0:
+		return nil, nil
+	case 1:
+		name, err := d.readString()
+		if err != nil {
+			return nil, err
+		}
+		t, ok := codecRegistry[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errCodecUnsupported, name)
+		}
+		d.types = append(d.types, t)
+		return t, nil
+	default:
+		index := tag - 2
+		if index >= uint64(len(d.types)) {
+			return nil, fmt.Errorf("invalid type reference %d", index)
+		}
+		return d.types[index], nil
+	}
+}
+
+func (d *decoder) structValue(v reflect.Value) error {
+	cs, err := codecStructOf(v.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range cs.fields {
+		if err = d.value(v.Field(f)); err != nil {
+			return err
+		}
+	}
+	if !cs.position && !cs.raw && !cs.parent {
+		return nil
+	}
+	p := v.Addr().Interface()
+	if cs.position {
+		hp := p.(asciidoc.HasPosition)
+		var document *asciidoc.Document
+		var line, column, offset int64
+		if err = d.pointer(reflect.ValueOf(&document).Elem()); err != nil {
+			return err
+		}
+		if line, err = d.readInt(); err != nil {
+			return err
+		}
+		if column, err = d.readInt(); err != nil {
+			return err
+		}
+		if offset, err = d.readInt(); err != nil {
+			return err
+		}
+		if document != nil {
+			hp.SetDocument(document)
+		}
+		hp.SetPosition(int(line), int(column), int(offset))
+	}
+	if cs.raw {
+		var raw string
+		if raw, err = d.readString(); err != nil {
+			return err
+		}
+		p.(asciidoc.HasRaw).SetRaw(raw)
+	}
+	if cs.parent {
+		var parent asciidoc.Element
+		if err = d.value(reflect.ValueOf(&parent).Elem()); err != nil {
+			return err
+		}
+		if parent != nil {
+			p.(asciidoc.HasParent).SetParent(parent)
+		}
+	}
+	return nil
+}
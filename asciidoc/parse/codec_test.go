@@ -0,0 +1,51 @@
+package parse
+
+import (
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"testing"
+)
+
+// TestCodecTypes checks that every element and attribute type in the asciidoc package is registered with the
+// codec, so that adding a node type without registering it fails here rather than when a cached document is read
+func TestCodecTypes(t *testing.T) {
+	fset := token.NewFileSet()
+	packages, err := goparser.ParseDir(fset, "..", nil, goparser.SkipObjectResolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, ok := packages["asciidoc"]
+	if !ok {
+		t.Fatalf("asciidoc package not found")
+	}
+	var count int
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 || fn.Type.Results == nil || len(fn.Type.Results.List) != 1 {
+				continue
+			}
+			// Elements have a Type method, and attributes an AttributeType method
+			result, ok := fn.Type.Results.List[0].Type.(*ast.Ident)
+			if !ok || !(fn.Name.Name == "Type" && result.Name == "ElementType" || fn.Name.Name == "AttributeType" && result.Name == "AttributeType") {
+				continue
+			}
+			receiver := fn.Recv.List[0].Type
+			if star, ok := receiver.(*ast.StarExpr); ok {
+				receiver = star.X
+			}
+			name, ok := receiver.(*ast.Ident)
+			if !ok || !name.IsExported() {
+				continue
+			}
+			count++
+			if _, ok := codecRegistry["asciidoc."+name.Name]; !ok {
+				t.Errorf("asciidoc.%s is not registered in codecTypes", name.Name)
+			}
+		}
+	}
+	if count == 0 {
+		t.Errorf("found no element types in the asciidoc package")
+	}
+}
@@ -0,0 +1,16 @@
+package parse
+
+import "errors"
+
+// ErrorPosition returns the one-based line and column, in runes, at which parsing failed, if err came from the parser
+func ErrorPosition(err error) (line int, column int, ok bool) {
+	var errs errList
+	if !errors.As(err, &errs) || len(errs) == 0 {
+		return
+	}
+	var pe *parserError
+	if !errors.As(errs[0], &pe) {
+		return
+	}
+	return pe.pos.line, pe.pos.col, true
+}
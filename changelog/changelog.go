@@ -0,0 +1,97 @@
+package changelog
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/project-chip/alchemy/provisional"
+)
+
+type ChangeType uint8
+
+const (
+	ChangeTypeUnknown ChangeType = iota
+	ChangeTypeAdded
+	ChangeTypeRemoved
+	ChangeTypeRenamed
+	ChangeTypeModified
+)
+
+func (ct ChangeType) String() string {
+	switch ct {
+	case ChangeTypeAdded:
+		return "added"
+	case ChangeTypeRemoved:
+		return "removed"
+	case ChangeTypeRenamed:
+		return "renamed"
+	case ChangeTypeModified:
+		return "modified"
+	default:
+		return "unknown"
+	}
+}
+
+func (ct ChangeType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ct.String())
+}
+
+// Changelog lists the changes to the clusters and device types of a spec between two versions
+type Changelog struct {
+	Clusters    []*EntityChanges `json:"clusters,omitempty"`
+	DeviceTypes []*EntityChanges `json:"deviceTypes,omitempty"`
+}
+
+// EntityChanges lists the changes to a single cluster or device type; added and removed clusters and device
+// types do not list the entities within them
+type EntityChanges struct {
+	Type         ChangeType `json:"type"`
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	BaseRevision string     `json:"baseRevision,omitempty"`
+	HeadRevision string     `json:"headRevision,omitempty"`
+	Changes      []*Change  `json:"changes,omitempty"`
+}
+
+// Change describes an entity which was added, removed, renamed or modified; Parent names the entity which
+// holds it, such as the command of a command field
+type Change struct {
+	Type        ChangeType             `json:"type"`
+	Kind        string                 `json:"kind"`
+	Name        string                 `json:"name"`
+	Parent      string                 `json:"parent,omitempty"`
+	OldName     string                 `json:"oldName,omitempty"`
+	Properties  []*PropertyChange      `json:"properties,omitempty"`
+	Provisional *ProvisionalTransition `json:"provisional,omitempty"`
+}
+
+func (c *Change) String() string {
+	name := c.qualifiedName(c.Name)
+	switch c.Type {
+	case ChangeTypeRenamed:
+		return fmt.Sprintf("renamed %s %s to %s", c.Kind, c.qualifiedName(c.OldName), c.Name)
+	default:
+		return fmt.Sprintf("%s %s %s", c.Type, c.Kind, name)
+	}
+}
+
+func (c *Change) qualifiedName(name string) string {
+	if c.Parent != "" {
+		return c.Parent + "." + name
+	}
+	return name
+}
+
+// PropertyChange holds the values of a single property of an entity, as written in the spec, before and after
+type PropertyChange struct {
+	Name   string `json:"name"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// ProvisionalTransition holds the provisional states of an entity before and after; it is only reported when an
+// entity becomes or stops being provisional, or when a new entity is provisional
+type ProvisionalTransition struct {
+	Before provisional.State `json:"before"`
+	After  provisional.State `json:"after"`
+}
@@ -0,0 +1,143 @@
+package changelog
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/provisional"
+)
+
+// Compare lists the changes to the clusters and device types of head since base; clusters and device types are
+// matched by ID
+func Compare(base *spec.Specification, head *spec.Specification) *Changelog {
+	cl := &Changelog{}
+	for _, id := range sortedKeys(base.ClustersByID, head.ClustersByID) {
+		baseCluster, headCluster := base.ClustersByID[id], head.ClustersByID[id]
+		var ec *EntityChanges
+		switch {
+		case baseCluster == nil:
+			ec = &EntityChanges{Type: ChangeTypeAdded, ID: headCluster.ID.HexString(), Name: headCluster.Name}
+		case headCluster == nil:
+			ec = &EntityChanges{Type: ChangeTypeRemoved, ID: baseCluster.ID.HexString(), Name: baseCluster.Name}
+		default:
+			changes := compareElements(clusterElements(baseCluster), clusterElements(headCluster), base, head)
+			if len(changes) == 0 {
+				continue
+			}
+			ec = &EntityChanges{Type: ChangeTypeModified, ID: headCluster.ID.HexString(), Name: headCluster.Name, Changes: changes}
+			ec.BaseRevision = mostRecentRevision(baseCluster.Revisions)
+		}
+		if headCluster != nil {
+			ec.HeadRevision = mostRecentRevision(headCluster.Revisions)
+		}
+		cl.Clusters = append(cl.Clusters, ec)
+	}
+	for _, id := range sortedKeys(base.DeviceTypesByID, head.DeviceTypesByID) {
+		baseDeviceType, headDeviceType := base.DeviceTypesByID[id], head.DeviceTypesByID[id]
+		var ec *EntityChanges
+		switch {
+		case baseDeviceType == nil:
+			ec = &EntityChanges{Type: ChangeTypeAdded, ID: headDeviceType.ID.HexString(), Name: headDeviceType.Name}
+		case headDeviceType == nil:
+			ec = &EntityChanges{Type: ChangeTypeRemoved, ID: baseDeviceType.ID.HexString(), Name: baseDeviceType.Name}
+		default:
+			changes := CompareDeviceType(baseDeviceType, headDeviceType)
+			if len(changes) == 0 {
+				continue
+			}
+			ec = &EntityChanges{Type: ChangeTypeModified, ID: headDeviceType.ID.HexString(), Name: headDeviceType.Name, Changes: changes}
+			ec.BaseRevision = mostRecentRevision(baseDeviceType.Revisions)
+		}
+		if headDeviceType != nil {
+			ec.HeadRevision = mostRecentRevision(headDeviceType.Revisions)
+		}
+		cl.DeviceTypes = append(cl.DeviceTypes, ec)
+	}
+	return cl
+}
+
+// CompareCluster lists the changes to a cluster, without provisional transitions, which need the specs the
+// cluster belongs to
+func CompareCluster(base *matter.Cluster, head *matter.Cluster) []*Change {
+	return compareElements(clusterElements(base), clusterElements(head), nil, nil)
+}
+
+// CompareDeviceType lists the changes to the requirements of a device type
+func CompareDeviceType(base *matter.DeviceType, head *matter.DeviceType) []*Change {
+	return compareElements(deviceTypeElements(base), deviceTypeElements(head), nil, nil)
+}
+
+// compareElements lists added, renamed and modified elements in head's order, followed by removed elements in
+// base's order; if both specs are given, provisional transitions are included
+func compareElements(base *elementList, head *elementList, baseSpec *spec.Specification, headSpec *spec.Specification) (changes []*Change) {
+	checkProvisional := baseSpec != nil && headSpec != nil
+	for _, h := range head.elements {
+		b, ok := base.byKey[h.key]
+		if !ok {
+			c := &Change{Type: ChangeTypeAdded, Kind: h.kind, Name: h.name, Parent: h.parent}
+			if checkProvisional && h.entity != nil {
+				if state := provisional.Check(headSpec, h.entity, h.entity); state.IsProvisional() {
+					c.Provisional = &ProvisionalTransition{After: state}
+				}
+			}
+			changes = append(changes, c)
+			continue
+		}
+		c := &Change{Type: ChangeTypeModified, Kind: h.kind, Name: h.name, Parent: h.parent}
+		if b.name != h.name {
+			c.Type = ChangeTypeRenamed
+			c.OldName = b.name
+		}
+		c.Properties = compareProperties(b.properties, h.properties)
+		if checkProvisional && b.entity != nil && h.entity != nil {
+			before := provisional.Check(baseSpec, b.entity, b.entity)
+			after := provisional.Check(headSpec, h.entity, h.entity)
+			if before.IsProvisional() != after.IsProvisional() {
+				c.Provisional = &ProvisionalTransition{Before: before, After: after}
+			}
+		}
+		if c.Type == ChangeTypeRenamed || len(c.Properties) > 0 || c.Provisional != nil {
+			changes = append(changes, c)
+		}
+	}
+	for _, b := range base.elements {
+		if _, ok := head.byKey[b.key]; !ok {
+			changes = append(changes, &Change{Type: ChangeTypeRemoved, Kind: b.kind, Name: b.name, Parent: b.parent})
+		}
+	}
+	return
+}
+
+func compareProperties(base []property, head []property) (changes []*PropertyChange) {
+	values := make(map[string]string, len(base))
+	for _, p := range base {
+		values[p.name] = p.value
+	}
+	for _, p := range head {
+		if before := values[p.name]; before != p.value {
+			changes = append(changes, &PropertyChange{Name: p.name, Before: before, After: p.value})
+		}
+	}
+	return
+}
+
+func mostRecentRevision(revisions matter.Revisions) string {
+	if r := revisions.MostRecent(); r != nil {
+		return r.Number.IntString()
+	}
+	return ""
+}
+
+func sortedKeys[T any](base map[uint64]T, head map[uint64]T) []uint64 {
+	keys := slices.Collect(maps.Keys(base))
+	for id := range head {
+		if _, ok := base[id]; !ok {
+			keys = append(keys, id)
+		}
+	}
+	slices.SortFunc(keys, cmp.Compare)
+	return keys
+}
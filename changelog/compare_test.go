@@ -0,0 +1,100 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestCompare(t *testing.T) {
+	baseCluster := newCluster(0xFFF1, "Sample", 1)
+	baseCluster.Attributes = matter.FieldSet{
+		newAttribute(baseCluster, 0, "OnTime", "max 10", &conformance.Mandatory{}),
+		newAttribute(baseCluster, 1, "OffTime", "", &conformance.Mandatory{}),
+		newAttribute(baseCluster, 2, "Level", "", &conformance.Mandatory{}),
+	}
+	retired := newCluster(0xFFF2, "Retired", 1)
+
+	headCluster := newCluster(0xFFF1, "Sample", 2)
+	headCluster.Attributes = matter.FieldSet{
+		newAttribute(headCluster, 0, "OnTime", "max 20", &conformance.Mandatory{}),
+		newAttribute(headCluster, 1, "OffWaitTime", "", &conformance.Mandatory{}),
+		newAttribute(headCluster, 3, "Color", "", &conformance.Provisional{}),
+	}
+	added := newCluster(0xFFF3, "Added", 1)
+
+	cl := Compare(newSpec(baseCluster, retired), newSpec(headCluster, added))
+	if len(cl.Clusters) != 3 {
+		t.Fatalf("expected 3 changed clusters, got %d", len(cl.Clusters))
+	}
+	sample := cl.Clusters[0]
+	if sample.Type != ChangeTypeModified || sample.BaseRevision != "1" || sample.HeadRevision != "2" {
+		t.Errorf("unexpected cluster change: %+v", sample)
+	}
+	var descriptions []string
+	for _, c := range sample.Changes {
+		descriptions = append(descriptions, c.String())
+	}
+	expected := "modified attribute OnTime, renamed attribute OffTime to OffWaitTime, added attribute Color, removed attribute Level"
+	if got := strings.Join(descriptions, ", "); got != expected {
+		t.Errorf("expected changes %q, got %q", expected, got)
+	}
+	if p := sample.Changes[0].Properties; len(p) != 1 || p[0].Name != "constraint" || p[0].Before != "max 10" || p[0].After != "max 20" {
+		t.Errorf("unexpected property changes: %+v", p)
+	}
+	if p := sample.Changes[2].Provisional; p == nil || !p.After.IsProvisional() {
+		t.Errorf("expected added attribute to be provisional, got %+v", p)
+	}
+	if cl.Clusters[1].Type != ChangeTypeRemoved || cl.Clusters[2].Type != ChangeTypeAdded {
+		t.Errorf("expected removed and added clusters, got %s and %s", cl.Clusters[1].Type, cl.Clusters[2].Type)
+	}
+
+	md := cl.Markdown()
+	for _, line := range []string{
+		"### Sample (0xFFF1)\n\nRevision 1 → 2\n",
+		"- Modified attribute `OnTime`\n  - constraint: `max 10` → `max 20`\n",
+		"- Renamed attribute `OffTime` to `OffWaitTime`\n",
+		"- Added attribute `Color` (provisional)\n",
+		"### Retired (0xFFF2)\n\nRemoved cluster.\n",
+		"### Added (0xFFF3)\n\nAdded cluster at revision 1.\n",
+	} {
+		if !strings.Contains(md, line) {
+			t.Errorf("expected Markdown to contain %q; got:\n%s", line, md)
+		}
+	}
+}
+
+func newCluster(id uint64, name string, revision uint64) *matter.Cluster {
+	c := matter.NewCluster(nil)
+	c.ID = matter.NewNumber(id)
+	c.Name = name
+	r := matter.NewRevision(c, nil)
+	r.Number = matter.NewNumber(revision)
+	c.Revisions = matter.Revisions{r}
+	return c
+}
+
+func newAttribute(cluster *matter.Cluster, id uint64, name string, c string, conf conformance.Conformance) *matter.Field {
+	a := matter.NewAttribute(nil, cluster)
+	a.ID = matter.NewNumber(id)
+	a.Name = name
+	a.Type = types.NewDataType(types.BaseDataTypeUInt16, types.DataTypeRankScalar)
+	if c != "" {
+		a.Constraint = constraint.ParseString(c)
+	}
+	a.Conformance = conformance.Set{conf}
+	return a
+}
+
+func newSpec(clusters ...*matter.Cluster) *spec.Specification {
+	s := &spec.Specification{ClustersByID: make(map[uint64]*matter.Cluster)}
+	for _, c := range clusters {
+		s.ClustersByID[c.ID.Value()] = c
+	}
+	return s
+}
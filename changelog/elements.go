@@ -0,0 +1,240 @@
+package changelog
+
+import (
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// element describes a single entity within a cluster or device type; elements are matched between versions by
+// key, which is built from IDs where the entity has one, so that renames are not mistaken for additions
+type element struct {
+	key        string
+	kind       string
+	name       string
+	parent     string
+	properties []property
+	entity     types.Entity
+}
+
+type property struct {
+	name  string
+	value string
+}
+
+type elementList struct {
+	elements []*element
+	byKey    map[string]*element
+}
+
+func newElementList() *elementList {
+	return &elementList{byKey: make(map[string]*element)}
+}
+
+func (el *elementList) add(e *element) {
+	if _, ok := el.byKey[e.key]; ok {
+		return
+	}
+	el.elements = append(el.elements, e)
+	el.byKey[e.key] = e
+}
+
+// clusterElements lists everything about a cluster which is called out in release notes; descriptions and
+// summaries are editorial, and so are left out
+func clusterElements(cluster *matter.Cluster) *elementList {
+	el := newElementList()
+	el.add(&element{key: "cluster", kind: "cluster", name: cluster.Name, entity: cluster, properties: []property{
+		{"conformance", conformanceString(cluster.Conformance)},
+		{"hierarchy", cluster.Hierarchy},
+		{"role", cluster.Role},
+		{"scope", cluster.Scope},
+		{"PICS", cluster.PICS},
+		{"quality", cluster.ClusterClassification.Quality.String()},
+	}})
+	if cluster.Features != nil {
+		for _, b := range cluster.Features.Bits {
+			f, ok := b.(*matter.Feature)
+			if !ok {
+				continue
+			}
+			el.add(&element{key: "feature " + f.Bit(), kind: "feature", name: f.Name(), entity: f, properties: []property{
+				{"bit", f.Bit()},
+				{"code", f.Code},
+				{"conformance", conformanceString(f.Conformance())},
+			}})
+		}
+	}
+	for _, a := range cluster.Attributes {
+		el.add(&element{key: "attribute " + id(a.ID, a.Name), kind: "attribute", name: a.Name, entity: a, properties: fieldProperties(a)})
+	}
+	for _, c := range cluster.Commands {
+		key := "command " + id(c.ID, c.Name) + " " + c.Direction.String()
+		el.add(&element{key: key, kind: "command", name: c.Name, entity: c, properties: []property{
+			{"ID", id(c.ID, "")},
+			{"direction", c.Direction.String()},
+			{"response", typeName(c.Response)},
+			{"conformance", conformanceString(c.Conformance)},
+			{"quality", c.Quality.String()},
+			{"access", c.Access.String()},
+		}})
+		addFields(el, key, "command field", c.Name, c.Fields)
+	}
+	for _, e := range cluster.Events {
+		key := "event " + id(e.ID, e.Name)
+		el.add(&element{key: key, kind: "event", name: e.Name, entity: e, properties: []property{
+			{"ID", id(e.ID, "")},
+			{"priority", e.Priority},
+			{"conformance", conformanceString(e.Conformance)},
+			{"access", e.Access.String()},
+		}})
+		addFields(el, key, "event field", e.Name, e.Fields)
+	}
+	for _, en := range cluster.Enums {
+		key := "enum " + en.Name
+		el.add(&element{key: key, kind: "enum", name: en.Name, entity: en, properties: []property{{"type", typeName(en.Type)}}})
+		for _, v := range en.Values {
+			el.add(&element{key: key + " value " + id(v.Value, v.Name), kind: "enum value", name: v.Name, parent: en.Name, entity: v, properties: []property{
+				{"value", id(v.Value, "")},
+				{"conformance", conformanceString(v.Conformance)},
+			}})
+		}
+	}
+	for _, bm := range cluster.Bitmaps {
+		key := "bitmap " + bm.Name
+		el.add(&element{key: key, kind: "bitmap", name: bm.Name, entity: bm, properties: []property{{"type", typeName(bm.Type)}}})
+		for _, b := range bm.Bits {
+			el.add(&element{key: key + " bit " + b.Bit(), kind: "bit", name: b.Name(), parent: bm.Name, entity: b, properties: []property{
+				{"bit", b.Bit()},
+				{"conformance", conformanceString(b.Conformance())},
+			}})
+		}
+	}
+	for _, s := range cluster.Structs {
+		key := "struct " + s.Name
+		el.add(&element{key: key, kind: "struct", name: s.Name, entity: s, properties: []property{{"fabric scoping", s.FabricScoping.String()}}})
+		addFields(el, key, "struct field", s.Name, s.Fields)
+	}
+	for _, td := range cluster.TypeDefs {
+		el.add(&element{key: "typedef " + td.Name, kind: "typedef", name: td.Name, entity: td, properties: []property{{"type", typeName(td.Type)}}})
+	}
+	return el
+}
+
+func addFields(el *elementList, parentKey string, kind string, parentName string, fields matter.FieldSet) {
+	for _, f := range fields {
+		el.add(&element{key: parentKey + " field " + id(f.ID, f.Name), kind: kind, name: f.Name, parent: parentName, entity: f, properties: fieldProperties(f)})
+	}
+}
+
+func fieldProperties(f *matter.Field) []property {
+	var fallback string
+	if !constraint.IsBlankLimit(f.Fallback) {
+		fallback = f.Fallback.ASCIIDocString(f.Type)
+	}
+	return []property{
+		{"ID", id(f.ID, "")},
+		{"type", typeName(f.Type)},
+		{"constraint", constraintString(f.Constraint, f.Type)},
+		{"quality", f.Quality.String()},
+		{"access", f.Access.String()},
+		{"default", fallback},
+		{"conformance", conformanceString(f.Conformance)},
+	}
+}
+
+// deviceTypeElements lists the requirements a device type declares itself; requirements composed from other
+// device types are called out in the notes for those device types
+func deviceTypeElements(deviceType *matter.DeviceType) *elementList {
+	el := newElementList()
+	el.add(&element{key: "device type", kind: "device type", name: deviceType.Name, properties: []property{
+		{"class", deviceType.Class},
+		{"scope", deviceType.Scope},
+		{"superset of", deviceType.SupersetOf},
+	}})
+	for _, c := range deviceType.Conditions {
+		el.add(&element{key: "condition " + c.Feature, kind: "condition", name: c.Feature, properties: []property{{"ID", id(c.ID, "")}}})
+	}
+	for _, cr := range deviceType.ClusterRequirements {
+		key := "cluster requirement " + id(cr.ClusterID, cr.ClusterName) + " " + cr.Interface.String()
+		el.add(&element{key: key, kind: cr.Interface.String() + " cluster requirement", name: cr.ClusterName, properties: []property{
+			{"quality", cr.Quality.String()},
+			{"conformance", conformanceString(cr.Conformance)},
+		}})
+	}
+	for _, er := range deviceType.ElementRequirements {
+		name := er.Name
+		if er.Field != "" {
+			name += "." + er.Field
+		}
+		key := "element requirement " + id(er.ClusterID, er.ClusterName) + " " + er.Element.String() + " " + name
+		el.add(&element{key: key, kind: er.Element.String() + " requirement", name: name, parent: er.ClusterName, properties: []property{
+			{"constraint", constraintString(er.Constraint, nil)},
+			{"quality", er.Quality.String()},
+			{"access", er.Access.String()},
+			{"conformance", conformanceString(er.Conformance)},
+		}})
+	}
+	for _, cr := range deviceType.ConditionRequirements {
+		key := "condition requirement " + id(cr.DeviceTypeID, cr.DeviceTypeName) + " " + cr.ConditionName + " " + cr.Location.String()
+		el.add(&element{key: key, kind: "condition requirement", name: cr.ConditionName, parent: cr.DeviceTypeName, properties: []property{
+			{"conformance", conformanceString(cr.Conformance)},
+		}})
+	}
+	for _, tr := range deviceType.TagRequirements {
+		key := "tag requirement " + id(tr.NamespaceID, tr.NamespaceName) + " " + id(tr.SemanticTagID, tr.SemanticTagName)
+		el.add(&element{key: key, kind: "tag requirement", name: tr.SemanticTagName, parent: tr.NamespaceName, properties: []property{
+			{"constraint", constraintString(tr.Constraint, nil)},
+			{"conformance", conformanceString(tr.Conformance)},
+		}})
+	}
+	for _, dtr := range deviceType.DeviceTypeRequirements {
+		var superset string
+		if dtr.AllowsSuperset {
+			superset = "allowed"
+		}
+		key := "device type requirement " + id(dtr.DeviceTypeID, dtr.DeviceTypeName) + " " + dtr.Location.String()
+		el.add(&element{key: key, kind: "device type requirement", name: dtr.DeviceTypeName, properties: []property{
+			{"constraint", constraintString(dtr.Constraint, nil)},
+			{"conformance", conformanceString(dtr.Conformance)},
+			{"superset", superset},
+		}})
+	}
+	return el
+}
+
+func id(n *matter.Number, name string) string {
+	if n.Valid() {
+		return n.HexString()
+	}
+	return name
+}
+
+func typeName(dataType *types.DataType) string {
+	if dataType == nil {
+		return ""
+	}
+	if dataType.IsArray() {
+		return "list[" + typeName(dataType.EntryType) + "]"
+	}
+	if dataType.Entity != nil {
+		if name := matter.EntityName(dataType.Entity); name != "" {
+			return name
+		}
+	}
+	return dataType.Name
+}
+
+func conformanceString(c conformance.Set) string {
+	if c == nil {
+		return ""
+	}
+	return c.ASCIIDocString()
+}
+
+func constraintString(c constraint.Constraint, dataType *types.DataType) string {
+	if c == nil {
+		return ""
+	}
+	return c.ASCIIDocString(dataType)
+}
@@ -0,0 +1,89 @@
+package changelog
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Markdown renders the changelog as release notes
+func (cl *Changelog) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("# Changelog\n")
+	if len(cl.Clusters) == 0 && len(cl.DeviceTypes) == 0 {
+		sb.WriteString("\nNo changes.\n")
+		return sb.String()
+	}
+	writeSection(&sb, "Clusters", "cluster", cl.Clusters)
+	writeSection(&sb, "Device Types", "device type", cl.DeviceTypes)
+	return sb.String()
+}
+
+func writeSection(sb *strings.Builder, title string, kind string, entities []*EntityChanges) {
+	if len(entities) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "\n## %s\n", title)
+	for _, ec := range entities {
+		fmt.Fprintf(sb, "\n### %s (%s)\n\n", ec.Name, ec.ID)
+		switch ec.Type {
+		case ChangeTypeAdded:
+			fmt.Fprintf(sb, "Added %s", kind)
+			if ec.HeadRevision != "" {
+				fmt.Fprintf(sb, " at revision %s", ec.HeadRevision)
+			}
+			sb.WriteString(".\n")
+			continue
+		case ChangeTypeRemoved:
+			fmt.Fprintf(sb, "Removed %s.\n", kind)
+			continue
+		}
+		switch {
+		case ec.BaseRevision != ec.HeadRevision:
+			fmt.Fprintf(sb, "Revision %s → %s\n\n", ec.BaseRevision, ec.HeadRevision)
+		case ec.HeadRevision != "":
+			fmt.Fprintf(sb, "Revision %s (unchanged)\n\n", ec.HeadRevision)
+		}
+		for _, c := range ec.Changes {
+			writeChange(sb, c)
+		}
+	}
+}
+
+func writeChange(sb *strings.Builder, c *Change) {
+	switch c.Type {
+	case ChangeTypeRenamed:
+		fmt.Fprintf(sb, "- Renamed %s `%s` to `%s`", c.Kind, c.qualifiedName(c.OldName), c.Name)
+	default:
+		fmt.Fprintf(sb, "- %s %s `%s`", capitalize(c.Type.String()), c.Kind, c.qualifiedName(c.Name))
+	}
+	if c.Provisional != nil {
+		switch {
+		case c.Type == ChangeTypeAdded:
+			sb.WriteString(" (provisional)")
+		case c.Provisional.After.IsProvisional():
+			sb.WriteString("; now provisional")
+		default:
+			sb.WriteString("; no longer provisional")
+		}
+	}
+	sb.WriteRune('\n')
+	for _, p := range c.Properties {
+		fmt.Fprintf(sb, "  - %s: %s → %s\n", p.Name, code(p.Before), code(p.After))
+	}
+}
+
+func code(value string) string {
+	if value == "" {
+		return "_none_"
+	}
+	return "`" + value + "`"
+}
+
+func capitalize(s string) string {
+	r := []rune(s)
+	if len(r) > 0 {
+		r[0] = unicode.ToUpper(r[0])
+	}
+	return string(r)
+}
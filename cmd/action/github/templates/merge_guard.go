@@ -9,20 +9,20 @@ import (
 )
 
 type Violation struct {
-	EntityName string
-	EntityType string
-	SourceLink string
-	SourceLine int
-	Violations []string
+	EntityName string   `json:"entityName"`
+	EntityType string   `json:"entityType"`
+	SourceLink string   `json:"sourceLink,omitempty"`
+	SourceLine int      `json:"sourceLine"`
+	Violations []string `json:"violations"`
 }
 
 type ViolationFile struct {
-	Path       string
-	Violations []Violation
+	Path       string      `json:"path"`
+	Violations []Violation `json:"violations"`
 }
 
 type ViolationComment struct {
-	Files []ViolationFile
+	Files []ViolationFile `json:"files"`
 }
 
 //go:embed merge_guard
@@ -9,21 +9,14 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
-	"slices"
 
-	"github.com/mailgun/raymond/v2"
 	"github.com/project-chip/alchemy/cmd/action/github"
 	"github.com/project-chip/alchemy/cmd/action/github/templates"
 	"github.com/project-chip/alchemy/cmd/cli"
 	"github.com/project-chip/alchemy/config"
-	"github.com/project-chip/alchemy/errdiff"
 	"github.com/project-chip/alchemy/internal/files"
 	"github.com/project-chip/alchemy/internal/pipeline"
-	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/spec"
-	"github.com/project-chip/alchemy/matter/types"
-	"github.com/project-chip/alchemy/mle"
-	"github.com/project-chip/alchemy/provisional"
 	"github.com/sethvargo/go-githubactions"
 )
 
@@ -109,30 +102,28 @@ func (c *MergeGuard) Run(cc *cli.Context) (err error) {
 	var out bytes.Buffer
 	writer := files.NewPatcher[string]("Generating patch file...", &out)
 
-	specs, err := spec.LoadSpecPullRequest(cc, baseRoot, headRoot, pipelineOptions)
+	specs, cleanup, err := spec.LoadSpecPullRequest(cc, spec.PullRequestOptions{BaseRoot: baseRoot, HeadRoot: headRoot}, pipelineOptions)
 	if err != nil {
 		return fmt.Errorf("failed to load specs: %v", err)
 	}
+	defer cleanup()
 
-	var vp map[string][]spec.Violation
-	vp, err = provisional.ProcessSpecs(cc, &specs, pipelineOptions, writer)
+	violations, err := cli.MergeGuardViolations(cc, &specs, headRoot, pipelineOptions, writer)
 	if err != nil {
-		return fmt.Errorf("failed checking provisional status: %v", err)
+		return err
 	}
 
-	var ve map[string][]spec.Violation = errdiff.ProcessComparison(&specs)
+	owner, repo := githubContext.Repo()
 
-	var vm map[string][]spec.Violation
-	vm, err = mle.Process(headRoot, specs.Head)
+	var vc templates.ViolationComment
+	vc, err = cli.MergeGuardComment(violations, headRoot, func(relPath string, line int) string {
+		pathHash := sha256.Sum256([]byte(relPath))
+		return fmt.Sprintf("https://github.com/%s/%s/pull/%d/files#diff-%sR%d", owner, repo, pr.GetNumber(), hex.EncodeToString(pathHash[:]), line)
+	})
 	if err != nil {
-		return fmt.Errorf("failed checking Master List Enforcer status: %v", err)
+		return
 	}
 
-	violations := spec.MergeViolations(vp, ve, vm)
-
-	owner, repo := githubContext.Repo()
-
-	var comment string
 	if len(violations) > 0 {
 		action.SetOutput("merge_guard_status", "violations")
 
@@ -140,97 +131,14 @@ func (c *MergeGuard) Run(cc *cli.Context) (err error) {
 		if err != nil {
 			return fmt.Errorf("failed saving provisional patch: %v", err)
 		}
-
-		var t *raymond.Template
-		t, err = templates.LoadMergeGuardViolationsTemplate()
-		if err != nil {
-			err = fmt.Errorf("error loading violation template: %w", err)
-			return
-		}
-
-		vc := templates.ViolationComment{}
-
-		var paths []string
-		for path := range violations {
-			paths = append(paths, path)
-		}
-
-		slices.Sort(paths)
-
-		for _, path := range paths {
-			vs := violations[path]
-			slices.SortFunc(vs, func(a spec.Violation, b spec.Violation) int {
-				return a.Line - b.Line
-			})
-
-			var relPath string
-			relPath, err = filepath.Rel(headRoot, path)
-			if err != nil {
-				err = fmt.Errorf("the relative path could not be determined. Path: %s, Root: %s", path, headRoot)
-				return
-			}
-
-			vf := templates.ViolationFile{Path: relPath}
-			for _, v := range vs {
-				vv := templates.Violation{}
-
-				if v.Entity != nil {
-					vv.EntityName = matter.EntityName(v.Entity)
-					vv.EntityType = entityTypeName(v.Entity)
-
-					parent := v.Entity.Parent()
-					for {
-						if parent == nil {
-							break
-						}
-						vv.EntityName = matter.EntityName(parent) + "." + vv.EntityName
-						parent = parent.Parent()
-					}
-				} else {
-					vv.EntityName = "-"
-					vv.EntityType = "-"
-				}
-
-				pathHash := sha256.Sum256([]byte(relPath))
-				vv.SourceLink = fmt.Sprintf("https://github.com/%s/%s/pull/%d/files#diff-%sR%d", owner, repo, pr.GetNumber(), hex.EncodeToString(pathHash[:]), v.Line)
-				vv.SourceLine = v.Line
-				if v.Type.Has(spec.ViolationTypeNonProvisional) {
-					vv.Violations = append(vv.Violations, "Not marked Provisional")
-				}
-				if v.Type.Has(spec.ViolationTypeNotIfDefd) {
-					vv.Violations = append(vv.Violations, "Not in in-progress ifdef")
-				}
-				if v.Type.Has(spec.ViolationNewParseError) {
-					vv.Violations = append(vv.Violations, "New Parse Error introduced by this PR: "+v.Text)
-				}
-				if v.Type.Has(spec.ViolationMasterList) {
-					vv.Violations = append(vv.Violations, "Incompatible with Master List: "+v.Text)
-				}
-				vf.Violations = append(vf.Violations, vv)
-			}
-			vc.Files = append(vc.Files, vf)
-		}
-
-		tc := map[string]any{
-			"comment": vc,
-		}
-		comment, err = t.Exec(tc)
-		if err != nil {
-			return
-		}
 	} else {
 		action.SetOutput("merge_guard_status", "no_violations")
+	}
 
-		var t *raymond.Template
-		t, err = templates.LoadMergeGuardNoViolationsTemplate()
-		if err != nil {
-			err = fmt.Errorf("error loading no violation template: %w", err)
-			return
-		}
-		comment, err = t.Exec(map[string]any{})
-		if err != nil {
-			return
-		}
+	var comment string
+	comment, err = cli.RenderMergeGuardComment(vc)
+	if err != nil {
+		return
 	}
 
 	if c.WriteComment {
@@ -248,35 +156,3 @@ func (c *MergeGuard) Run(cc *cli.Context) (err error) {
 
 	return
 }
-
-func entityTypeName(e types.Entity) string {
-	switch e := e.(type) {
-	case *matter.Struct:
-		return "Struct"
-	case *matter.Feature:
-		return "Feature"
-	case *matter.Field:
-		switch e.EntityType() {
-		case types.EntityTypeAttribute:
-			return "Attribute"
-		case types.EntityTypeEventField:
-			return "Event Field"
-		case types.EntityTypeCommandField:
-			return "Command Field"
-		case types.EntityTypeStructField:
-			return "Struct Field"
-		default:
-			return "Field"
-		}
-	case *matter.Bitmap:
-		return "Bitmap"
-	case *matter.Enum:
-		return "Enum"
-	case *matter.EnumValue:
-		return "Enum Value"
-	case matter.Bit:
-		return "Bit"
-	default:
-		return e.EntityType().String()
-	}
-}
@@ -15,10 +15,21 @@ var commands struct {
 	ZAP           cli.ZAP           `cmd:"" help:"transmute the Matter spec into ZAP templates, optionally filtered to the files specified by filename_pattern" group:"SDK Commands:"`
 	IDL           cli.IDL           `cmd:"" help:"commands for manipulating Matter IDLs" group:"SDK Commands:"`
 	ZAPDiff       cli.ZAPDiff       `cmd:"" name:"zap-diff" help:"Compares two set of ZAP XMLs for any inconsistency." group:"SDK Commands:"`
+	ZAPImport     cli.ZAPImport     `cmd:"" name:"zap-import" help:"build the Matter object model from the SDK's ZAP templates and render it" group:"SDK Commands:"`
+	ZAPCheck      cli.ZAPCheck      `cmd:"" name:"zap-check" help:"check the endpoints of the SDK's .zap app configurations against the device type requirements of the Matter spec" group:"SDK Commands:"`
+	Drift         cli.Drift         `cmd:"" help:"compare the Matter spec object model with the one built from the SDK's ZAP templates" group:"SDK Commands:"`
 	MLE           cli.MLE           `cmd:"" help:"master list enforcer checks for inconsistencies between the master list and spec." group:"Spec Commands:"`
 	Conformance   cli.Conformance   `cmd:"" help:"test conformance values"  group:"Spec Commands:"`
+	DeviceType    cli.DeviceType    `cmd:"" name:"device-type" help:"commands for inspecting Matter device types" group:"Spec Commands:"`
+	CheckDevice   cli.CheckDevice   `cmd:"" name:"check-device" help:"check a description of a device against the requirements of the Matter spec" group:"Spec Commands:"`
+	CheckValue    cli.CheckValue    `cmd:"" name:"check-value" help:"check JSON values for a command, event, struct or attribute against the data types and constraints of the Matter spec" group:"Spec Commands:"`
+	TLV           cli.TLV           `cmd:"" name:"tlv" help:"commands for encoding and decoding Matter TLV payloads using the Matter spec" group:"Spec Commands:"`
 	Dump          dump.Command      `cmd:"" hidden:"" help:"dump the parse tree of Matter documents specified by filename_pattern"`
 	DM            cli.DataModel     `cmd:"" help:"transmute the Matter spec into data model XML; optionally filtered to the files specified in filename_pattern" group:"SDK Commands:"`
+	JSONSchema    cli.JSONSchema    `cmd:"" name:"json-schema" help:"transmute the Matter spec into JSON Schema for the data types, commands and events of each cluster, optionally filtered to the files specified by filename_pattern" group:"SDK Commands:"`
+	Proto         cli.Proto         `cmd:"" name:"proto" help:"transmute the Matter spec into Protocol Buffers messages and enums for each cluster, optionally filtered to the files specified by filename_pattern" group:"SDK Commands:"`
+	TypeScript    cli.TypeScript    `cmd:"" name:"typescript" help:"transmute the Matter spec into TypeScript modules declaring the IDs, data types, attributes, commands and events of each cluster, optionally filtered to the files specified by filename_pattern" group:"SDK Commands:"`
+	GoGen         cli.GoGen         `cmd:"" name:"go-gen" help:"transmute the Matter spec into Go packages declaring the IDs and data types of each cluster, optionally filtered to the files specified by filename_pattern" group:"SDK Commands:"`
 	TestPlan      cli.TestPlan      `cmd:"" name:"test-plan" aliases:"testplan" help:"create an initial test plan from the spec, optionally filtered to the files specified in filename_pattern" group:"Testing Commands:"`
 	TestScript    cli.TestScript    `cmd:"" name:"test-script" aliases:"testscript" help:"create shell python scripts from the spec, optionally filtered to the files specified by filename_pattern" group:"Testing Commands:"`
 	Provisional   cli.Provisional   `cmd:"" name:"provisional" hidden:"" group:"Provisional:"`
@@ -26,7 +37,11 @@ var commands struct {
 	Yaml2Python   cli.Yaml2Python   `cmd:"" name:"yaml-2-python" aliases:"yaml2python" help:"create a shell python script from a test YAML, optionally filtered to the files specified by filename_pattern"  group:"Testing Commands:"`
 	Wordlist      cli.Wordlist      `cmd:"" hidden:"" name:"wordlist" help:"add words to wordlist.txt"`
 	StripComments cli.StripComments `cmd:"" hidden:"" name:"strip-comments" help:"removes comments from a file"`
+	LSP           cli.LSP           `cmd:"" name:"lsp" help:"run a Language Server Protocol server for Matter spec documents over stdio" group:"Spec Commands:"`
 	ErrDiff       cli.ErrDiff       `cmd:"" name:"err-diff" hidden:"" help:"Checks for new errors caused by a PR in review."`
+	Changelog     cli.Changelog     `cmd:"" name:"changelog" help:"list the changes to clusters and device types between two versions of the spec, as Markdown or JSON" group:"Spec Commands:"`
+	MergeGuard    cli.MergeGuard    `cmd:"" name:"merge-guard" help:"run the checks of the merge guard GitHub action over two versions of the spec, and print its verdict as Markdown or JSON" group:"Spec Commands:"`
+	RevisionCheck cli.RevisionCheck `cmd:"" name:"revision-check" help:"checks that clusters and device types changed by a PR in review have new revisions, and that new revisions have changes" group:"Spec Commands:"`
 	Version       Version           `cmd:"" hidden:"" name:"version" help:"display version number"`
 
 	globalFlags `embed:""`
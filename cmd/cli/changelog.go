@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"io"
+
+	"github.com/project-chip/alchemy/changelog"
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+type Changelog struct {
+	common.ASCIIDocAttributes  `embed:""`
+	pipeline.ProcessingOptions `embed:""`
+	spec.PullRequestOptions    `embed:""`
+
+	Output     string `default:"markdown" enum:"markdown,json" help:"output format for the changelog; 'markdown' or 'json'" group:"Output:"`
+	OutputPath string `name:"output-path" help:"path to write the changelog to; defaults to stdout" group:"Output:"`
+}
+
+func (c *Changelog) Run(cc *Context) (err error) {
+	var base, head *spec.Specification
+	base, _, err = spec.Parse(cc, c.PullRequestOptions.Base(), c.ProcessingOptions, nil, c.ASCIIDocAttributes.ToList())
+	if err != nil {
+		return
+	}
+	head, _, err = spec.Parse(cc, c.PullRequestOptions.Head(), c.ProcessingOptions, nil, c.ASCIIDocAttributes.ToList())
+	if err != nil {
+		return
+	}
+
+	cl := changelog.Compare(base, head)
+
+	switch c.Output {
+	case "json":
+		return writeOutput(c.OutputPath, writeJSON(cl))
+	default:
+		return writeOutput(c.OutputPath, func(w io.Writer) (err error) {
+			_, err = io.WriteString(w, cl.Markdown())
+			return
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/compliance"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+type CheckDevice struct {
+	common.ASCIIDocAttributes  `embed:""`
+	spec.ParserOptions         `embed:""`
+	pipeline.ProcessingOptions `embed:""`
+
+	Device     string `arg:"" help:"path to a JSON or YAML description of the device's endpoints, clusters and attributes" required:""`
+	Output     string `default:"log" enum:"log,json" help:"output format for violations; 'log' or 'json'" group:"Output:"`
+	OutputPath string `name:"output-path" help:"path to write violations to; defaults to stdout" group:"Output:"`
+}
+
+func (c *CheckDevice) Run(cc *Context) (err error) {
+	var device *compliance.Device
+	device, err = compliance.LoadDevice(c.Device)
+	if err != nil {
+		return
+	}
+
+	var specification *spec.Specification
+	specification, _, err = spec.Parse(cc, c.ParserOptions, c.ProcessingOptions, nil, c.ASCIIDocAttributes.ToList())
+	if err != nil {
+		return
+	}
+
+	violations := compliance.Check(specification, device)
+	err = writeViolations(c.Output, c.OutputPath, violations)
+	if err != nil {
+		return
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("device %s has %d violations", c.Device, len(violations))
+	}
+	return
+}
+
+func writeViolations(output string, outputPath string, violations []compliance.Violation) (err error) {
+	switch output {
+	case "json":
+		if violations == nil {
+			violations = []compliance.Violation{}
+		}
+		return writeOutput(outputPath, writeJSON(violations))
+	default:
+		for _, v := range violations {
+			attrs := []any{slog.Uint64("endpoint", v.Endpoint), slog.String("type", v.Type.String())}
+			if v.Source != "" {
+				attrs = append(attrs, slog.String("source", v.Source))
+			}
+			slog.Warn(v.Message, attrs...)
+		}
+	}
+	return
+}
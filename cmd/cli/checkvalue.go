@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/constraint"
+)
+
+type CheckValue struct {
+	PayloadTarget `embed:""`
+
+	Paths  []string `arg:"" optional:"" help:"paths to JSON files holding the values to check; read from stdin if omitted or -"`
+	Output string   `default:"log" enum:"log,json" help:"output format for violations; 'log' or 'json'" group:"Output:"`
+}
+
+type valueViolation struct {
+	Source string `json:"source,omitempty"`
+	constraint.Violation
+}
+
+func (c *CheckValue) Run(cc *Context) (err error) {
+	if c.Cluster == "" {
+		return fmt.Errorf("--cluster is required")
+	}
+	var pe payloadEntity
+	pe, err = c.resolve(cc)
+	if err != nil {
+		return
+	}
+
+	paths := c.Paths
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+	var violations []valueViolation
+	for _, path := range paths {
+		var vs []constraint.Violation
+		vs, err = checkValueFile(path, pe)
+		if err != nil {
+			return
+		}
+		for _, v := range vs {
+			violations = append(violations, valueViolation{Source: path, Violation: v})
+		}
+	}
+
+	switch c.Output {
+	case "json":
+		if violations == nil {
+			violations = []valueViolation{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		err = encoder.Encode(violations)
+		if err != nil {
+			return
+		}
+	default:
+		for _, v := range violations {
+			slog.Warn(v.Message, slog.String("source", v.Source), slog.String("path", v.Path), slog.String("type", v.Type.String()))
+		}
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("found %d violations", len(violations))
+	}
+	return
+}
+
+func checkValueFile(path string, pe payloadEntity) (violations []constraint.Violation, err error) {
+	var b []byte
+	if path == "-" {
+		b, err = io.ReadAll(os.Stdin)
+	} else {
+		b, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var value any
+	err = decoder.Decode(&value)
+	if err != nil {
+		err = fmt.Errorf("error parsing %s: %w", path, err)
+		return
+	}
+	if pe.attribute != nil {
+		violations = matter.ValidateValue(pe.attribute, value, pe.cluster.Attributes)
+		return
+	}
+	o, ok := value.(map[string]any)
+	if !ok {
+		err = fmt.Errorf("%s does not hold a JSON object", path)
+		return
+	}
+	if pe.s != nil {
+		violations = matter.ValidateStruct(pe.s, o)
+	} else {
+		violations = matter.ValidateValues(pe.fields, o)
+	}
+	return
+}
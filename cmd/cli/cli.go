@@ -10,4 +10,8 @@ type Context struct {
 	context.Context
 
 	Kong *kong.Context
+
+	// IgnoreLoggedErrors is set by commands which decide their own exit code, so that errors logged while they
+	// run don't force a failing exit code
+	IgnoreLoggedErrors bool
 }
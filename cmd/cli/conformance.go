@@ -2,17 +2,33 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/featuremap"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
 )
 
 type Conformance struct {
+	ConformanceExplain  ConformanceExplain  `cmd:"" name:"explain" default:"withargs" help:"explain a conformance string, and optionally evaluate it"`
+	ConformanceCluster  ConformanceCluster  `cmd:"" name:"cluster" help:"evaluate the conformance of every element of a cluster against a concrete implementation"`
+	ConformanceFeatures ConformanceFeatures `cmd:"" name:"features" help:"enumerate the valid FeatureMap values of a cluster, with the mandatory and optional elements of each"`
+}
+
+type ConformanceExplain struct {
 	Conformance string   `arg:"" help:"conformance string" required:""`
-	Params      []string `arg:"" help:"parameters to use to evaluate conformance" optional:""`
+	Params      []string `arg:"" help:"parameters to use to evaluate conformance; identifiers, or name=value pairs for comparisons" optional:""`
 }
 
-func (cmd *Conformance) Run(cc *Context) (err error) {
+func (cmd *ConformanceExplain) Run(cc *Context) (err error) {
 	if len(cmd.Conformance) == 0 {
 		// TODO: re-add usage
 		return nil
@@ -23,7 +39,15 @@ func (cmd *Conformance) Run(cc *Context) (err error) {
 		var cxt conformance.BasicContext
 		cxt.Values = make(map[string]any)
 		for _, arg := range cmd.Params {
-			cxt.Values[arg] = true
+			name, value, ok := strings.Cut(arg, "=")
+			if !ok {
+				cxt.Values[arg] = true
+				continue
+			}
+			cxt.Values[name], err = parseConformanceValue(value)
+			if err != nil {
+				return err
+			}
 		}
 		crm, err := c.Eval(&cxt)
 		if err != nil {
@@ -33,3 +57,183 @@ func (cmd *Conformance) Run(cc *Context) (err error) {
 	}
 	return nil
 }
+
+type ConformanceCluster struct {
+	common.ASCIIDocAttributes  `embed:""`
+	spec.ParserOptions         `embed:""`
+	pipeline.ProcessingOptions `embed:""`
+
+	Cluster    string            `arg:"" help:"name of the cluster to evaluate" required:""`
+	Feature    []string          `help:"code of a supported feature; all other features are unsupported" group:"Implementation:"`
+	FeatureMap string            `name:"feature-map" help:"value of the FeatureMap attribute, e.g. 0x0005; its features are supported along with any passed with --feature" group:"Implementation:"`
+	Attribute  []string          `name:"has-attribute" help:"name of an implemented attribute; if none are provided, attributes are evaluated by their own conformance" group:"Implementation:"`
+	Command    []string          `name:"has-command" help:"name of an implemented command; if none are provided, commands are evaluated by their own conformance" group:"Implementation:"`
+	Revision   int64             `help:"implemented revision of the cluster" group:"Implementation:"`
+	Value      map[string]string `help:"value of an identifier used in comparisons, e.g. MaxLevel=254" group:"Implementation:"`
+	Evaluate   string            `help:"conformance string to evaluate against the implementation, instead of the cluster's elements" group:"Implementation:"`
+	Mandatory  bool              `help:"only list mandatory elements" group:"Implementation:"`
+}
+
+func (cmd *ConformanceCluster) Run(cc *Context) (err error) {
+	var specification *spec.Specification
+	specification, _, err = spec.Parse(cc, cmd.ParserOptions, cmd.ProcessingOptions, nil, cmd.ASCIIDocAttributes.ToList())
+	if err != nil {
+		return
+	}
+	cluster, ok := specification.ClustersByName[cmd.Cluster]
+	if !ok {
+		return fmt.Errorf("unknown cluster: %s", cmd.Cluster)
+	}
+
+	implementation := matter.ClusterImplementation{
+		Features:   cmd.Feature,
+		Attributes: cmd.Attribute,
+		Commands:   cmd.Command,
+		Revision:   conformance.Revision(cmd.Revision),
+		Values:     make(map[string]any, len(cmd.Value)),
+	}
+	if cmd.FeatureMap != "" {
+		implementation.FeatureMap, err = strconv.ParseUint(cmd.FeatureMap, 0, 64)
+		if err != nil {
+			return fmt.Errorf("invalid feature map %q: %w", cmd.FeatureMap, err)
+		}
+	}
+	for name, value := range cmd.Value {
+		implementation.Values[name], err = parseConformanceValue(value)
+		if err != nil {
+			return
+		}
+	}
+
+	if cmd.Evaluate != "" {
+		var cxt *conformance.BasicContext
+		cxt, err = implementation.Context(cluster)
+		if err != nil {
+			return
+		}
+		c := conformance.ParseConformance(cmd.Evaluate)
+		fmt.Fprintf(os.Stdout, "description: %s\n", c.Description())
+		var state conformance.ConformanceState
+		state, err = c.Eval(cxt)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(os.Stdout, "conformance: %v\n", state)
+		return
+	}
+
+	var elements []matter.ElementConformance
+	elements, err = matter.EvaluateClusterConformance(cluster, implementation)
+	if err != nil {
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tNAME\tCONFORMANCE\tSTATE\tCONFIDENCE")
+	for _, e := range elements {
+		if cmd.Mandatory && e.State.State != conformance.StateMandatory {
+			continue
+		}
+		entityType := e.Entity.EntityType()
+		name := matter.EntityName(e.Entity)
+		switch e.Parent.(type) {
+		case *matter.Cluster:
+		case *matter.Features:
+			entityType = types.EntityTypeFeature
+		default:
+			name = matter.EntityName(e.Parent) + "." + name
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", entityType, name, e.Conformance.ASCIIDocString(), e.State.State, e.State.Confidence)
+	}
+	return w.Flush()
+}
+
+type ConformanceFeatures struct {
+	common.ASCIIDocAttributes  `embed:""`
+	spec.ParserOptions         `embed:""`
+	pipeline.ProcessingOptions `embed:""`
+
+	Cluster    string `arg:"" help:"name of the cluster to enumerate" required:""`
+	Limit      int    `default:"64" help:"most combinations to list; if more are valid, a random sample is listed; 0 lists them all, if there are few enough to count" group:"Combinations:"`
+	Seed       uint64 `help:"seed for the random sample of combinations" group:"Combinations:"`
+	Output     string `default:"text" enum:"text,json" help:"output format for the combinations; 'text' or 'json'" group:"Output:"`
+	OutputPath string `name:"output-path" help:"path to write the combinations to; defaults to stdout" group:"Output:"`
+}
+
+func (cmd *ConformanceFeatures) Run(cc *Context) (err error) {
+	var specification *spec.Specification
+	specification, _, err = spec.Parse(cc, cmd.ParserOptions, cmd.ProcessingOptions, nil, cmd.ASCIIDocAttributes.ToList())
+	if err != nil {
+		return
+	}
+	cluster, ok := specification.ClustersByName[cmd.Cluster]
+	if !ok {
+		return fmt.Errorf("unknown cluster: %s", cmd.Cluster)
+	}
+
+	var combinations *featuremap.Combinations
+	combinations, err = featuremap.Enumerate(cluster, featuremap.Options{Limit: cmd.Limit, Seed: cmd.Seed})
+	if err != nil {
+		return
+	}
+
+	switch cmd.Output {
+	case "json":
+		return writeOutput(cmd.OutputPath, writeJSON(combinations))
+	default:
+		return writeOutput(cmd.OutputPath, func(w io.Writer) error {
+			return writeFeatureCombinations(w, combinations, cmd.Seed)
+		})
+	}
+}
+
+func writeFeatureCombinations(w io.Writer, combinations *featuremap.Combinations, seed uint64) (err error) {
+	count := strconv.Itoa(combinations.Count)
+	if combinations.Partial {
+		count = "at least " + count
+	}
+	fmt.Fprintf(w, "%s (%s): %s valid FeatureMap values\n", combinations.Cluster, combinations.ID, count)
+	if combinations.Sampled {
+		fmt.Fprintf(w, "sample of %d, seed %d\n", len(combinations.Combinations), seed)
+	}
+	for _, c := range combinations.Combinations {
+		features := "none"
+		if len(c.Features) > 0 {
+			features = strings.Join(c.Features, ", ")
+		}
+		fmt.Fprintf(w, "\nFeatureMap 0x%04X: %s\n", c.FeatureMap, features)
+		for _, list := range []struct {
+			name     string
+			elements []featuremap.Element
+		}{{"mandatory", c.Mandatory}, {"optional", c.Optional}} {
+			if len(list.elements) == 0 {
+				continue
+			}
+			fmt.Fprintf(w, "  %s:\n", list.name)
+			for _, e := range list.elements {
+				_, err = fmt.Fprintf(w, "    %s %s (%s)\n", e.Type, e.Name, e.Conformance)
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
+	return
+}
+
+// parseConformanceValue converts a value provided on the command line into the most specific type
+// conformance comparisons understand
+func parseConformanceValue(value string) (any, error) {
+	if i, err := strconv.ParseInt(value, 0, 64); err == nil {
+		return i, nil
+	}
+	if u, err := strconv.ParseUint(value, 0, 64); err == nil {
+		return u, nil
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f, nil
+	}
+	if b, err := strconv.ParseBool(value); err == nil {
+		return b, nil
+	}
+	return nil, fmt.Errorf("unable to parse conformance value: %s", value)
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+type DeviceType struct {
+	DeviceTypeResolve DeviceTypeResolve `cmd:"" name:"resolve" help:"list the effective requirements of a device type, merging those of its base, subset and composed device types"`
+}
+
+type DeviceTypeResolve struct {
+	common.ASCIIDocAttributes  `embed:""`
+	spec.ParserOptions         `embed:""`
+	pipeline.ProcessingOptions `embed:""`
+
+	DeviceType string `arg:"" help:"name or ID of the device type to resolve" required:""`
+	Output     string `default:"json" enum:"json,asciidoc" help:"output format; 'json' or 'asciidoc'" group:"Output:"`
+	OutputPath string `name:"output-path" help:"path to write the requirements to; defaults to stdout" group:"Output:"`
+}
+
+func (c *DeviceTypeResolve) Run(cc *Context) (err error) {
+	var specification *spec.Specification
+	specification, _, err = spec.Parse(cc, c.ParserOptions, c.ProcessingOptions, nil, c.ASCIIDocAttributes.ToList())
+	if err != nil {
+		return
+	}
+
+	deviceType, ok := specification.DeviceTypesByName[c.DeviceType]
+	if !ok {
+		id := matter.ParseNumber(c.DeviceType)
+		if id.Valid() {
+			deviceType, ok = specification.DeviceTypesByID[id.Value()]
+		}
+	}
+	if !ok {
+		return fmt.Errorf("unknown device type: %s", c.DeviceType)
+	}
+
+	var resolved *matter.ResolvedEndpoint
+	resolved, err = specification.ResolveDeviceType(deviceType)
+	if err != nil {
+		return
+	}
+
+	switch c.Output {
+	case "asciidoc":
+		return writeOutput(c.OutputPath, func(w io.Writer) error {
+			return writeResolvedEndpoint(w, resolved, 1)
+		})
+	default:
+		return writeOutput(c.OutputPath, writeJSON(resolved))
+	}
+}
+
+// writeResolvedEndpoint renders endpoint and its children as AsciiDoc before writing it to w in one go, so that only
+// a single write can fail
+func writeResolvedEndpoint(w io.Writer, endpoint *matter.ResolvedEndpoint, level int) (err error) {
+	var b bytes.Buffer
+	renderResolvedEndpoint(&b, endpoint, level)
+	_, err = w.Write(b.Bytes())
+	return
+}
+
+func renderResolvedEndpoint(w *bytes.Buffer, endpoint *matter.ResolvedEndpoint, level int) {
+	heading := strings.Repeat("=", level+1)
+	fmt.Fprintf(w, "%s %s (%s)\n\n", heading, endpoint.DeviceTypeName, endpoint.Location)
+	if !conformance.IsBlank(endpoint.Conformance) {
+		fmt.Fprintf(w, "Conformance: %s\n\n", endpoint.Conformance.ASCIIDocString())
+	}
+
+	if len(endpoint.Clusters) > 0 {
+		fmt.Fprintf(w, "%s= Cluster Requirements\n\n|===\n| ID | Cluster | Client/Server | Conformance | Origin | Declared By\n", heading)
+		for _, cr := range endpoint.Clusters {
+			fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s\n", cr.ClusterID.HexString(), cr.ClusterName, cr.Interface, tableCell(cr.Conformance.ASCIIDocString()), cr.Origin, cr.DeclaredBy)
+		}
+		fmt.Fprint(w, "|===\n\n")
+	}
+
+	var hasElements bool
+	for _, cr := range endpoint.Clusters {
+		if len(cr.Features)+len(cr.Attributes)+len(cr.Commands)+len(cr.Events) > 0 {
+			hasElements = true
+			break
+		}
+	}
+	if hasElements {
+		fmt.Fprintf(w, "%s= Element Requirements\n\n|===\n| ID | Cluster | Element | Name | Constraint | Conformance | Origin | Declared By\n", heading)
+		for _, cr := range endpoint.Clusters {
+			for _, elements := range [][]*matter.ResolvedElementRequirement{cr.Features, cr.Attributes, cr.Commands, cr.Events} {
+				for _, er := range elements {
+					name := er.Name
+					if er.Field != "" {
+						name += "." + er.Field
+					}
+					fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s | %s | %s\n", cr.ClusterID.HexString(), cr.ClusterName, er.Element, name, tableCell(constraintString(er.Constraint)), tableCell(er.Conformance.ASCIIDocString()), er.Origin, er.DeclaredBy)
+				}
+			}
+		}
+		fmt.Fprint(w, "|===\n\n")
+	}
+
+	if len(endpoint.Tags) > 0 {
+		fmt.Fprintf(w, "%s= Tag Requirements\n\n|===\n| Namespace | Tag | Constraint | Conformance | Origin | Declared By\n", heading)
+		for _, tr := range endpoint.Tags {
+			fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s\n", tr.NamespaceName, tr.SemanticTagName, tableCell(constraintString(tr.Constraint)), tableCell(tr.Conformance.ASCIIDocString()), tr.Origin, tr.DeclaredBy)
+		}
+		fmt.Fprint(w, "|===\n\n")
+	}
+
+	for _, child := range endpoint.Endpoints {
+		renderResolvedEndpoint(w, child, level+1)
+	}
+}
+
+func constraintString(c constraint.Constraint) string {
+	if c == nil {
+		return ""
+	}
+	return c.ASCIIDocString(nil)
+}
+
+// tableCell escapes cell separators in s
+func tableCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
@@ -1,11 +1,15 @@
 package cli
 
 import (
+	"context"
+	"log/slog"
+
 	"github.com/project-chip/alchemy/asciidoc/render"
 	"github.com/project-chip/alchemy/cmd/common"
 	"github.com/project-chip/alchemy/disco"
 	"github.com/project-chip/alchemy/internal/files"
 	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/internal/watch"
 	"github.com/project-chip/alchemy/matter/spec"
 )
 
@@ -17,13 +21,31 @@ type Disco struct {
 	pipeline.ProcessingOptions `embed:""`
 	render.RenderOptions       `embed:""`
 	files.OutputOptions        `embed:""`
+	watch.Options              `embed:""`
 
 	Paths []string `arg:"" optional:"" help:"The paths of AsciiDoc files to disco-ball. If not specified, all files will be disco-balled."`
 }
 
 func (d *Disco) Run(cc *Context) (err error) {
+	err = d.discoBall(cc, d.Paths)
+	if !d.Watch {
+		return
+	}
+	if err != nil {
+		slog.Error("Error disco-balling", slog.Any("error", err))
+	}
+	return watch.Run(cc, []string{d.ParserOptions.Root}, []string{".adoc"}, d.Options, func(cxt context.Context, changed []string) error {
+		changed, err := restrictPaths(changed, d.Paths)
+		if err != nil || len(changed) == 0 {
+			return err
+		}
+		return d.discoBall(cxt, changed)
+	})
+}
+
+func (d *Disco) discoBall(cxt context.Context, paths []string) (err error) {
 	writer := files.NewWriter[string]("Writing disco-balled docs", d.OutputOptions)
 
-	err = disco.Pipeline(cc, d.ParserOptions, d.Paths, d.ProcessingOptions, d.DiscoOptions, d.ASCIIDocAttributes.ToList(), d.RenderOptions.ToOptions(), writer)
+	err = disco.Pipeline(cxt, d.ParserOptions, paths, d.ProcessingOptions, d.DiscoOptions, d.ASCIIDocAttributes.ToList(), d.RenderOptions.ToOptions(), writer)
 	return
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/project-chip/alchemy/dm"
 	"github.com/project-chip/alchemy/internal/files"
 	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/internal/watch"
 	"github.com/project-chip/alchemy/matter/spec"
 )
 
@@ -16,33 +17,41 @@ type DataModel struct {
 	pipeline.ProcessingOptions `embed:""`
 	files.OutputOptions        `embed:""`
 	dm.DataModelOptions        `embed:""`
+	watch.Options              `embed:""`
 
 	spec.FilterOptions `embed:""`
 }
 
 func (c *DataModel) Run(cc *Context) (err error) {
+	if c.Watch {
+		return watchSpec(cc, c.ParserOptions, c.Options, c.FilterOptions, c.generate)
+	}
+	_, err = c.generate(cc, c.FilterOptions)
+	return
+}
+
+func (c *DataModel) generate(cc *Context, filterOptions spec.FilterOptions) (specification *spec.Specification, err error) {
 
 	builderOptions := []spec.BuilderOption{spec.IgnoreHierarchy(c.IgnoreHierarchy)}
 
-	var specification *spec.Specification
 	var specDocs spec.DocSet
 	specification, specDocs, err = spec.Parse(cc, c.ParserOptions, c.ProcessingOptions, builderOptions, c.ASCIIDocAttributes.ToList())
 
 	if err != nil {
-		return err
+		return
 	}
 
-	specDocs, err = filterSpecDocs(cc, specDocs, specification, c.FilterOptions, c.ProcessingOptions)
+	specDocs, err = filterSpecDocs(cc, specDocs, specification, filterOptions, c.ProcessingOptions)
 	if err != nil {
 		return
 	}
 
-	specDocs, err = filterSpecErrors(cc, specDocs, specification, c.FilterOptions, c.ProcessingOptions)
+	specDocs, err = filterSpecErrors(cc, specDocs, specification, filterOptions, c.ProcessingOptions)
 	if err != nil {
 		return
 	}
 
-	err = checkSpecErrors(cc, specification, c.FilterOptions, specDocs)
+	err = checkSpecErrors(cc, specification, filterOptions, specDocs)
 	if err != nil {
 		return
 	}
@@ -55,9 +64,14 @@ func (c *DataModel) Run(cc *Context) (err error) {
 		slog.Warn("The following Asciidoctor files exist on disk, but were not used in parsing the specification. If this seems incorrect, check to make sure they are included properly.", slog.GroupAttrs("paths", unuseds...))
 	}
 
-	dataModelRenderer := dm.NewRenderer(c.DmRoot, specification)
+	err = renderDataModel(cc, specification, specDocs, c.DmRoot, c.ProcessingOptions, c.OutputOptions)
+	return
+}
+
+func renderDataModel(cc *Context, specification *spec.Specification, specDocs spec.DocSet, dmRoot string, processingOptions pipeline.ProcessingOptions, outputOptions files.OutputOptions) (err error) {
+	dataModelRenderer := dm.NewRenderer(dmRoot, specification)
 
-	dataModelDocs, err := pipeline.Parallel(cc, c.ProcessingOptions, dataModelRenderer, specDocs)
+	dataModelDocs, err := pipeline.Parallel(cc, processingOptions, dataModelRenderer, specDocs)
 	if err != nil {
 		return err
 	}
@@ -78,8 +92,8 @@ func (c *DataModel) Run(cc *Context) (err error) {
 	}
 	dataModelDocs.Store(clusterIDJSON.Path, clusterIDJSON)
 
-	writer := files.NewWriter[string]("Writing data model", c.OutputOptions)
-	err = writer.Write(cc, dataModelDocs, c.ProcessingOptions)
+	writer := files.NewWriter[string]("Writing data model", outputOptions)
+	err = writer.Write(cc, dataModelDocs, processingOptions)
 	if err != nil {
 		return err
 	}
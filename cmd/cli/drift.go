@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/drift"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/sdk"
+	"github.com/project-chip/alchemy/zap/parse"
+)
+
+type Drift struct {
+	common.ASCIIDocAttributes  `embed:""`
+	spec.ParserOptions         `embed:""`
+	pipeline.ProcessingOptions `embed:""`
+	sdk.SDKOptions             `embed:""`
+
+	Output     string `default:"text" enum:"text,json" help:"output format for the drift report; 'text' or 'json'" group:"Output:"`
+	OutputPath string `name:"output-path" help:"path to write the drift report to; defaults to stdout" group:"Output:"`
+}
+
+func (c *Drift) Run(cc *Context) (err error) {
+	var specification *spec.Specification
+	specification, _, err = spec.Parse(cc, c.ParserOptions, c.ProcessingOptions, nil, c.ASCIIDocAttributes.ToList())
+	if err != nil {
+		return
+	}
+
+	var sdkSpecification *spec.Specification
+	sdkSpecification, _, err = parse.Import(cc, c.SdkRoot, c.ProcessingOptions)
+	if err != nil {
+		return
+	}
+
+	report := drift.Compare(specification, sdkSpecification)
+	switch c.Output {
+	case "json":
+		return writeOutput(c.OutputPath, report.WriteJSON)
+	default:
+		return writeOutput(c.OutputPath, report.WriteText)
+	}
+}
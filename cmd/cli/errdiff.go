@@ -13,12 +13,11 @@ type ErrDiff struct {
 
 	spec.FilterOptions `embed:""`
 
-	HeadRoot   string `name:"head-root" default:"connectedhomeip-spec" help:"the src root of your clone of CHIP-Specifications/connectedhomeip-spec"`
-	BaseRoot   string `name:"base-root" default:"connectedhomeip-spec" help:"the src root of your clone of CHIP-Specifications/connectedhomeip-spec"`
+	spec.PullRequestOptions `embed:""`
 }
 
 func (c *ErrDiff) Run(cc *Context) (err error) {
-	_, err = errdiff.Pipeline(cc, c.BaseRoot, c.HeadRoot, c.Paths, c.ProcessingOptions)
+	_, err = errdiff.Pipeline(cc, c.PullRequestOptions, c.Paths, c.ProcessingOptions)
 
 	return
 }
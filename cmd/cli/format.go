@@ -1,11 +1,15 @@
 package cli
 
 import (
+	"context"
+	"log/slog"
+
 	"github.com/project-chip/alchemy/asciidoc"
 	"github.com/project-chip/alchemy/asciidoc/render"
 	"github.com/project-chip/alchemy/internal/files"
 	"github.com/project-chip/alchemy/internal/paths"
 	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/internal/watch"
 	"github.com/project-chip/alchemy/matter/spec"
 )
 
@@ -13,14 +17,37 @@ type Format struct {
 	pipeline.ProcessingOptions `embed:""`
 	files.OutputOptions        `embed:""`
 	render.RenderOptions       `embed:""`
+	watch.Options              `embed:""`
 
 	Paths []string `arg:"" help:"Paths of AsciiDoc files to format" required:""`
 }
 
 func (f *Format) Run(cc *Context) (err error) {
+	err = f.format(cc, f.Paths)
+	if !f.Watch {
+		return
+	}
+	if err != nil {
+		slog.Error("Error formatting", slog.Any("error", err))
+	}
+	var roots []string
+	roots, err = watchRoots(f.Paths)
+	if err != nil {
+		return
+	}
+	return watch.Run(cc, roots, []string{".adoc"}, f.Options, func(cxt context.Context, changed []string) error {
+		changed, err := restrictPaths(changed, f.Paths)
+		if err != nil || len(changed) == 0 {
+			return err
+		}
+		return f.format(cxt, changed)
+	})
+}
+
+func (f *Format) format(cxt context.Context, targets []string) (err error) {
 	var inputs pipeline.Paths
 
-	inputs, err = pipeline.Start(cc, paths.NewTargeter(f.Paths...))
+	inputs, err = pipeline.Start(cxt, paths.NewTargeter(targets...))
 
 	if err != nil {
 		return err
@@ -30,7 +57,7 @@ func (f *Format) Run(cc *Context) (err error) {
 	if err != nil {
 		return err
 	}
-	docs, err := pipeline.Parallel(cc, f.ProcessingOptions, docReader, inputs)
+	docs, err := pipeline.Parallel(cxt, f.ProcessingOptions, docReader, inputs)
 	if err != nil {
 		return err
 	}
@@ -43,12 +70,12 @@ func (f *Format) Run(cc *Context) (err error) {
 
 	renderer := render.NewRenderer(f.RenderOptions.ToOptions()...)
 	var renders pipeline.StringSet
-	renders, err = pipeline.Parallel(cc, f.ProcessingOptions, renderer, ids)
+	renders, err = pipeline.Parallel(cxt, f.ProcessingOptions, renderer, ids)
 	if err != nil {
 		return err
 	}
 
 	writer := files.NewWriter[string]("Formatting docs", f.OutputOptions)
-	err = writer.Write(cc, renders, f.ProcessingOptions)
+	err = writer.Write(cxt, renders, f.ProcessingOptions)
 	return
 }
@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"slices"
@@ -9,7 +11,9 @@ import (
 
 	"github.com/project-chip/alchemy/cmd/common"
 	"github.com/project-chip/alchemy/idl"
+	idlparse "github.com/project-chip/alchemy/idl/parse"
 	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/paths"
 	"github.com/project-chip/alchemy/internal/pipeline"
 	"github.com/project-chip/alchemy/matter/spec"
 	"github.com/project-chip/alchemy/sdk"
@@ -18,6 +22,7 @@ import (
 type IDL struct {
 	IDLRegen              IDLRegen              `cmd:"" name:"regen"`
 	IDLControllerClusters IDLControllerClusters `cmd:"" name:"controller-clusters"`
+	IDLLint               IDLLint               `cmd:"" name:"lint" help:"check .matter IDL files for syntax errors, duplicate names and IDs and unknown endpoint elements"`
 }
 
 type IDLRegen struct {
@@ -44,7 +49,11 @@ func (z *IDLRegen) Run(cc *Context) (err error) {
 		return
 	}
 
-	zapTargeter := idl.Targeter(z.SdkRoot)
+	return regenIDL(cc, specification, z.SdkRoot, z.SuppressProvisional, z.KeepLongStrings, z.ProcessingOptions, z.OutputOptions)
+}
+
+func regenIDL(cc *Context, specification *spec.Specification, sdkRoot string, suppressProvisional string, keepLongStrings bool, processingOptions pipeline.ProcessingOptions, outputOptions files.OutputOptions) (err error) {
+	zapTargeter := idl.Targeter(sdkRoot)
 
 	var zapPaths pipeline.Paths
 	zapPaths, err = pipeline.Start(cc, zapTargeter)
@@ -59,7 +68,7 @@ func (z *IDLRegen) Run(cc *Context) (err error) {
 	}
 
 	var zapFiles pipeline.Map[string, *pipeline.Data[*idl.File]]
-	zapFiles, err = pipeline.Parallel(cc, z.ProcessingOptions, reader, zapPaths)
+	zapFiles, err = pipeline.Parallel(cc, processingOptions, reader, zapPaths)
 	if err != nil {
 		return
 	}
@@ -69,17 +78,17 @@ func (z *IDLRegen) Run(cc *Context) (err error) {
 	if err != nil {
 		return
 	}
-	renderer.SuppressProvisional = z.SuppressProvisional
-	renderer.KeepLongStrings = z.KeepLongStrings
+	renderer.SuppressProvisional = suppressProvisional
+	renderer.KeepLongStrings = keepLongStrings
 
 	var matterFiles pipeline.StringSet
-	matterFiles, err = pipeline.Parallel(cc, z.ProcessingOptions, renderer, zapFiles)
+	matterFiles, err = pipeline.Parallel(cc, processingOptions, renderer, zapFiles)
 	if err != nil {
 		return
 	}
 
-	writer := files.NewWriter[string]("Writing .matter files", z.OutputOptions)
-	err = writer.Write(cc, matterFiles, z.ProcessingOptions)
+	writer := files.NewWriter[string]("Writing .matter files", outputOptions)
+	err = writer.Write(cc, matterFiles, processingOptions)
 
 	return
 }
@@ -194,3 +203,67 @@ func (z *IDLControllerClusters) Run(cc *Context) (err error) {
 
 	return writer.Write(cc, outputSet, z.ProcessingOptions)
 }
+
+type IDLLint struct {
+	pipeline.ProcessingOptions `embed:""`
+	sdk.SDKOptions             `embed:""`
+
+	Paths []string `arg:"" optional:"" help:"paths of .matter files to check; defaults to the IDLs of the SDK's .zap app configurations"`
+}
+
+func (z *IDLLint) Run(cc *Context) (err error) {
+	var matterPaths pipeline.Paths
+	if len(z.Paths) > 0 {
+		matterPaths, err = pipeline.Start(cc, paths.NewTargeter(z.Paths...))
+	} else {
+		matterPaths, err = pipeline.Start(cc, idlTargeter(z.SdkRoot))
+	}
+	if err != nil {
+		return
+	}
+
+	var sources []string
+	matterPaths.Range(func(path string, _ *pipeline.Data[struct{}]) bool {
+		sources = append(sources, path)
+		return true
+	})
+	slices.Sort(sources)
+
+	var problemCount int
+	for _, source := range sources {
+		var idlFile *idlparse.IDL
+		idlFile, err = idlparse.ParseIDLFile(source)
+		if err != nil {
+			slog.Warn("IDL syntax error", slog.String("path", source), slog.Any("error", err))
+			problemCount++
+			continue
+		}
+		for _, problem := range idlparse.Lint(idlFile) {
+			slog.Warn("IDL problem", slog.String("path", source), slog.String("problem", problem))
+			problemCount++
+		}
+	}
+	if problemCount > 0 {
+		return fmt.Errorf("found %d problems in %d IDL files", problemCount, len(sources))
+	}
+	return
+}
+
+// idlTargeter finds the .matter files generated alongside the SDK's .zap app configurations
+func idlTargeter(sdkRoot string) pipeline.Targeter {
+	zapTargeter := idl.Targeter(sdkRoot)
+	return func(cxt context.Context) (matterPaths []string, err error) {
+		var zapPaths []string
+		zapPaths, err = zapTargeter(cxt)
+		if err != nil {
+			return
+		}
+		for _, zapPath := range zapPaths {
+			matterPath := strings.TrimSuffix(zapPath, filepath.Ext(zapPath)) + ".matter"
+			if _, statErr := os.Stat(matterPath); statErr == nil {
+				matterPaths = append(matterPaths, matterPath)
+			}
+		}
+		return
+	}
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/jsonschema"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+type JSONSchema struct {
+	jsonschema.Options `embed:""`
+
+	common.ASCIIDocAttributes  `embed:""`
+	spec.ParserOptions         `embed:""`
+	spec.FilterOptions         `embed:""`
+	pipeline.ProcessingOptions `embed:""`
+	files.OutputOptions        `embed:""`
+}
+
+func (c *JSONSchema) Run(cc *Context) (err error) {
+	var specDocs spec.DocSet
+	var specification *spec.Specification
+	specification, specDocs, err = spec.Parse(cc, c.ParserOptions, c.ProcessingOptions, nil, c.ASCIIDocAttributes.ToList())
+	if err != nil {
+		return
+	}
+
+	specDocs, err = filterSpecDocs(cc, specDocs, specification, c.FilterOptions, c.ProcessingOptions)
+	if err != nil {
+		return
+	}
+
+	specDocs, err = filterSpecErrors(cc, specDocs, specification, c.FilterOptions, c.ProcessingOptions)
+	if err != nil {
+		return
+	}
+
+	err = checkSpecErrors(cc, specification, c.FilterOptions, specDocs)
+	if err != nil {
+		return
+	}
+
+	renderer := jsonschema.NewRenderer(specification, c.Options)
+	var schemas pipeline.StringSet
+	schemas, err = pipeline.Parallel(cc, c.ProcessingOptions, renderer, specDocs)
+	if err != nil {
+		return
+	}
+
+	writer := files.NewWriter[string]("Writing JSON Schema", c.OutputOptions)
+	return writer.Write(cc, schemas, c.ProcessingOptions)
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"os"
+	"time"
+
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/lsp"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+type LSP struct {
+	common.ASCIIDocAttributes `embed:""`
+	spec.ParserOptions        `embed:""`
+
+	Debounce time.Duration `default:"500ms" help:"how long to wait after the last edit before rebuilding the spec"`
+}
+
+func (c *LSP) Run(cc *Context) (err error) {
+	return lsp.Run(cc, os.Stdin, os.Stdout, lsp.Options{
+		ParserOptions: c.ParserOptions,
+		Attributes:    c.ASCIIDocAttributes.ToList(),
+		Debounce:      c.Debounce,
+	})
+}
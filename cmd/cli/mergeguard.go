@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mailgun/raymond/v2"
+	"github.com/project-chip/alchemy/cmd/action/github/templates"
+	"github.com/project-chip/alchemy/errdiff"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/mle"
+	"github.com/project-chip/alchemy/provisional"
+	"github.com/project-chip/alchemy/revision"
+)
+
+type MergeGuard struct {
+	pipeline.ProcessingOptions `embed:""`
+	spec.PullRequestOptions    `embed:""`
+
+	Output     string `default:"markdown" enum:"markdown,json" help:"output format for the verdict; 'markdown' or 'json'" group:"Output:"`
+	OutputPath string `name:"output-path" help:"path to write the verdict to; defaults to stdout" group:"Output:"`
+	PatchPath  string `name:"patch-path" help:"path to write a patch marking new entities provisional to" group:"Output:"`
+}
+
+func (c *MergeGuard) Run(cc *Context) (err error) {
+	options := c.PullRequestOptions
+	options.HeadRoot, err = filepath.Abs(options.HeadRoot)
+	if err != nil {
+		return
+	}
+
+	var specs spec.SpecPullRequest
+	var cleanup func()
+	specs, cleanup, err = spec.LoadSpecPullRequest(cc, options, c.ProcessingOptions)
+	if err != nil {
+		return fmt.Errorf("failed to load specs: %w", err)
+	}
+	// The master list check and the provisional patch read head's files after it is parsed
+	defer cleanup()
+
+	var patch bytes.Buffer
+	writer := files.NewPatcher[string]("Generating patch file...", &patch)
+	writer.Root = specs.HeadRoot
+
+	var violations map[string][]spec.Violation
+	violations, err = MergeGuardViolations(cc, &specs, specs.HeadRoot, c.ProcessingOptions, writer)
+	if err != nil {
+		return
+	}
+
+	if c.PatchPath != "" && patch.Len() > 0 {
+		err = os.WriteFile(c.PatchPath, patch.Bytes(), 0644)
+		if err != nil {
+			return fmt.Errorf("failed saving provisional patch: %w", err)
+		}
+	}
+
+	var vc templates.ViolationComment
+	vc, err = MergeGuardComment(violations, specs.HeadRoot, func(path string, line int) string {
+		return fmt.Sprintf("%s#L%d", path, line)
+	})
+	if err != nil {
+		return
+	}
+
+	switch c.Output {
+	case "json":
+		if vc.Files == nil {
+			vc.Files = []templates.ViolationFile{}
+		}
+		err = writeOutput(c.OutputPath, writeJSON(vc))
+	default:
+		var comment string
+		comment, err = RenderMergeGuardComment(vc)
+		if err != nil {
+			return
+		}
+		err = writeOutput(c.OutputPath, func(w io.Writer) (err error) {
+			_, err = io.WriteString(w, comment)
+			return
+		})
+	}
+	if err != nil {
+		return
+	}
+
+	if len(violations) > 0 {
+		err = errors.New("merge guard violations found")
+	}
+	return
+}
+
+// MergeGuardViolations runs the checks which guard the merging of a pull request: new entities must be provisional
+// and if-def'd, no new parse errors may be introduced, the master lists must agree with the spec, and changed
+// clusters and device types must have new revisions. If writer is not nil, a patch marking new entities
+// provisional is written to it.
+func MergeGuardViolations(cxt context.Context, specs *spec.SpecPullRequest, headRoot string, pipelineOptions pipeline.ProcessingOptions, writer files.Writer[string]) (violations map[string][]spec.Violation, err error) {
+	var vp map[string][]spec.Violation
+	vp, err = provisional.ProcessSpecs(cxt, specs, pipelineOptions, writer)
+	if err != nil {
+		return nil, fmt.Errorf("failed checking provisional status: %w", err)
+	}
+
+	ve := errdiff.ProcessComparison(specs)
+
+	var vm map[string][]spec.Violation
+	vm, err = mle.Process(headRoot, specs.Head)
+	if err != nil {
+		return nil, fmt.Errorf("failed checking Master List Enforcer status: %w", err)
+	}
+
+	vr := revision.ProcessComparison(specs)
+
+	violations = spec.MergeViolations(vp, ve, vm, vr)
+	return
+}
+
+// MergeGuardComment arranges violations by file, with paths relative to headRoot, for the merge guard templates;
+// sourceLink returns the link to a line of a file
+func MergeGuardComment(violations map[string][]spec.Violation, headRoot string, sourceLink func(path string, line int) string) (vc templates.ViolationComment, err error) {
+	var paths []string
+	for path := range violations {
+		paths = append(paths, path)
+	}
+
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		vs := violations[path]
+
+		var relPath string
+		relPath, err = filepath.Rel(headRoot, path)
+		if err != nil {
+			err = fmt.Errorf("the relative path could not be determined. Path: %s, Root: %s", path, headRoot)
+			return
+		}
+
+		vf := templates.ViolationFile{Path: relPath}
+		for _, v := range vs {
+			vv := templates.Violation{}
+
+			if v.Entity != nil {
+				vv.EntityName = matter.EntityName(v.Entity)
+				vv.EntityType = entityTypeName(v.Entity)
+
+				parent := v.Entity.Parent()
+				for {
+					if parent == nil {
+						break
+					}
+					vv.EntityName = matter.EntityName(parent) + "." + vv.EntityName
+					parent = parent.Parent()
+				}
+			} else {
+				vv.EntityName = "-"
+				vv.EntityType = "-"
+			}
+
+			vv.SourceLink = sourceLink(relPath, v.Line)
+			vv.SourceLine = v.Line
+			if v.Type.Has(spec.ViolationTypeNonProvisional) {
+				vv.Violations = append(vv.Violations, "Not marked Provisional")
+			}
+			if v.Type.Has(spec.ViolationTypeNotIfDefd) {
+				vv.Violations = append(vv.Violations, "Not in in-progress ifdef")
+			}
+			if v.Type.Has(spec.ViolationNewParseError) {
+				vv.Violations = append(vv.Violations, "New Parse Error introduced by this PR: "+v.Text)
+			}
+			if v.Type.Has(spec.ViolationMasterList) {
+				vv.Violations = append(vv.Violations, "Incompatible with Master List: "+v.Text)
+			}
+			if v.Type.Has(spec.ViolationRevision) {
+				vv.Violations = append(vv.Violations, "Revision: "+v.Text)
+			}
+			vf.Violations = append(vf.Violations, vv)
+		}
+		// Violations on the same line are ordered by name, so the comment is the same from run to run
+		slices.SortFunc(vf.Violations, func(a templates.Violation, b templates.Violation) int {
+			return cmp.Or(cmp.Compare(a.SourceLine, b.SourceLine), strings.Compare(a.EntityName, b.EntityName))
+		})
+		vc.Files = append(vc.Files, vf)
+	}
+	return
+}
+
+// RenderMergeGuardComment renders the merge guard's verdict as Markdown
+func RenderMergeGuardComment(vc templates.ViolationComment) (comment string, err error) {
+	var t *raymond.Template
+	if len(vc.Files) == 0 {
+		t, err = templates.LoadMergeGuardNoViolationsTemplate()
+		if err != nil {
+			err = fmt.Errorf("error loading no violation template: %w", err)
+			return
+		}
+		return t.Exec(map[string]any{})
+	}
+	t, err = templates.LoadMergeGuardViolationsTemplate()
+	if err != nil {
+		err = fmt.Errorf("error loading violation template: %w", err)
+		return
+	}
+	tc := map[string]any{
+		"comment": vc,
+	}
+	return t.Exec(tc)
+}
+
+func entityTypeName(e types.Entity) string {
+	switch e := e.(type) {
+	case *matter.Struct:
+		return "Struct"
+	case *matter.Feature:
+		return "Feature"
+	case *matter.Field:
+		switch e.EntityType() {
+		case types.EntityTypeAttribute:
+			return "Attribute"
+		case types.EntityTypeEventField:
+			return "Event Field"
+		case types.EntityTypeCommandField:
+			return "Command Field"
+		case types.EntityTypeStructField:
+			return "Struct Field"
+		default:
+			return "Field"
+		}
+	case *matter.Bitmap:
+		return "Bitmap"
+	case *matter.Enum:
+		return "Enum"
+	case *matter.EnumValue:
+		return "Enum Value"
+	case matter.Bit:
+		return "Bit"
+	default:
+		return e.EntityType().String()
+	}
+}
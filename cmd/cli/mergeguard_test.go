@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+func TestMergeGuard(t *testing.T) {
+	cxt := context.Background()
+	headRoot, err := filepath.Abs("../../provisional/testdata/head")
+	if err != nil {
+		t.Fatal(err)
+	}
+	specs, cleanup, err := spec.LoadSpecPullRequest(cxt, spec.PullRequestOptions{BaseRoot: "../../provisional/testdata/base", HeadRoot: headRoot}, pipeline.ProcessingOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	violations, err := MergeGuardViolations(cxt, &specs, headRoot, pipeline.ProcessingOptions{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vc, err := MergeGuardComment(violations, headRoot, func(path string, line int) string {
+		return fmt.Sprintf("%s#L%d", path, line)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	err = writeJSON(vc)(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{
+  "files": [
+    {
+      "path": "master_lists/MasterDeviceTypeList.adoc",
+      "violations": [
+        {
+          "entityName": "-",
+          "entityType": "-",
+          "sourceLink": "master_lists/MasterDeviceTypeList.adoc#L7",
+          "sourceLine": 7,
+          "violations": [
+            "Incompatible with Master List: Device Type ID is duplicated on Master List. ID='0x0016'"
+          ]
+        }
+      ]
+    },
+    {
+      "path": "src/app_clusters/test_cluster.adoc",
+      "violations": [
+        {
+          "entityName": "Test.NonProvCommand",
+          "entityType": "command",
+          "sourceLink": "src/app_clusters/test_cluster.adoc#L19",
+          "sourceLine": 19,
+          "violations": [
+            "Not marked Provisional"
+          ]
+        },
+        {
+          "entityName": "Test.ProvCommand2",
+          "entityType": "command",
+          "sourceLink": "src/app_clusters/test_cluster.adoc#L19",
+          "sourceLine": 19,
+          "violations": [
+            "Not in in-progress ifdef"
+          ]
+        }
+      ]
+    }
+  ]
+}
+`
+	if out.String() != expected {
+		t.Errorf("unexpected JSON verdict; got:\n%s\nexpected:\n%s", out.String(), expected)
+	}
+
+	comment, err := RenderMergeGuardComment(vc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var last int
+	for _, expected := range []string{
+		"found blocking violations in this pull request",
+		"<tr><td colspan=\"4\"><b>master_lists/MasterDeviceTypeList.adoc</b></td></tr>",
+		"<a href=\"master_lists/MasterDeviceTypeList.adoc#L7\">Line 7</a>",
+		"<div>Incompatible with Master List: Device Type ID is duplicated on Master List. ID=&apos;0x0016&apos;</div>",
+		"<tr><td colspan=\"4\"><b>src/app_clusters/test_cluster.adoc</b></td></tr>",
+		"<td>Test.NonProvCommand</td>",
+		"<div>Not marked Provisional</div>",
+		"<td>Test.ProvCommand2</td>",
+		"<div>Not in in-progress ifdef</div>",
+		"These issues must be resolved before this PR can be merged.",
+	} {
+		i := strings.Index(comment[last:], expected)
+		if i < 0 {
+			t.Fatalf("expected %q after offset %d of comment:\n%s", expected, last, comment)
+		}
+		last += i + len(expected)
+	}
+
+	vc, err = MergeGuardComment(nil, headRoot, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comment, err = RenderMergeGuardComment(vc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(comment, "found no blocking violations") {
+		t.Errorf("expected comment without violations to say so; got:\n%s", comment)
+	}
+}
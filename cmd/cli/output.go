@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+)
+
+// writeOutput passes write the file at outputPath, or stdout if outputPath is empty; an error closing the file is
+// returned along with any error from write
+func writeOutput(outputPath string, write func(w io.Writer) error) (err error) {
+	if outputPath == "" {
+		return write(os.Stdout)
+	}
+	var f *os.File
+	f, err = os.Create(outputPath)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+	return write(f)
+}
+
+// writeJSON returns a write function for writeOutput which encodes v as indented JSON
+func writeJSON(v any) func(w io.Writer) error {
+	return func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(v)
+	}
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+// PayloadTarget selects the spec entity which describes a payload
+type PayloadTarget struct {
+	common.ASCIIDocAttributes  `embed:""`
+	spec.ParserOptions         `embed:""`
+	pipeline.ProcessingOptions `embed:""`
+
+	Cluster   string `help:"name or ID of the cluster the payload belongs to" group:"Payload:"`
+	Command   string `help:"name or ID of the command whose fields the payload holds; response commands are named by their own name" group:"Payload:" xor:"payload"`
+	Event     string `help:"name or ID of the event whose fields the payload holds" group:"Payload:" xor:"payload"`
+	Struct    string `help:"name of the struct the payload holds" group:"Payload:" xor:"payload"`
+	Attribute string `name:"cluster-attribute" help:"name or ID of the attribute whose value the payload holds" group:"Payload:" xor:"payload"`
+}
+
+// payloadEntity is the entity a PayloadTarget resolves to; when neither s nor attribute is set, the payload holds fields
+type payloadEntity struct {
+	cluster   *matter.Cluster
+	fields    matter.FieldSet
+	s         *matter.Struct
+	attribute *matter.Field
+}
+
+func (t *PayloadTarget) resolve(cc *Context) (pe payloadEntity, err error) {
+	pe.cluster, err = t.cluster(cc)
+	if err != nil {
+		return
+	}
+	switch {
+	case t.Command != "":
+		var command *matter.Command
+		command, err = findPayloadEntity(pe.cluster.Commands, t.Command, "command")
+		if err == nil {
+			pe.fields = command.Fields
+		}
+	case t.Event != "":
+		var event *matter.Event
+		event, err = findPayloadEntity(pe.cluster.Events, t.Event, "event")
+		if err == nil {
+			pe.fields = event.Fields
+		}
+	case t.Struct != "":
+		pe.s, err = findPayloadStruct(pe.cluster, t.Struct)
+	case t.Attribute != "":
+		pe.attribute, err = findPayloadEntity(pe.cluster.Attributes, t.Attribute, "attribute")
+	default:
+		err = fmt.Errorf("one of --command, --event, --struct or --cluster-attribute is required with --cluster")
+	}
+	return
+}
+
+func (t *PayloadTarget) cluster(cc *Context) (*matter.Cluster, error) {
+	specification, _, err := spec.Parse(cc, t.ParserOptions, t.ProcessingOptions, nil, t.ASCIIDocAttributes.ToList())
+	if err != nil {
+		return nil, err
+	}
+	cluster, ok := specification.ClustersByName[t.Cluster]
+	if !ok {
+		id := matter.ParseNumber(t.Cluster)
+		if id.Valid() {
+			cluster, ok = specification.ClustersByID[id.Value()]
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster: %s", t.Cluster)
+	}
+	return cluster, nil
+}
+
+type payloadEntityType interface {
+	*matter.Command | *matter.Event | *matter.Field
+}
+
+func findPayloadEntity[T payloadEntityType](entities []T, name string, entityType string) (entity T, err error) {
+	id := matter.ParseNumber(name)
+	for _, e := range entities {
+		var entityName string
+		var entityID *matter.Number
+		switch e := any(e).(type) {
+		case *matter.Command:
+			entityName, entityID = e.Name, e.ID
+		case *matter.Event:
+			entityName, entityID = e.Name, e.ID
+		case *matter.Field:
+			entityName, entityID = e.Name, e.ID
+		}
+		if strings.EqualFold(entityName, name) {
+			return e, nil
+		}
+		if id.Valid() && entityID.Valid() && id.Value() == entityID.Value() {
+			entity = e
+		}
+	}
+	if entity == nil {
+		err = fmt.Errorf("unknown %s: %s", entityType, name)
+	}
+	return
+}
+
+func findPayloadStruct(cluster *matter.Cluster, name string) (*matter.Struct, error) {
+	for _, s := range cluster.Structs {
+		if strings.EqualFold(s.Name, name) {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown struct: %s", name)
+}
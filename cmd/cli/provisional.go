@@ -17,17 +17,22 @@ type Provisional struct {
 
 	spec.FilterOptions `embed:""`
 
-	HeadRoot string `name:"head-root" default:"connectedhomeip-spec" help:"the src root of your clone of CHIP-Specifications/connectedhomeip-spec"  group:"Provisional:"`
-	BaseRoot string `name:"base-root" default:"connectedhomeip-spec" help:"the src root of your clone of CHIP-Specifications/connectedhomeip-spec"  group:"Provisional:"`
+	spec.PullRequestOptions `embed:"" group:"Provisional:"`
 }
 
 func (c *Provisional) Run(cc *Context) (err error) {
+	specs, cleanup, err := spec.LoadSpecPullRequest(cc, c.PullRequestOptions, c.ProcessingOptions)
+	if err != nil {
+		return
+	}
+	// The patch is made against head's files, which stay in head's worktree until cleanup when read at a ref
+	defer cleanup()
 
 	var out bytes.Buffer
 	writer := files.NewPatcher[string]("Generating patch file...", &out)
-	writer.Root = c.HeadRoot
+	writer.Root = specs.HeadRoot
 
-	_, err = provisional.Pipeline(cc, c.BaseRoot, c.HeadRoot, nil, c.ProcessingOptions, writer)
+	_, err = provisional.ProcessSpecs(cc, &specs, c.ProcessingOptions, writer)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/revision"
+)
+
+type RevisionCheck struct {
+	pipeline.ProcessingOptions `embed:""`
+	spec.PullRequestOptions    `embed:""`
+}
+
+func (c *RevisionCheck) Run(cc *Context) (err error) {
+	var violations map[string][]spec.Violation
+	violations, err = revision.Pipeline(cc, c.PullRequestOptions, c.ProcessingOptions)
+	if err != nil {
+		return
+	}
+
+	for _, vv := range violations {
+		for _, v := range vv {
+			source := fmt.Sprintf("%s:%d", v.Path, v.Line)
+			slog.Warn(v.Text, slog.String("source", source))
+		}
+	}
+	if len(violations) > 0 {
+		err = fmt.Errorf("revision violations found")
+	}
+	return
+}
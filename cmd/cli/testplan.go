@@ -50,18 +50,22 @@ func (c *TestPlan) Run(cc *Context) (err error) {
 		return
 	}
 
-	generator := testplanRender.NewRenderer(specification, c.RendererOptions)
+	return renderTestPlans(cc, specification, specDocs, c.RendererOptions, c.ProcessingOptions, c.OutputOptions)
+}
+
+func renderTestPlans(cc *Context, specification *spec.Specification, specDocs spec.DocSet, rendererOptions testplanRender.RendererOptions, processingOptions pipeline.ProcessingOptions, outputOptions files.OutputOptions) (err error) {
+	generator := testplanRender.NewRenderer(specification, rendererOptions)
 	var testplans pipeline.StringSet
-	testplans, err = pipeline.Parallel(cc, c.ProcessingOptions, generator, specDocs)
+	testplans, err = pipeline.Parallel(cc, processingOptions, generator, specDocs)
 	if err != nil {
 		return err
 	}
 
-	docReader, err := spec.NewStringReader("Reading test plans", c.TestRoot)
+	docReader, err := spec.NewStringReader("Reading test plans", rendererOptions.TestRoot)
 	if err != nil {
 		return err
 	}
-	testplanDocs, err := pipeline.Parallel(cc, c.ProcessingOptions, docReader, testplans)
+	testplanDocs, err := pipeline.Parallel(cc, processingOptions, docReader, testplans)
 	if err != nil {
 		return err
 	}
@@ -74,13 +78,13 @@ func (c *TestPlan) Run(cc *Context) (err error) {
 
 	renderer := render.NewRenderer()
 	var renders pipeline.StringSet
-	renders, err = pipeline.Parallel(cc, c.ProcessingOptions, renderer, ids)
+	renders, err = pipeline.Parallel(cc, processingOptions, renderer, ids)
 	if err != nil {
 		return err
 	}
 
-	writer := files.NewWriter[string]("Writing test plans", c.OutputOptions)
-	err = writer.Write(cc, renders, c.ProcessingOptions)
+	writer := files.NewWriter[string]("Writing test plans", outputOptions)
+	err = writer.Write(cc, renders, processingOptions)
 
 	return
 }
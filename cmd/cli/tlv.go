@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/project-chip/alchemy/tlv"
+)
+
+type TLV struct {
+	TLVDecode TLVDecode `cmd:"" name:"decode" help:"decode a hex TLV payload into JSON, naming fields after the spec"`
+	TLVEncode TLVEncode `cmd:"" name:"encode" help:"encode a JSON payload as hex TLV, using the field IDs and data types in the spec"`
+}
+
+type TLVDecode struct {
+	PayloadTarget `embed:""`
+
+	Payload string `arg:"" optional:"" help:"hex TLV payload; spaces, colons and 0x prefixes are ignored. Read from stdin if omitted or -"`
+}
+
+func (c *TLVDecode) Run(cc *Context) (err error) {
+	var payload string
+	payload, err = readPayload(c.Payload)
+	if err != nil {
+		return
+	}
+	var b []byte
+	b, err = parseHex(payload)
+	if err != nil {
+		return
+	}
+
+	var value any
+	if c.Cluster == "" {
+		var el *tlv.Element
+		el, err = tlv.Decode(b)
+		if err != nil {
+			return
+		}
+		value = tlv.Generic(el)
+	} else {
+		var pe payloadEntity
+		pe, err = c.resolve(cc)
+		if err != nil {
+			return
+		}
+		switch {
+		case pe.attribute != nil:
+			value, err = tlv.DecodeValue(b, pe.attribute.Type)
+		case pe.s != nil:
+			value, err = tlv.DecodeStruct(b, pe.s)
+		default:
+			value, err = tlv.DecodeFields(b, pe.fields)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(value)
+}
+
+type TLVEncode struct {
+	PayloadTarget `embed:""`
+
+	Payload string `arg:"" optional:"" help:"JSON payload; fields may be named by name or ID. Read from stdin if omitted or -"`
+}
+
+func (c *TLVEncode) Run(cc *Context) (err error) {
+	var payload string
+	payload, err = readPayload(c.Payload)
+	if err != nil {
+		return
+	}
+	data := []byte(payload)
+
+	var b []byte
+	if c.Cluster == "" {
+		b, err = tlv.EncodeValue(data, nil)
+	} else {
+		var pe payloadEntity
+		pe, err = c.resolve(cc)
+		if err != nil {
+			return
+		}
+		switch {
+		case pe.attribute != nil:
+			b, err = tlv.EncodeValue(data, pe.attribute.Type)
+		case pe.s != nil:
+			b, err = tlv.EncodeStruct(data, pe.s)
+		default:
+			b, err = tlv.EncodeFields(data, pe.fields)
+		}
+	}
+	if err != nil {
+		return
+	}
+	_, err = fmt.Fprintln(os.Stdout, hex.EncodeToString(b))
+	return
+}
+
+func readPayload(payload string) (string, error) {
+	if payload != "" && payload != "-" {
+		return payload, nil
+	}
+	b, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func parseHex(payload string) ([]byte, error) {
+	payload = strings.NewReplacer("0x", "", "0X", "", ":", "", ",", "").Replace(payload)
+	payload = strings.Join(strings.Fields(payload), "")
+	b, err := hex.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex payload: %w", err)
+	}
+	return b, nil
+}
@@ -4,21 +4,45 @@ import (
 	"github.com/project-chip/alchemy/cmd/common"
 	"github.com/project-chip/alchemy/internal/pipeline"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/validate"
 )
 
 type Validate struct {
 	common.ASCIIDocAttributes  `embed:""`
 	spec.ParserOptions         `embed:""`
 	pipeline.ProcessingOptions `embed:""`
+
+	Output     string         `default:"log" enum:"log,json,sarif" help:"output format for validation errors; 'log', 'json' or 'sarif'" group:"Output:"`
+	OutputPath string         `name:"output-path" help:"path to write validation errors to; defaults to stdout" group:"Output:"`
+	ExitCode   map[string]int `name:"exit-code" help:"exit code to return when errors of a given type are found, e.g. duplicate-entity-id=2; use * for any error type" group:"Output:"`
 }
 
 func (c *Validate) Run(cc *Context) (err error) {
 
+	var policy validate.ExitPolicy
+	policy, err = validate.NewExitPolicy(c.ExitCode)
+	if err != nil {
+		return
+	}
+	// An exit code policy decides the exit code alone; errors logged while building the spec are covered by it
+	cc.IgnoreLoggedErrors = len(c.ExitCode) > 0
+
 	var specification *spec.Specification
 	specification, _, err = spec.Parse(cc, c.ParserOptions, c.ProcessingOptions, nil, c.ASCIIDocAttributes.ToList())
 	if err != nil {
 		return
 	}
 	spec.Validate(specification)
-	return
+
+	report := validate.NewReport(specification)
+	switch c.Output {
+	case "json":
+		err = writeOutput(c.OutputPath, report.WriteJSON)
+	case "sarif":
+		err = writeOutput(c.OutputPath, report.WriteSARIF)
+	}
+	if err != nil {
+		return
+	}
+	return policy.Check(report)
 }
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/project-chip/alchemy/internal/paths"
+	"github.com/project-chip/alchemy/internal/watch"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+type specGenerator func(cc *Context, filterOptions spec.FilterOptions) (*spec.Specification, error)
+
+// watchSpec runs generate, then watches the spec for changes, running generate again for the documents affected
+// by each change; generate still parses and builds the whole spec, but only regenerates output for those documents
+func watchSpec(cc *Context, parserOptions spec.ParserOptions, watchOptions watch.Options, filterOptions spec.FilterOptions, generate specGenerator) error {
+	specification, err := generate(cc, filterOptions)
+	if err != nil {
+		slog.Error("Error generating", slog.Any("error", err))
+	}
+	return watch.Run(cc, []string{parserOptions.Root}, []string{".adoc"}, watchOptions, func(cxt context.Context, changed []string) error {
+		affectedOptions := filterOptions
+		affected, ok := affectedDocs(specification, changed)
+		if ok {
+			affected, err = restrictPaths(affected, filterOptions.Paths)
+			if err != nil {
+				return err
+			}
+			if len(affected) == 0 {
+				slog.Info("No selected documents were affected")
+				return nil
+			}
+			affectedOptions.Paths = affected
+		} else {
+			slog.Info("Changed files are not yet part of the spec; regenerating everything")
+		}
+		s, err := generate(&Context{Context: cxt, Kong: cc.Kong}, affectedOptions)
+		if s != nil {
+			specification = s
+		}
+		return err
+	})
+}
+
+// affectedDocs returns the changed documents and those which include them, according to the last build of
+// the spec; if any of the changes are to documents the spec doesn't know about, it returns false
+func affectedDocs(specification *spec.Specification, changed []string) ([]string, bool) {
+	if specification == nil {
+		return nil, false
+	}
+	known := make(map[string]struct{})
+	for _, library := range specification.Libraries {
+		for _, doc := range library.Docs {
+			known[doc.Path.Absolute] = struct{}{}
+		}
+	}
+	for _, path := range changed {
+		if _, ok := known[path]; !ok {
+			return nil, false
+		}
+	}
+	return specification.Dependents(changed...), true
+}
+
+// restrictPaths limits affected to the paths selected on the command line, if there were any
+func restrictPaths(affected []string, selected []string) (restricted []string, err error) {
+	if len(selected) == 0 {
+		return affected, nil
+	}
+	var expanded []string
+	expanded, err = paths.Expand(selected)
+	if err != nil {
+		return
+	}
+	selectedPaths := make(map[string]struct{}, len(expanded))
+	for _, p := range expanded {
+		p, err = filepath.Abs(p)
+		if err != nil {
+			return
+		}
+		selectedPaths[p] = struct{}{}
+	}
+	for _, p := range affected {
+		if _, ok := selectedPaths[p]; ok {
+			restricted = append(restricted, p)
+		}
+	}
+	return
+}
+
+// watchRoots returns the directories containing the files selected by targets
+func watchRoots(targets []string) (roots []string, err error) {
+	var expanded []string
+	expanded, err = paths.Expand(targets)
+	if err != nil {
+		return
+	}
+	seen := make(map[string]struct{})
+	for _, p := range expanded {
+		var dir string
+		dir, err = filepath.Abs(filepath.Dir(p))
+		if err != nil {
+			return
+		}
+		if _, ok := seen[dir]; ok {
+			continue
+		}
+		seen[dir] = struct{}{}
+		roots = append(roots, dir)
+	}
+	return
+}
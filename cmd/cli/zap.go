@@ -4,6 +4,7 @@ import (
 	"github.com/project-chip/alchemy/cmd/common"
 	"github.com/project-chip/alchemy/internal/files"
 	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/internal/watch"
 	"github.com/project-chip/alchemy/matter/spec"
 	"github.com/project-chip/alchemy/sdk"
 	"github.com/project-chip/alchemy/zap/render"
@@ -17,9 +18,18 @@ type ZAP struct {
 	spec.FilterOptions         `embed:""`
 	sdk.SDKOptions             `embed:""`
 	render.TemplateOptions     `embed:""`
+	watch.Options              `embed:""`
 }
 
 func (z *ZAP) Run(cc *Context) (err error) {
+	if z.Watch {
+		return watchSpec(cc, z.ParserOptions, z.Options, z.FilterOptions, z.generate)
+	}
+	_, err = z.generate(cc, z.FilterOptions)
+	return
+}
+
+func (z *ZAP) generate(cc *Context, filterOptions spec.FilterOptions) (specification *spec.Specification, err error) {
 
 	err = sdk.CheckAlchemyVersion(z.SdkRoot)
 	if err != nil {
@@ -27,7 +37,6 @@ func (z *ZAP) Run(cc *Context) (err error) {
 	}
 
 	var specDocs spec.DocSet
-	var specification *spec.Specification
 	specification, specDocs, err = spec.Parse(cc, z.ParserOptions, z.ProcessingOptions, []spec.BuilderOption{spec.PatchForSdk(true)}, z.ASCIIDocAttributes.ToList())
 	if err != nil {
 		return
@@ -38,7 +47,7 @@ func (z *ZAP) Run(cc *Context) (err error) {
 		return
 	}
 
-	specDocs, err = filterSpecDocs(cc, specDocs, specification, z.FilterOptions, z.ProcessingOptions)
+	specDocs, err = filterSpecDocs(cc, specDocs, specification, filterOptions, z.ProcessingOptions)
 	if err != nil {
 		return
 	}
@@ -57,7 +66,7 @@ func (z *ZAP) Run(cc *Context) (err error) {
 			return
 		}
 
-		clusters, err = filterSpecErrors(cc, clusters, specification, z.FilterOptions, z.ProcessingOptions)
+		clusters, err = filterSpecErrors(cc, clusters, specification, filterOptions, z.ProcessingOptions)
 		if err != nil {
 			return
 		}
@@ -66,20 +75,20 @@ func (z *ZAP) Run(cc *Context) (err error) {
 	}
 
 	if deviceTypes.Size() > 0 {
-		deviceTypes, err = filterSpecErrors(cc, deviceTypes, specification, z.FilterOptions, z.ProcessingOptions)
+		deviceTypes, err = filterSpecErrors(cc, deviceTypes, specification, filterOptions, z.ProcessingOptions)
 		if err != nil {
 			return
 		}
 	}
 
 	if namespaces.Size() > 0 {
-		namespaces, err = filterSpecErrors(cc, namespaces, specification, z.FilterOptions, z.ProcessingOptions)
+		namespaces, err = filterSpecErrors(cc, namespaces, specification, filterOptions, z.ProcessingOptions)
 		if err != nil {
 			return
 		}
 	}
 
-	err = checkSpecErrors(cc, specification, z.FilterOptions, clusters, deviceTypes, namespaces)
+	err = checkSpecErrors(cc, specification, filterOptions, clusters, deviceTypes, namespaces)
 	if err != nil {
 		return
 	}
@@ -155,7 +164,7 @@ func (z *ZAP) Run(cc *Context) (err error) {
 		stringWriter.SetName("Writing ZAP templates")
 		err = stringWriter.Write(cc, zapTemplateDocs, z.ProcessingOptions)
 		if err != nil {
-			return err
+			return
 		}
 	}
 
@@ -164,7 +173,7 @@ func (z *ZAP) Run(cc *Context) (err error) {
 		byteWriter.SetName("Writing provisional docs")
 		err = byteWriter.Write(cc, indexDocs, z.ProcessingOptions)
 		if err != nil {
-			return err
+			return
 		}
 	}
 
@@ -172,7 +181,7 @@ func (z *ZAP) Run(cc *Context) (err error) {
 		byteWriter.SetName("Writing deviceTypes")
 		err = byteWriter.Write(cc, patchedDeviceTypes, z.ProcessingOptions)
 		if err != nil {
-			return err
+			return
 		}
 	}
 
@@ -180,7 +189,7 @@ func (z *ZAP) Run(cc *Context) (err error) {
 		byteWriter.SetName("Writing namespaces")
 		err = byteWriter.Write(cc, patchedNamespaces, z.ProcessingOptions)
 		if err != nil {
-			return err
+			return
 		}
 	}
 
@@ -188,7 +197,7 @@ func (z *ZAP) Run(cc *Context) (err error) {
 		stringWriter.SetName("Writing global objects")
 		err = stringWriter.Write(cc, globalObjectFiles, z.ProcessingOptions)
 		if err != nil {
-			return err
+			return
 		}
 	}
 
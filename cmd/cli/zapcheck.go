@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/cmd/common"
+	"github.com/project-chip/alchemy/compliance"
+	"github.com/project-chip/alchemy/idl"
+	"github.com/project-chip/alchemy/internal/paths"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/sdk"
+)
+
+type ZAPCheck struct {
+	common.ASCIIDocAttributes  `embed:""`
+	spec.ParserOptions         `embed:""`
+	pipeline.ProcessingOptions `embed:""`
+	sdk.SDKOptions             `embed:""`
+
+	Paths      []string `arg:"" optional:"" help:"paths of .zap application configurations to check; defaults to the SDK's example apps"`
+	Output     string   `default:"log" enum:"log,json" help:"output format for violations; 'log' or 'json'" group:"Output:"`
+	OutputPath string   `name:"output-path" help:"path to write violations to; defaults to stdout" group:"Output:"`
+}
+
+func (c *ZAPCheck) Run(cc *Context) (err error) {
+	var specification *spec.Specification
+	specification, _, err = spec.Parse(cc, c.ParserOptions, c.ProcessingOptions, nil, c.ASCIIDocAttributes.ToList())
+	if err != nil {
+		return
+	}
+
+	var zapPaths pipeline.Paths
+	if len(c.Paths) > 0 {
+		zapPaths, err = pipeline.Start(cc, paths.NewTargeter(c.Paths...))
+	} else {
+		zapPaths, err = pipeline.Start(cc, idl.Targeter(c.SdkRoot))
+		if err != nil {
+			return
+		}
+		// controller-clusters.zap is a synthetic configuration listing every cluster, not a device
+		zapPaths.Delete(filepath.Join(c.SdkRoot, "src/controller/data_model/controller-clusters.zap"))
+	}
+	if err != nil {
+		return
+	}
+
+	var reader idl.Reader
+	reader, err = idl.NewReader()
+	if err != nil {
+		return
+	}
+
+	var zapFiles pipeline.Map[string, *pipeline.Data[*idl.File]]
+	zapFiles, err = pipeline.Parallel(cc, c.ProcessingOptions, reader, zapPaths)
+	if err != nil {
+		return
+	}
+
+	var sources []string
+	zapFiles.Range(func(path string, _ *pipeline.Data[*idl.File]) bool {
+		sources = append(sources, path)
+		return true
+	})
+	slices.SortFunc(sources, strings.Compare)
+
+	var violations []compliance.Violation
+	for _, source := range sources {
+		data, _ := zapFiles.Load(source)
+		var device *compliance.Device
+		device, err = compliance.DeviceFromZAP(data.Content)
+		if err != nil {
+			return fmt.Errorf("error reading endpoints from %s: %w", source, err)
+		}
+		for _, v := range compliance.Check(specification, device) {
+			v.Source = source
+			violations = append(violations, v)
+		}
+	}
+
+	err = writeViolations(c.Output, c.OutputPath, violations)
+	if err != nil {
+		return
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("found %d violations in %d app configurations", len(violations), len(sources))
+	}
+	return
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/sdk"
+	testplanRender "github.com/project-chip/alchemy/testplan/render"
+	"github.com/project-chip/alchemy/zap/parse"
+)
+
+type ZAPImport struct {
+	JSON     ZAPImportJSON     `cmd:"" name:"json" help:"write the entities read from the SDK's ZAP templates as JSON"`
+	DM       ZAPImportDM       `cmd:"" name:"dm" help:"transmute the SDK's ZAP templates into data model XML"`
+	TestPlan ZAPImportTestPlan `cmd:"" name:"test-plan" help:"create initial test plans from the SDK's ZAP templates"`
+	IDL      ZAPImportIDL      `cmd:"" name:"idl" help:"regenerate .matter IDL files from the SDK's ZAP templates"`
+}
+
+type ZAPImportOptions struct {
+	pipeline.ProcessingOptions `embed:""`
+	sdk.SDKOptions             `embed:""`
+}
+
+func (o *ZAPImportOptions) importSpec(cc *Context) (specification *spec.Specification, specDocs spec.DocSet, err error) {
+	return parse.Import(cc, o.SdkRoot, o.ProcessingOptions)
+}
+
+type ZAPImportJSON struct {
+	ZAPImportOptions `embed:""`
+
+	OutputPath string `name:"output-path" help:"path to write the JSON to; defaults to stdout" group:"Output:"`
+}
+
+type zapImportGraph struct {
+	Clusters    []*matter.Cluster    `json:"clusters"`
+	DeviceTypes []*matter.DeviceType `json:"deviceTypes"`
+}
+
+func (c *ZAPImportJSON) Run(cc *Context) (err error) {
+	var specification *spec.Specification
+	specification, _, err = c.importSpec(cc)
+	if err != nil {
+		return
+	}
+
+	var graph zapImportGraph
+	for cluster := range specification.Clusters {
+		graph.Clusters = append(graph.Clusters, cluster)
+	}
+	slices.SortFunc(graph.Clusters, func(a *matter.Cluster, b *matter.Cluster) int {
+		if cmp := a.ID.Compare(b.ID); cmp != 0 {
+			return cmp
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	graph.DeviceTypes = slices.Clone(specification.DeviceTypes)
+	slices.SortFunc(graph.DeviceTypes, func(a *matter.DeviceType, b *matter.DeviceType) int {
+		if cmp := a.ID.Compare(b.ID); cmp != 0 {
+			return cmp
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return writeOutput(c.OutputPath, writeJSON(graph))
+}
+
+type ZAPImportDM struct {
+	ZAPImportOptions    `embed:""`
+	files.OutputOptions `embed:""`
+
+	DmRoot string `default:"connectedhomeip/data_model/master" aliases:"dmRoot" help:"where to place the data model files" group:"Data Model:"`
+}
+
+func (c *ZAPImportDM) Run(cc *Context) (err error) {
+	var specification *spec.Specification
+	var specDocs spec.DocSet
+	specification, specDocs, err = c.importSpec(cc)
+	if err != nil {
+		return
+	}
+	return renderDataModel(cc, specification, specDocs, c.DmRoot, c.ProcessingOptions, c.OutputOptions)
+}
+
+type ZAPImportTestPlan struct {
+	ZAPImportOptions               `embed:""`
+	files.OutputOptions            `embed:""`
+	testplanRender.RendererOptions `embed:""`
+}
+
+func (c *ZAPImportTestPlan) Run(cc *Context) (err error) {
+	var specification *spec.Specification
+	var specDocs spec.DocSet
+	specification, specDocs, err = c.importSpec(cc)
+	if err != nil {
+		return
+	}
+	return renderTestPlans(cc, specification, specDocs, c.RendererOptions, c.ProcessingOptions, c.OutputOptions)
+}
+
+type ZAPImportIDL struct {
+	ZAPImportOptions    `embed:""`
+	files.OutputOptions `embed:""`
+
+	SuppressProvisional string `name:"suppress-provisional" help:"Suppress rendering of provisional elements" default:"all" enum:"none,all,keep-existing"`
+	KeepLongStrings     bool   `name:"keep-long-strings" help:"Keep 'long_' prefix on string types" default:"false"`
+}
+
+func (c *ZAPImportIDL) Run(cc *Context) (err error) {
+	var specification *spec.Specification
+	specification, _, err = c.importSpec(cc)
+	if err != nil {
+		return
+	}
+	return regenIDL(cc, specification, c.SdkRoot, c.SuppressProvisional, c.KeepLongStrings, c.ProcessingOptions, c.OutputOptions)
+}
@@ -59,7 +59,7 @@ func Execute() {
 		}
 	}
 
-	if logHadErrors || (commands.ErrorExitCode && err != nil) {
+	if (logHadErrors && !cc.IgnoreLoggedErrors) || (commands.ErrorExitCode && err != nil) {
 		os.Exit(1)
 	}
 }
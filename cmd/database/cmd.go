@@ -4,14 +4,22 @@ package database
 
 import (
 	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/project-chip/alchemy/asciidoc"
 	"github.com/project-chip/alchemy/cmd/cli"
 	"github.com/project-chip/alchemy/cmd/common"
 	"github.com/project-chip/alchemy/db"
+	dmparse "github.com/project-chip/alchemy/dm/parse"
 	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/internal/vcs"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/sdk"
+	zapparse "github.com/project-chip/alchemy/zap/parse"
 )
 
 type Command struct {
@@ -19,34 +27,161 @@ type Command struct {
 	spec.ParserOptions         `embed:""`
 	spec.BuilderOptions        `embed:""`
 	pipeline.ProcessingOptions `embed:""`
+	sdk.SDKOptions             `embed:""`
 
 	Address string `default:"localhost" help:"the address to host the database server on"`
 	Port    int    `default:"3306" help:"the port to run the database server on"`
 	Raw     bool   `default:"false" hidden:"" help:"parse the sections directly, bypassing entity building"`
+
+	ZAP       bool   `default:"false" help:"also load the SDK's ZAP templates into the \"ZAP\" schema" group:"Sources:"`
+	DataModel bool   `default:"false" name:"dm" help:"also load the data model XML into the \"DataModel\" schema" group:"Sources:"`
+	DmRoot    string `default:"connectedhomeip/data_model/master" aliases:"dmRoot" help:"the data model XML to load with --dm" group:"Sources:"`
+
+	Revisions []string `name:"revision" help:"load a revision of the spec into its own schema, as label=path or label=git-ref, instead of the \"MatterSpec\" schema; may be repeated, and each pair of revisions gets a \"base..head\" schema of diff views" group:"Revisions:"`
+
+	SnapshotOut  string `name:"snapshot-out" help:"write a snapshot of the database to this path instead of starting the server" group:"Snapshot:"`
+	FromSnapshot string `name:"from-snapshot" help:"start the server from a snapshot written with --snapshot-out instead of parsing the spec" group:"Snapshot:"`
 }
 
 func (cmd *Command) Run(cc *cli.Context) (err error) {
 
+	sc := sql.NewContext(cc)
+	sc.SetCurrentDatabase(db.SpecSchema)
+
+	if cmd.FromSnapshot != "" {
+		var hosts []*db.Host
+		hosts, err = db.LoadSnapshot(sc, cmd.FromSnapshot)
+		if err != nil {
+			return fmt.Errorf("error loading DB snapshot: %w", err)
+		}
+		return db.Run(cmd.Address, cmd.Port, hosts...)
+	}
+
 	var specDocs spec.DocSet
 	var specification *spec.Specification
-	specification, specDocs, err = spec.Parse(cc, cmd.ParserOptions, cmd.ProcessingOptions, cmd.BuilderOptions.List(), cmd.ASCIIDocAttributes.ToList())
-	if err != nil {
+	var hosts []*db.Host
+	var h *db.Host
+	if len(cmd.Revisions) > 0 {
+		hosts, err = cmd.buildRevisions(cc, sc)
+		if err != nil {
+			return
+		}
+	} else {
+		specification, specDocs, err = spec.Parse(cc, cmd.ParserOptions, cmd.ProcessingOptions, cmd.BuilderOptions.List(), cmd.ASCIIDocAttributes.ToList())
+		if err != nil {
+			return
+		}
+		h, err = buildHost(sc, db.SpecSchema, specification, specDocs)
+		if err != nil {
+			return
+		}
+		hosts = append(hosts, h)
+	}
+
+	if cmd.ZAP {
+		specification, specDocs, err = zapparse.Import(cc, cmd.SdkRoot, cmd.ProcessingOptions, cmd.BuilderOptions.List()...)
+		if err != nil {
+			return fmt.Errorf("error importing ZAP templates: %w", err)
+		}
+		h, err = buildHost(sc, db.ZAPSchema, specification, specDocs)
+		if err != nil {
+			return
+		}
+		hosts = append(hosts, h)
+	}
+
+	if cmd.DataModel {
+		specification, specDocs, err = dmparse.Import(cc, cmd.DmRoot, cmd.ProcessingOptions, cmd.BuilderOptions.List()...)
+		if err != nil {
+			return fmt.Errorf("error importing data model XML: %w", err)
+		}
+		h, err = buildHost(sc, db.DataModelSchema, specification, specDocs)
+		if err != nil {
+			return
+		}
+		hosts = append(hosts, h)
+	}
+
+	if cmd.SnapshotOut != "" {
+		err = db.Snapshot(sc, cmd.SnapshotOut, hosts...)
+		if err != nil {
+			return fmt.Errorf("error writing DB snapshot: %w", err)
+		}
 		return
 	}
+	return db.Run(cmd.Address, cmd.Port, hosts...)
+}
 
+// buildRevisions builds a schema for each revision of the spec, followed by a schema of diff views for each pair of revisions
+func (cmd *Command) buildRevisions(cc *cli.Context, sc *sql.Context) (hosts []*db.Host, err error) {
+	for _, revision := range cmd.Revisions {
+		label, source, ok := strings.Cut(revision, "=")
+		if !ok || label == "" || source == "" {
+			return nil, fmt.Errorf("invalid revision \"%s\"; expected label=path or label=git-ref", revision)
+		}
+		var h *db.Host
+		h, err = cmd.buildRevision(cc, sc, label, source)
+		if err != nil {
+			return
+		}
+		hosts = append(hosts, h)
+	}
+	revisions := len(hosts)
+	for i := 0; i < revisions; i++ {
+		for j := i + 1; j < revisions; j++ {
+			var h *db.Host
+			h, err = db.NewDiff(sc, hosts[i], hosts[j])
+			if err != nil {
+				return
+			}
+			hosts = append(hosts, h)
+		}
+	}
+	return
+}
+
+func (cmd *Command) buildRevision(cc *cli.Context, sc *sql.Context, label string, source string) (h *db.Host, err error) {
+	parserOptions := cmd.ParserOptions
+	if info, statErr := os.Stat(source); statErr == nil && info.IsDir() {
+		parserOptions.Root, err = filepath.Abs(source)
+		if err != nil {
+			return
+		}
+	} else {
+		var remove func() error
+		parserOptions.Root, remove, err = vcs.AddWorktree(cmd.ParserOptions.Root, source)
+		if err != nil {
+			return nil, fmt.Errorf("error loading revision \"%s\": %w", label, err)
+		}
+		defer func() {
+			removeErr := remove()
+			if removeErr != nil {
+				slog.WarnContext(cc, "Unable to remove worktree", "revision", label, "error", removeErr)
+			}
+		}()
+	}
+
+	slog.InfoContext(cc, "Loading revision", "label", label, "root", parserOptions.Root)
+	var specification *spec.Specification
+	var specDocs spec.DocSet
+	specification, specDocs, err = spec.Parse(cc, parserOptions, cmd.ProcessingOptions, cmd.BuilderOptions.List(), cmd.ASCIIDocAttributes.ToList())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing revision \"%s\": %w", label, err)
+	}
+	return buildHost(sc, label, specification, specDocs)
+}
+
+func buildHost(sc *sql.Context, name string, specification *spec.Specification, specDocs spec.DocSet) (h *db.Host, err error) {
 	docs := make([]*asciidoc.Document, 0, specDocs.Size())
 	specDocs.Range(func(key string, value *pipeline.Data[*asciidoc.Document]) bool {
 		docs = append(docs, value.Content)
 		return true
 	})
 
-	sc := sql.NewContext(cc)
-	sc.SetCurrentDatabase("matter")
-
-	h := db.New()
+	h = db.New(name)
 	err = h.Build(sc, specification, docs)
 	if err != nil {
-		return fmt.Errorf("error building DB: %w", err)
+		err = fmt.Errorf("error building %s DB: %w", name, err)
 	}
-	return h.Run(cmd.Address, cmd.Port)
+	return
 }
@@ -0,0 +1,356 @@
+package compliance
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// Check evaluates every endpoint of device against the requirements of its device types and the conformance
+// of each of its server clusters, returning any violations found
+func Check(specification *spec.Specification, device *Device) []Violation {
+	c := &checker{spec: specification, device: device, seen: make(map[Violation]struct{})}
+	for _, endpoint := range device.Endpoints {
+		c.checkEndpoint(endpoint)
+	}
+	return c.violations
+}
+
+type checker struct {
+	spec       *spec.Specification
+	device     *Device
+	violations []Violation
+	seen       map[Violation]struct{}
+}
+
+func (c *checker) add(v Violation) {
+	if _, ok := c.seen[v]; ok {
+		return
+	}
+	c.seen[v] = struct{}{}
+	c.violations = append(c.violations, v)
+}
+
+// implementation is what an endpoint actually implements of a single server cluster
+type implementation struct {
+	cluster  *matter.Cluster
+	instance *ClusterInstance
+
+	features   map[*matter.Feature]struct{}
+	attributes map[*matter.Field]struct{}
+	commands   map[*matter.Command]struct{}
+}
+
+func (c *checker) checkEndpoint(endpoint *Endpoint) {
+	servers := make(map[*matter.Cluster]*implementation)
+	for _, instance := range endpoint.Servers {
+		cluster, ok := c.findCluster(instance.Cluster)
+		if !ok {
+			c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeUnknownCluster, Cluster: string(instance.Cluster), Interface: matter.InterfaceServer.String(), Message: fmt.Sprintf("unknown server cluster %s", instance.Cluster)})
+			continue
+		}
+		servers[cluster] = c.readImplementation(endpoint, cluster, instance)
+	}
+	clients := make(map[*matter.Cluster]struct{})
+	for _, id := range endpoint.Clients {
+		cluster, ok := c.findCluster(id)
+		if !ok {
+			c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeUnknownCluster, Cluster: string(id), Interface: matter.InterfaceClient.String(), Message: fmt.Sprintf("unknown client cluster %s", id)})
+			continue
+		}
+		clients[cluster] = struct{}{}
+	}
+
+	for _, instance := range endpoint.DeviceTypes {
+		deviceType, ok := c.findDeviceType(instance.DeviceType)
+		if !ok {
+			c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeUnknownDeviceType, DeviceType: string(instance.DeviceType), Message: fmt.Sprintf("unknown device type %s", instance.DeviceType)})
+			continue
+		}
+		if instance.Revision != 0 {
+			if latest := deviceType.Revisions.MostRecent(); latest != nil && latest.Number.Valid() && latest.Number.Value() != instance.Revision {
+				c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeRevision, DeviceType: deviceType.Name, Message: fmt.Sprintf("device type %s has revision %d; expected %d", deviceType.Name, instance.Revision, latest.Number.Value())})
+			}
+		}
+		c.checkDeviceType(endpoint, deviceType, servers, clients)
+	}
+
+	for _, instance := range endpoint.Servers {
+		cluster, ok := c.findCluster(instance.Cluster)
+		if !ok {
+			continue
+		}
+		c.checkCluster(endpoint, servers[cluster])
+	}
+}
+
+func (c *checker) readImplementation(endpoint *Endpoint, cluster *matter.Cluster, instance *ClusterInstance) *implementation {
+	impl := &implementation{
+		cluster:    cluster,
+		instance:   instance,
+		features:   make(map[*matter.Feature]struct{}),
+		attributes: make(map[*matter.Field]struct{}),
+		commands:   make(map[*matter.Command]struct{}),
+	}
+	var knownBits uint64
+	for f := range cluster.Features.FeatureBits() {
+		mask, err := f.Mask()
+		if err != nil {
+			continue
+		}
+		knownBits |= mask
+		if instance.FeatureMap&mask != 0 {
+			impl.features[f] = struct{}{}
+		}
+	}
+	if unknown := instance.FeatureMap &^ knownBits; unknown != 0 {
+		c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeUnknownElement, Cluster: cluster.Name, Element: types.EntityTypeFeature.String(), Message: fmt.Sprintf("%s FeatureMap sets unknown bits 0x%X", cluster.Name, unknown)})
+	}
+	for _, id := range instance.AttributeList {
+		if isGlobalOrManufacturerID(id) {
+			continue
+		}
+		a := findAttribute(cluster, id)
+		if a == nil {
+			c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeUnknownElement, Cluster: cluster.Name, Element: types.EntityTypeAttribute.String(), Name: fmt.Sprintf("0x%04X", id), Message: fmt.Sprintf("%s AttributeList contains unknown attribute 0x%04X", cluster.Name, id)})
+			continue
+		}
+		impl.attributes[a] = struct{}{}
+	}
+	for _, id := range instance.AcceptedCommandList {
+		if isGlobalOrManufacturerID(id) {
+			continue
+		}
+		cmd := findAcceptedCommand(cluster, id)
+		if cmd == nil {
+			c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeUnknownElement, Cluster: cluster.Name, Element: types.EntityTypeCommand.String(), Name: fmt.Sprintf("0x%02X", id), Message: fmt.Sprintf("%s AcceptedCommandList contains unknown command 0x%02X", cluster.Name, id)})
+			continue
+		}
+		impl.commands[cmd] = struct{}{}
+	}
+	return impl
+}
+
+func (c *checker) checkDeviceType(endpoint *Endpoint, deviceType *matter.DeviceType, servers map[*matter.Cluster]*implementation, clients map[*matter.Cluster]struct{}) {
+	resolved, err := c.spec.ResolveDeviceType(deviceType)
+	if err != nil {
+		c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeUnknownDeviceType, DeviceType: deviceType.Name, Message: fmt.Sprintf("unable to resolve requirements of device type %s: %v", deviceType.Name, err)})
+		return
+	}
+	cxt := deviceTypeContext(c.device, deviceType)
+	for _, cr := range resolved.Clusters {
+		state, err := cr.Conformance.Eval(cxt)
+		if err != nil {
+			continue
+		}
+		var present bool
+		switch cr.Interface {
+		case matter.InterfaceServer:
+			_, present = servers[cr.Cluster]
+		case matter.InterfaceClient:
+			_, present = clients[cr.Cluster]
+		}
+		switch {
+		case isDefinitely(state, conformance.StateMandatory) && !present:
+			c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeMissingCluster, DeviceType: deviceType.Name, Cluster: cr.ClusterName, Interface: cr.Interface.String(), Message: fmt.Sprintf("device type %s requires %s cluster %s (%s)", deviceType.Name, cr.Interface, cr.ClusterName, cr.Conformance.ASCIIDocString())})
+		case isDefinitely(state, conformance.StateDisallowed) && present:
+			c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeDisallowedCluster, DeviceType: deviceType.Name, Cluster: cr.ClusterName, Interface: cr.Interface.String(), Message: fmt.Sprintf("device type %s disallows %s cluster %s (%s)", deviceType.Name, cr.Interface, cr.ClusterName, cr.Conformance.ASCIIDocString())})
+		}
+
+		impl, ok := servers[cr.Cluster]
+		if !ok || cr.Interface != matter.InterfaceServer {
+			continue
+		}
+		for _, elements := range [][]*matter.ResolvedElementRequirement{cr.Features, cr.Attributes, cr.Commands} {
+			for _, er := range elements {
+				present, known := impl.implements(er.Requirement.ElementRequirement.Entity)
+				if !known {
+					continue
+				}
+				state, err := er.Conformance.Eval(cxt)
+				if err != nil {
+					continue
+				}
+				switch {
+				case isDefinitely(state, conformance.StateMandatory) && !present:
+					c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeMissingElement, DeviceType: deviceType.Name, Cluster: cr.ClusterName, Element: er.Element.String(), Name: er.Name, Message: fmt.Sprintf("device type %s requires %s %s on cluster %s (%s)", deviceType.Name, er.Element, er.Name, cr.ClusterName, er.Conformance.ASCIIDocString())})
+				case isDefinitely(state, conformance.StateDisallowed) && present:
+					c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeDisallowedElement, DeviceType: deviceType.Name, Cluster: cr.ClusterName, Element: er.Element.String(), Name: er.Name, Message: fmt.Sprintf("device type %s disallows %s %s on cluster %s (%s)", deviceType.Name, er.Element, er.Name, cr.ClusterName, er.Conformance.ASCIIDocString())})
+				}
+			}
+		}
+	}
+}
+
+func (c *checker) checkCluster(endpoint *Endpoint, impl *implementation) {
+	cluster := impl.cluster
+	instance := impl.instance
+
+	if instance.ClusterRevision != 0 {
+		if latest := cluster.Revisions.MostRecent(); latest != nil && latest.Number.Valid() && latest.Number.Value() != instance.ClusterRevision {
+			c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeRevision, Cluster: cluster.Name, Message: fmt.Sprintf("%s has ClusterRevision %d; expected %d", cluster.Name, instance.ClusterRevision, latest.Number.Value())})
+		}
+	}
+
+	ci := matter.ClusterImplementation{
+		Attributes: make([]string, 0, len(impl.attributes)),
+		Commands:   make([]string, 0, len(impl.commands)),
+		Revision:   conformance.Revision(instance.ClusterRevision),
+		Values:     make(map[string]any),
+	}
+	for f := range impl.features {
+		ci.Features = append(ci.Features, f.Code)
+	}
+	for a := range impl.attributes {
+		ci.Attributes = append(ci.Attributes, a.Name)
+	}
+	for cmd := range impl.commands {
+		ci.Commands = append(ci.Commands, cmd.Name)
+	}
+
+	for key, value := range instance.Attributes {
+		a := findAttributeByKey(cluster, key)
+		if a == nil {
+			c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeUnknownElement, Cluster: cluster.Name, Element: types.EntityTypeAttribute.String(), Name: key, Message: fmt.Sprintf("value supplied for unknown attribute %s on cluster %s", key, cluster.Name)})
+			continue
+		}
+		// Only numbers are used in comparisons; the presence of the attribute comes from the AttributeList, and a
+		// boolean value must not stand in for it
+		switch value := value.(type) {
+		case int64, uint64, float64:
+			ci.Values[a.Name] = value
+		}
+		for _, v := range matter.ValidateValue(a, value, cluster.Attributes) {
+			c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeConstraint, Cluster: cluster.Name, Element: types.EntityTypeAttribute.String(), Name: a.Name, Message: fmt.Sprintf("%s.%s %s", cluster.Name, v.Path, v.Message)})
+		}
+	}
+
+	elements, err := matter.EvaluateClusterConformance(cluster, ci)
+	if err != nil {
+		c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeUnknownElement, Cluster: cluster.Name, Message: fmt.Sprintf("unable to evaluate conformance of %s: %v", cluster.Name, err)})
+		return
+	}
+	for _, e := range elements {
+		switch e.Parent.(type) {
+		case *matter.Cluster, *matter.Features:
+		default:
+			continue
+		}
+		present, known := impl.implements(e.Entity)
+		if !known {
+			continue
+		}
+		elementType := e.Entity.EntityType()
+		if _, ok := e.Entity.(*matter.Feature); ok {
+			elementType = types.EntityTypeFeature
+		}
+		name := matter.EntityName(e.Entity)
+		switch {
+		case isDefinitely(e.State, conformance.StateMandatory) && !present:
+			c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeMissingElement, Cluster: cluster.Name, Element: elementType.String(), Name: name, Message: fmt.Sprintf("%s %s on cluster %s is mandatory (%s)", elementType, name, cluster.Name, e.Conformance.ASCIIDocString())})
+		case isDefinitely(e.State, conformance.StateDisallowed) && present:
+			c.add(Violation{Endpoint: endpoint.ID, Type: ViolationTypeDisallowedElement, Cluster: cluster.Name, Element: elementType.String(), Name: name, Message: fmt.Sprintf("%s %s on cluster %s is disallowed (%s)", elementType, name, cluster.Name, e.Conformance.ASCIIDocString())})
+		}
+	}
+}
+
+// implements reports whether the element is implemented, and whether that is something a device can report
+func (impl *implementation) implements(entity types.Entity) (present bool, known bool) {
+	switch entity := entity.(type) {
+	case *matter.Feature:
+		_, present = impl.features[entity]
+		return present, true
+	case *matter.Field:
+		if entity.EntityType() != types.EntityTypeAttribute {
+			return false, false
+		}
+		_, present = impl.attributes[entity]
+		return present, true
+	case *matter.Command:
+		if entity.Direction != matter.InterfaceServer {
+			// Only commands sent to the server appear in AcceptedCommandList
+			return false, false
+		}
+		_, present = impl.commands[entity]
+		return present, true
+	}
+	return false, false
+}
+
+func (c *checker) findCluster(id Identifier) (*matter.Cluster, bool) {
+	if n, ok := id.ID(); ok {
+		cluster, ok := c.spec.ClustersByID[n.Value()]
+		return cluster, ok
+	}
+	cluster, ok := c.spec.ClustersByName[string(id)]
+	if !ok {
+		name := strings.TrimSuffix(string(id), " Cluster")
+		cluster, ok = c.spec.ClustersByName[name]
+	}
+	return cluster, ok
+}
+
+func (c *checker) findDeviceType(id Identifier) (*matter.DeviceType, bool) {
+	if n, ok := id.ID(); ok {
+		deviceType, ok := c.spec.DeviceTypesByID[n.Value()]
+		return deviceType, ok
+	}
+	deviceType, ok := c.spec.DeviceTypesByName[string(id)]
+	return deviceType, ok
+}
+
+func findAttribute(cluster *matter.Cluster, id uint64) *matter.Field {
+	for _, a := range cluster.Attributes {
+		if a.ID.Valid() && a.ID.Value() == id {
+			return a
+		}
+	}
+	return nil
+}
+
+func findAttributeByKey(cluster *matter.Cluster, key string) *matter.Field {
+	if id := matter.ParseNumber(key); id.Valid() {
+		return findAttribute(cluster, id.Value())
+	}
+	i := slices.IndexFunc(cluster.Attributes, func(a *matter.Field) bool { return strings.EqualFold(a.Name, key) })
+	if i < 0 {
+		return nil
+	}
+	return cluster.Attributes[i]
+}
+
+func findAcceptedCommand(cluster *matter.Cluster, id uint64) *matter.Command {
+	for _, cmd := range cluster.Commands {
+		if cmd.Direction == matter.InterfaceServer && cmd.ID.Valid() && cmd.ID.Value() == id {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// isGlobalOrManufacturerID reports whether id is a global element, such as AttributeList, or manufacturer-specific
+func isGlobalOrManufacturerID(id uint64) bool {
+	return id > 0xFFFF || id >= 0xF000
+}
+
+func isDefinitely(cs conformance.ConformanceState, state conformance.State) bool {
+	return cs.State == state && cs.Confidence == conformance.ConfidenceDefinite
+}
+
+func deviceTypeContext(device *Device, deviceType *matter.DeviceType) conformance.Context {
+	cxt := &conformance.BasicContext{
+		Values: map[string]any{
+			"Matter": device.Matter,
+			"Zigbee": device.Zigbee,
+		},
+	}
+	if deviceType.Class != "" {
+		cxt.Values[deviceType.Class] = true
+	}
+	return cxt
+}
@@ -0,0 +1,91 @@
+// Package compliance checks descriptions of real devices against the requirements of the Matter spec
+package compliance
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/project-chip/alchemy/matter"
+)
+
+// Device describes the endpoints of a device, as read from its Descriptor and global attributes
+type Device struct {
+	// Matter and Zigbee are the protocols the device implements, which the conformance of device type requirements
+	// can depend on; Matter is assumed unless it is turned off
+	Matter bool `json:"matter" yaml:"matter"`
+	Zigbee bool `json:"zigbee,omitempty" yaml:"zigbee,omitempty"`
+
+	Endpoints []*Endpoint `json:"endpoints" yaml:"endpoints"`
+}
+
+type Endpoint struct {
+	ID          uint64                `json:"endpoint" yaml:"endpoint"`
+	DeviceTypes []*DeviceTypeInstance `json:"deviceTypes,omitempty" yaml:"deviceTypes,omitempty"`
+	Servers     []*ClusterInstance    `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Clients     []Identifier          `json:"clients,omitempty" yaml:"clients,omitempty"`
+}
+
+type DeviceTypeInstance struct {
+	DeviceType Identifier `json:"deviceType" yaml:"deviceType"`
+	Revision   uint64     `json:"revision,omitempty" yaml:"revision,omitempty"`
+}
+
+type ClusterInstance struct {
+	Cluster             Identifier `json:"cluster" yaml:"cluster"`
+	ClusterRevision     uint64     `json:"clusterRevision,omitempty" yaml:"clusterRevision,omitempty"`
+	FeatureMap          uint64     `json:"featureMap,omitempty" yaml:"featureMap,omitempty"`
+	AttributeList       []uint64   `json:"attributeList,omitempty" yaml:"attributeList,omitempty"`
+	AcceptedCommandList []uint64   `json:"acceptedCommandList,omitempty" yaml:"acceptedCommandList,omitempty"`
+
+	// Attributes holds the values of attributes, keyed by attribute ID or name
+	Attributes map[string]any `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// Identifier is a reference to a device type or cluster by either ID or name
+type Identifier string
+
+func (i *Identifier) UnmarshalYAML(b []byte) error {
+	var v any
+	err := yaml.Unmarshal(b, &v)
+	if err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case string:
+		*i = Identifier(strings.TrimSpace(v))
+	case uint64:
+		*i = Identifier(fmt.Sprintf("0x%04X", v))
+	case int64:
+		*i = Identifier(fmt.Sprintf("0x%04X", v))
+	default:
+		return fmt.Errorf("invalid identifier: %v", v)
+	}
+	return nil
+}
+
+// ID returns the identifier as a number, if it is one
+func (i Identifier) ID() (*matter.Number, bool) {
+	id := matter.ParseNumber(string(i))
+	return id, id.Valid()
+}
+
+// LoadDevice reads a device description from a JSON or YAML file
+func LoadDevice(path string) (*Device, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDevice(b)
+}
+
+// ParseDevice reads a device description from JSON or YAML
+func ParseDevice(b []byte) (*Device, error) {
+	device := Device{Matter: true}
+	err := yaml.UnmarshalWithOptions(b, &device, yaml.Strict())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing device description: %w", err)
+	}
+	return &device, nil
+}
@@ -0,0 +1,169 @@
+package compliance
+
+import (
+	"testing"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestParseDevice(t *testing.T) {
+	device, err := ParseDevice([]byte(`
+endpoints:
+  - endpoint: 1
+    deviceTypes:
+      - deviceType: 0x0100
+    servers:
+      - cluster: On/Off
+        attributes:
+          OnTime: 10
+    clients: [6]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !device.Matter || device.Zigbee {
+		t.Errorf("expected a Matter-only device, got Matter %v, Zigbee %v", device.Matter, device.Zigbee)
+	}
+	if len(device.Endpoints) != 1 {
+		t.Fatalf("expected 1 endpoint, got %d", len(device.Endpoints))
+	}
+	ep := device.Endpoints[0]
+	if id, ok := ep.DeviceTypes[0].DeviceType.ID(); !ok || id.Value() != 0x0100 {
+		t.Errorf("expected device type ID 0x0100, got %s", ep.DeviceTypes[0].DeviceType)
+	}
+	if _, ok := ep.Servers[0].Cluster.ID(); ok {
+		t.Errorf("expected cluster name, got ID %s", ep.Servers[0].Cluster)
+	}
+	if id, ok := ep.Clients[0].ID(); !ok || id.Value() != 6 {
+		t.Errorf("expected client cluster ID 6, got %s", ep.Clients[0])
+	}
+
+	_, err = ParseDevice([]byte("endpoints:\n  - endpoint: 1\n    bogus: true\n"))
+	if err == nil {
+		t.Errorf("expected error for unknown field")
+	}
+}
+
+func TestDeviceTypeContext(t *testing.T) {
+	deviceType := &matter.DeviceType{Class: "Simple"}
+	tests := []struct {
+		description string
+		conformance string
+		state       conformance.State
+	}{
+		{"matter: false\nendpoints: []\n", "Matter", conformance.StateDisallowed},
+		{"endpoints: []\n", "Matter", conformance.StateMandatory},
+		{"endpoints: []\n", "Zigbee", conformance.StateDisallowed},
+		{"zigbee: true\nendpoints: []\n", "Zigbee", conformance.StateMandatory},
+		{"endpoints: []\n", "Simple", conformance.StateMandatory},
+	}
+	for _, test := range tests {
+		device, err := ParseDevice([]byte(test.description))
+		if err != nil {
+			t.Fatal(err)
+		}
+		state, err := conformance.ParseConformance(test.conformance).Eval(deviceTypeContext(device, deviceType))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.State != test.state {
+			t.Errorf("%q with %q: expected %s, got %s", test.conformance, test.description, test.state, state.State)
+		}
+	}
+}
+
+func TestCheckAttributeValues(t *testing.T) {
+	cluster := matter.NewCluster(nil)
+	cluster.Name = "Level Control"
+	cluster.ID = matter.NewNumber(0x0008)
+
+	level := matter.NewAttribute(nil, cluster)
+	level.ID = matter.NewNumber(0x0000)
+	level.Name = "CurrentLevel"
+	level.Type = types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankScalar)
+	level.Constraint = constraint.ParseString("1 to 254")
+	level.Quality = matter.QualityNullable
+	level.Conformance = conformance.ParseConformance("M")
+
+	minLevel := matter.NewAttribute(nil, cluster)
+	minLevel.ID = matter.NewNumber(0x0002)
+	minLevel.Name = "MinLevel"
+	minLevel.Type = types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankScalar)
+	minLevel.Constraint = constraint.ParseString("1 to 254")
+	minLevel.Conformance = conformance.ParseConformance("M")
+	cluster.Attributes = matter.FieldSet{level, minLevel}
+
+	specification := &spec.Specification{ClustersByName: map[string]*matter.Cluster{cluster.Name: cluster}}
+
+	tests := []struct {
+		attributes map[string]any
+		violations int
+	}{
+		{map[string]any{"CurrentLevel": uint64(1)}, 0},
+		{map[string]any{"CurrentLevel": uint64(254)}, 0},
+		{map[string]any{"CurrentLevel": nil}, 0},
+		{map[string]any{"CurrentLevel": uint64(0)}, 1},
+		{map[string]any{"CurrentLevel": uint64(255)}, 1},
+		{map[string]any{"CurrentLevel": int64(-1)}, 1},
+		{map[string]any{"0x0000": "high"}, 1},
+		{map[string]any{"MinLevel": nil}, 1},
+		{map[string]any{"MinLevel": uint64(0)}, 1},
+	}
+	for _, test := range tests {
+		device := &Device{Matter: true, Endpoints: []*Endpoint{{
+			ID: 1,
+			Servers: []*ClusterInstance{{
+				Cluster:       "Level Control",
+				AttributeList: []uint64{0x0000, 0x0002},
+				Attributes:    test.attributes,
+			}},
+		}}}
+		var violations []Violation
+		for _, v := range Check(specification, device) {
+			if v.Type == ViolationTypeConstraint {
+				violations = append(violations, v)
+			}
+		}
+		if len(violations) != test.violations {
+			t.Errorf("attributes %v: expected %d constraint violations, got %v", test.attributes, test.violations, violations)
+		}
+	}
+}
+
+// TestCheckBooleanAttributeValue checks that an implemented boolean attribute whose value is false is still present
+// to the conformance of the attributes which depend on it
+func TestCheckBooleanAttributeValue(t *testing.T) {
+	cluster := matter.NewCluster(nil)
+	cluster.Name = "On/Off"
+	cluster.ID = matter.NewNumber(0x0006)
+
+	onOff := matter.NewAttribute(nil, cluster)
+	onOff.ID = matter.NewNumber(0x0000)
+	onOff.Name = "OnOff"
+	onOff.Type = types.NewDataType(types.BaseDataTypeBoolean, types.DataTypeRankScalar)
+	onOff.Conformance = conformance.ParseConformance("M")
+
+	globalSceneControl := matter.NewAttribute(nil, cluster)
+	globalSceneControl.ID = matter.NewNumber(0x4000)
+	globalSceneControl.Name = "GlobalSceneControl"
+	globalSceneControl.Type = types.NewDataType(types.BaseDataTypeBoolean, types.DataTypeRankScalar)
+	globalSceneControl.Conformance = conformance.ParseConformance("OnOff")
+	cluster.Attributes = matter.FieldSet{onOff, globalSceneControl}
+
+	specification := &spec.Specification{ClustersByName: map[string]*matter.Cluster{cluster.Name: cluster}}
+	device := &Device{Matter: true, Endpoints: []*Endpoint{{
+		ID: 1,
+		Servers: []*ClusterInstance{{
+			Cluster:       "On/Off",
+			AttributeList: []uint64{0x0000, 0x4000},
+			Attributes:    map[string]any{"OnOff": false, "GlobalSceneControl": true},
+		}},
+	}}}
+	if violations := Check(specification, device); len(violations) > 0 {
+		t.Errorf("expected no violations, got %v", violations)
+	}
+}
@@ -0,0 +1,65 @@
+package compliance
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type ViolationType uint8
+
+const (
+	ViolationTypeUnknown ViolationType = iota
+	ViolationTypeUnknownDeviceType
+	ViolationTypeUnknownCluster
+	ViolationTypeUnknownElement
+	ViolationTypeMissingCluster
+	ViolationTypeDisallowedCluster
+	ViolationTypeMissingElement
+	ViolationTypeDisallowedElement
+	ViolationTypeRevision
+	ViolationTypeConstraint
+)
+
+var violationTypeNames = map[ViolationType]string{
+	ViolationTypeUnknown:           "unknown",
+	ViolationTypeUnknownDeviceType: "unknown-device-type",
+	ViolationTypeUnknownCluster:    "unknown-cluster",
+	ViolationTypeUnknownElement:    "unknown-element",
+	ViolationTypeMissingCluster:    "missing-cluster",
+	ViolationTypeDisallowedCluster: "disallowed-cluster",
+	ViolationTypeMissingElement:    "missing-element",
+	ViolationTypeDisallowedElement: "disallowed-element",
+	ViolationTypeRevision:          "revision",
+	ViolationTypeConstraint:        "constraint",
+}
+
+func (vt ViolationType) String() string {
+	if name, ok := violationTypeNames[vt]; ok {
+		return name
+	}
+	return fmt.Sprintf("ViolationType(%d)", vt)
+}
+
+func (vt ViolationType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(vt.String())
+}
+
+// Violation is a single way in which a device fails to meet the requirements of the spec
+type Violation struct {
+	Source     string        `json:"source,omitempty"`
+	Endpoint   uint64        `json:"endpoint"`
+	Type       ViolationType `json:"type"`
+	DeviceType string        `json:"deviceType,omitempty"`
+	Cluster    string        `json:"cluster,omitempty"`
+	Interface  string        `json:"interface,omitempty"`
+	Element    string        `json:"element,omitempty"`
+	Name       string        `json:"name,omitempty"`
+	Message    string        `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Source != "" {
+		return fmt.Sprintf("%s: endpoint %d: %s: %s", v.Source, v.Endpoint, v.Type, v.Message)
+	}
+	return fmt.Sprintf("endpoint %d: %s: %s", v.Endpoint, v.Type, v.Message)
+}
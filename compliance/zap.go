@@ -0,0 +1,107 @@
+package compliance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/project-chip/alchemy/idl"
+)
+
+const (
+	featureMapAttributeID      = 0xFFFC
+	clusterRevisionAttributeID = 0xFFFD
+)
+
+// DeviceFromZAP builds a device description from the endpoints of a ZAP application configuration
+//
+// Only enabled clusters, included attributes and enabled incoming commands are described; the FeatureMap and
+// ClusterRevision of each server cluster are read from the default values of those attributes. Other attribute
+// values are not described, as ZAP default values are frequently placeholders which are set by the application.
+func DeviceFromZAP(file *idl.File) (*Device, error) {
+	device := &Device{Matter: true}
+	for _, jep := range file.Endpoints {
+		if jep.EndpointTypeIndex < 0 || jep.EndpointTypeIndex >= len(file.EndpointTypes) {
+			return nil, fmt.Errorf("endpoint %d refers to unknown endpoint type %d", jep.EndpointId, jep.EndpointTypeIndex)
+		}
+		endpointType := file.EndpointTypes[jep.EndpointTypeIndex]
+		endpoint := &Endpoint{ID: uint64(jep.EndpointId)}
+
+		switch {
+		case len(endpointType.DeviceTypes) > 0:
+			for i, dtr := range endpointType.DeviceTypes {
+				dti := &DeviceTypeInstance{DeviceType: zapIdentifier(dtr.Code)}
+				if i < len(endpointType.DeviceVersions) {
+					dti.Revision = uint64(endpointType.DeviceVersions[i])
+				}
+				endpoint.DeviceTypes = append(endpoint.DeviceTypes, dti)
+			}
+		case endpointType.DeviceTypeRef.Code != 0:
+			endpoint.DeviceTypes = append(endpoint.DeviceTypes, &DeviceTypeInstance{DeviceType: zapIdentifier(endpointType.DeviceTypeRef.Code)})
+		case endpointType.DeviceTypeCode != 0:
+			endpoint.DeviceTypes = append(endpoint.DeviceTypes, &DeviceTypeInstance{DeviceType: zapIdentifier(endpointType.DeviceTypeCode)})
+		}
+
+		for _, cr := range endpointType.Clusters {
+			if cr.Enabled == 0 || cr.MfgCode != nil {
+				continue
+			}
+			switch cr.Side {
+			case "client":
+				endpoint.Clients = append(endpoint.Clients, zapIdentifier(cr.Code))
+			case "server":
+				ci, err := zapClusterInstance(cr)
+				if err != nil {
+					return nil, fmt.Errorf("endpoint %d: %w", jep.EndpointId, err)
+				}
+				endpoint.Servers = append(endpoint.Servers, ci)
+			}
+		}
+		device.Endpoints = append(device.Endpoints, endpoint)
+	}
+	return device, nil
+}
+
+func zapClusterInstance(cr idl.ClusterRef) (*ClusterInstance, error) {
+	ci := &ClusterInstance{Cluster: zapIdentifier(cr.Code)}
+	for _, ar := range cr.Attributes {
+		if ar.Included == 0 || ar.MfgCode != nil || (ar.Side != "" && ar.Side != "server") {
+			continue
+		}
+		ci.AttributeList = append(ci.AttributeList, uint64(ar.Code))
+		switch ar.Code {
+		case featureMapAttributeID:
+			v, err := parseZAPNumber(ar.DefaultValue)
+			if err != nil {
+				return nil, fmt.Errorf("invalid FeatureMap on cluster %s: %w", cr.Name, err)
+			}
+			ci.FeatureMap = v
+		case clusterRevisionAttributeID:
+			v, err := parseZAPNumber(ar.DefaultValue)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ClusterRevision on cluster %s: %w", cr.Name, err)
+			}
+			ci.ClusterRevision = v
+		}
+	}
+	for _, cmd := range cr.Commands {
+		// Commands accepted by a server are sent by the client
+		if cmd.IsIncoming == 0 || cmd.IsEnabled == 0 || cmd.MfgCode != nil || cmd.Source != "client" {
+			continue
+		}
+		ci.AcceptedCommandList = append(ci.AcceptedCommandList, uint64(cmd.Code))
+	}
+	return ci, nil
+}
+
+func zapIdentifier(code int) Identifier {
+	return Identifier(fmt.Sprintf("0x%04X", code))
+}
+
+func parseZAPNumber(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(s, 0, 64)
+}
@@ -0,0 +1,72 @@
+package compliance
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/project-chip/alchemy/idl"
+)
+
+func TestDeviceFromZAP(t *testing.T) {
+	file := &idl.File{
+		EndpointTypes: []idl.EndpointType{
+			{
+				DeviceTypes:    []idl.DeviceTypeRef{{Code: 0x0100}},
+				DeviceVersions: []int{3},
+				Clusters: []idl.ClusterRef{
+					{
+						Code:    0x0006,
+						Side:    "server",
+						Enabled: 1,
+						Attributes: []idl.AttributeRef{
+							{Code: 0x0000, Side: "server", Included: 1},
+							{Code: 0x4000, Side: "server", Included: 0},
+							{Code: featureMapAttributeID, Side: "server", Included: 1, DefaultValue: "0x0001"},
+							{Code: clusterRevisionAttributeID, Side: "server", Included: 1, DefaultValue: "6"},
+						},
+						Commands: []idl.CommandRef{
+							{Code: 0x00, Source: "client", IsIncoming: 1, IsEnabled: 1},
+							{Code: 0x40, Source: "client", IsIncoming: 1, IsEnabled: 0},
+						},
+					},
+					{Code: 0x0008, Side: "server", Enabled: 0},
+					{Code: 0x0003, Side: "client", Enabled: 1},
+				},
+			},
+		},
+		Endpoints: []idl.JSONEndpoint{{EndpointId: 1, EndpointTypeIndex: 0}},
+	}
+
+	device, err := DeviceFromZAP(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(device.Endpoints) != 1 {
+		t.Fatalf("expected 1 endpoint, got %d", len(device.Endpoints))
+	}
+	ep := device.Endpoints[0]
+	if ep.ID != 1 || len(ep.DeviceTypes) != 1 || ep.DeviceTypes[0].DeviceType != "0x0100" || ep.DeviceTypes[0].Revision != 3 {
+		t.Errorf("unexpected device types on endpoint %d: %v", ep.ID, ep.DeviceTypes)
+	}
+	if len(ep.Servers) != 1 {
+		t.Fatalf("expected 1 enabled server cluster, got %d", len(ep.Servers))
+	}
+	server := ep.Servers[0]
+	if server.Cluster != "0x0006" || server.FeatureMap != 1 || server.ClusterRevision != 6 {
+		t.Errorf("unexpected server cluster: %s, feature map %d, revision %d", server.Cluster, server.FeatureMap, server.ClusterRevision)
+	}
+	if !slices.Equal(server.AttributeList, []uint64{0x0000, featureMapAttributeID, clusterRevisionAttributeID}) {
+		t.Errorf("unexpected attribute list: %v", server.AttributeList)
+	}
+	if !slices.Equal(server.AcceptedCommandList, []uint64{0x00}) {
+		t.Errorf("unexpected accepted command list: %v", server.AcceptedCommandList)
+	}
+	if !slices.Equal(ep.Clients, []Identifier{"0x0003"}) {
+		t.Errorf("unexpected client clusters: %v", ep.Clients)
+	}
+
+	file.Endpoints[0].EndpointTypeIndex = 1
+	if _, err = DeviceFromZAP(file); err == nil {
+		t.Errorf("expected error for unknown endpoint type")
+	}
+}
@@ -12,9 +12,7 @@ import (
 
 func (h *Host) Build(sc *sql.Context, spec *spec.Specification, docs []*asciidoc.Document) error {
 
-	pro := memory.NewDBProvider(h.db)
-	session := memory.NewSession(sql.NewBaseSession(), pro)
-	ctx := sql.NewContext(sc, sql.WithSession(session))
+	ctx := h.newContext(sc)
 
 	h.base = &sectionInfo{children: make(map[string][]*sectionInfo)}
 	var sis []*sectionInfo
@@ -32,6 +30,12 @@ func (h *Host) Build(sc *sql.Context, spec *spec.Specification, docs []*asciidoc
 	return h.createTables(ctx, h.base)
 }
 
+func (h *Host) newContext(sc *sql.Context) *sql.Context {
+	pro := memory.NewDBProvider(h.db)
+	session := memory.NewSession(sql.NewBaseSession(), pro)
+	return sql.NewContext(sc, sql.WithSession(session))
+}
+
 func (h *Host) createTables(sc *sql.Context, bs *sectionInfo) error {
 	slog.InfoContext(sc, "Creating tables...")
 	for _, tableName := range h.tableNames {
@@ -0,0 +1,178 @@
+package db
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// The tables compared across revisions, with the columns which identify each row within its parent
+var diffTables = []struct {
+	table string
+	keys  []string
+}{
+	{table: clusterTable, keys: []string{"id"}},
+	{table: attributeTable, keys: []string{"id"}},
+	{table: commandTable, keys: []string{"id", "direction"}},
+	{table: commandFieldTable, keys: []string{"id"}},
+	{table: eventTable, keys: []string{"id"}},
+	{table: eventFieldTable, keys: []string{"id"}},
+	{table: deviceTypeTable, keys: []string{"id"}},
+	{table: deviceTypeClusterRequirementTable, keys: []string{"id", "direction"}},
+	{table: namespaceTable, keys: []string{"id"}},
+	{table: tagTable, keys: []string{"id"}},
+}
+
+// DiffSchemaName returns the name of the schema holding the views which compare two revisions
+func DiffSchemaName(base string, head string) string {
+	return base + ".." + head
+}
+
+// NewDiff creates a schema of views comparing the tables of two revisions. Each view has the name of the
+// table it compares, and lists the rows added, removed or changed between base and head; rows are matched
+// by the IDs of the entities they describe and of those entities' parents, rather than by row ID.
+func NewDiff(sc *sql.Context, base *Host, head *Host) (*Host, error) {
+	h := New(DiffSchemaName(base.db.Name(), head.db.Name()))
+	ctx := h.newContext(sc)
+	for _, dt := range diffTables {
+		view, ok := diffView(base, head, dt.table, dt.keys)
+		if !ok {
+			continue
+		}
+		err := h.db.CreateView(ctx, dt.table, view, fmt.Sprintf("CREATE VIEW `%s` AS %s", dt.table, view))
+		if err != nil {
+			return nil, fmt.Errorf("error creating diff view \"%s\": %w", dt.table, err)
+		}
+	}
+	return h, nil
+}
+
+type diffKey struct {
+	alias     string
+	reference string
+}
+
+// diffView builds the select statement for a view comparing a table in two revisions; it returns false
+// if either revision is missing the table or one of its ancestors
+func diffView(base *Host, head *Host, tableName string, keys []string) (string, bool) {
+	baseTable, ok := base.tables[tableName]
+	if !ok {
+		return "", false
+	}
+	headTable, ok := head.tables[tableName]
+	if !ok {
+		return "", false
+	}
+
+	var ancestors []string
+	for parent := tableSchema[tableName].parent; parent != "" && parent != documentTable; parent = tableSchema[parent].parent {
+		if _, ok := base.tables[parent]; !ok {
+			return "", false
+		}
+		if _, ok := head.tables[parent]; !ok {
+			return "", false
+		}
+		ancestors = append(ancestors, parent)
+	}
+
+	var diffKeys []diffKey
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		for _, k := range diffTableKeys(ancestors[i]) {
+			alias := ancestors[i] + "_" + k
+			if k == "id" {
+				// Avoid confusion with the row ID columns of the underlying tables
+				alias = ancestors[i] + "_entity_id"
+			}
+			diffKeys = append(diffKeys, diffKey{alias: alias, reference: fmt.Sprintf("p%d.`%s`", i, k)})
+		}
+	}
+	for _, k := range keys {
+		diffKeys = append(diffKeys, diffKey{alias: k, reference: "t.`" + k + "`"})
+	}
+	columns := diffColumns(baseTable, headTable, tableName, keys)
+
+	baseSide := diffSide(base.db.Name(), tableName, ancestors, diffKeys, columns)
+	headSide := diffSide(head.db.Name(), tableName, ancestors, diffKeys, columns)
+
+	var join strings.Builder
+	for i, k := range diffKeys {
+		if i > 0 {
+			join.WriteString(" AND ")
+		}
+		fmt.Fprintf(&join, "b.`%s` <=> h.`%s`", k.alias, k.alias)
+	}
+
+	selectList := func(change string, keySide string) string {
+		var s strings.Builder
+		fmt.Fprintf(&s, "SELECT '%s' AS change_type", change)
+		for _, k := range diffKeys {
+			fmt.Fprintf(&s, ", %s.`%s` AS `%s`", keySide, k.alias, k.alias)
+		}
+		for _, c := range columns {
+			fmt.Fprintf(&s, ", b.`%s` AS `base_%s`, h.`%s` AS `head_%s`", c, c, c, c)
+		}
+		return s.String()
+	}
+
+	var changed strings.Builder
+	for i, c := range columns {
+		if i > 0 {
+			changed.WriteString(" AND ")
+		}
+		fmt.Fprintf(&changed, "b.`%s` <=> h.`%s`", c, c)
+	}
+	if changed.Len() == 0 {
+		changed.WriteString("TRUE")
+	}
+
+	var view strings.Builder
+	fmt.Fprintf(&view, "%s FROM (%s) AS b JOIN (%s) AS h ON %s WHERE NOT (%s)", selectList("changed", "b"), baseSide, headSide, join.String(), changed.String())
+	fmt.Fprintf(&view, " UNION ALL %s FROM (%s) AS b LEFT JOIN (%s) AS h ON %s WHERE h.present IS NULL", selectList("removed", "b"), baseSide, headSide, join.String())
+	fmt.Fprintf(&view, " UNION ALL %s FROM (%s) AS h LEFT JOIN (%s) AS b ON %s WHERE b.present IS NULL", selectList("added", "h"), headSide, baseSide, join.String())
+	return view.String(), true
+}
+
+func diffTableKeys(tableName string) []string {
+	for _, dt := range diffTables {
+		if dt.table == tableName {
+			return dt.keys
+		}
+	}
+	return []string{"id"}
+}
+
+// diffColumns returns the columns present in both revisions of a table, other than row IDs and key columns
+func diffColumns(baseTable *memory.Table, headTable *memory.Table, tableName string, keys []string) (columns []string) {
+	headSchema := headTable.Schema()
+	for _, col := range baseTable.Schema() {
+		if col.Name == tableName+"_id" || col.Name == tableSchema[tableName].parent+"_id" || slices.Contains(keys, col.Name) {
+			continue
+		}
+		if headSchema.IndexOfColName(col.Name) < 0 {
+			continue
+		}
+		columns = append(columns, col.Name)
+	}
+	return
+}
+
+func diffSide(schema string, tableName string, ancestors []string, keys []diffKey, columns []string) string {
+	var s strings.Builder
+	s.WriteString("SELECT 1 AS present")
+	for _, k := range keys {
+		fmt.Fprintf(&s, ", %s AS `%s`", k.reference, k.alias)
+	}
+	for _, c := range columns {
+		fmt.Fprintf(&s, ", t.`%s` AS `%s`", c, c)
+	}
+	fmt.Fprintf(&s, " FROM `%s`.`%s` AS t", schema, tableName)
+	child := "t"
+	for i, a := range ancestors {
+		fmt.Fprintf(&s, " JOIN `%s`.`%s` AS p%d ON %s.`%s_id` = p%d.`%s_id`", schema, a, i, child, a, i, a)
+		child = fmt.Sprintf("p%d", i)
+	}
+	return s.String()
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/memory"
+	mms "github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+func newDiffTestHost(t *testing.T, name string, clusters []mms.Row, attributes []mms.Row) *Host {
+	h := New(name)
+	newTestTable(t, h, clusterTable, mms.Schema{
+		{Name: "cluster_id", Type: types.Int32, PrimaryKey: true},
+		{Name: "document_id", Type: types.Int32, PrimaryKey: true},
+		{Name: "id", Type: types.Int64, Nullable: true},
+		{Name: "name", Type: types.Text, Nullable: true},
+	}, clusters...)
+	newTestTable(t, h, attributeTable, mms.Schema{
+		{Name: "attribute_id", Type: types.Int32, PrimaryKey: true},
+		{Name: "cluster_id", Type: types.Int32, PrimaryKey: true},
+		{Name: "id", Type: types.Int64, Nullable: true},
+		{Name: "name", Type: types.Text, Nullable: true},
+		{Name: "data_type", Type: types.Text, Nullable: true},
+	}, attributes...)
+	return h
+}
+
+func TestNewDiff(t *testing.T) {
+	base := newDiffTestHost(t, "base",
+		[]mms.Row{
+			{int32(1), int32(1), int64(0x0006), "OnOff"},
+			{int32(2), int32(2), int64(0x0008), "Level Control"},
+		},
+		[]mms.Row{
+			{int32(1), int32(1), int64(0x0000), "OnOff", "bool"},
+			{int32(2), int32(1), int64(0x4001), "OnTime", "uint16"},
+		})
+	// Row IDs differ between revisions; rows are matched by the IDs of their entities and of their parents
+	head := newDiffTestHost(t, "head",
+		[]mms.Row{
+			{int32(1), int32(1), int64(0x0045), "Boolean State"},
+			{int32(2), int32(2), int64(0x0006), "On/Off"},
+		},
+		[]mms.Row{
+			{int32(1), int32(2), int64(0x4002), "OffWaitTime", "uint16"},
+			{int32(2), int32(2), int64(0x4001), "OnTime", "uint32"},
+			{int32(3), int32(2), int64(0x0000), "OnOff", "bool"},
+		})
+
+	sc := mms.NewEmptyContext()
+	diff, err := NewDiff(sc, base, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := diff.db.Name(); name != "base..head" {
+		t.Errorf("expected diff schema base..head, got %s", name)
+	}
+
+	pro := memory.NewDBProvider(base.db, head.db, diff.db)
+	engine := sqle.NewDefault(pro)
+	ctx := mms.NewContext(sc, mms.WithSession(memory.NewSession(mms.NewBaseSession(), pro)))
+	ctx.SetCurrentDatabase(diff.db.Name())
+	query := func(q string) (rows []string) {
+		t.Helper()
+		_, iter, _, err := engine.Query(ctx, q)
+		if err != nil {
+			t.Fatalf("error running %s: %v", q, err)
+		}
+		result, err := mms.RowIterToRows(ctx, iter)
+		if err != nil {
+			t.Fatalf("error reading %s: %v", q, err)
+		}
+		for _, r := range result {
+			values := make([]string, len(r))
+			for i, v := range r {
+				values[i] = fmt.Sprint(v)
+			}
+			rows = append(rows, strings.Join(values, "|"))
+		}
+		slices.Sort(rows)
+		return
+	}
+
+	clusters := query("SELECT change_type, id, base_name, head_name FROM `base..head`.`cluster`")
+	expected := []string{
+		"added|69|<nil>|Boolean State",
+		"changed|6|OnOff|On/Off",
+		"removed|8|Level Control|<nil>",
+	}
+	if !slices.Equal(clusters, expected) {
+		t.Errorf("expected cluster changes %v, got %v", expected, clusters)
+	}
+
+	attributes := query("SELECT change_type, cluster_entity_id, id, base_data_type, head_data_type FROM `base..head`.`attribute`")
+	expected = []string{
+		"added|6|16386|<nil>|uint16",
+		"changed|6|16385|uint16|uint32",
+	}
+	if !slices.Equal(attributes, expected) {
+		t.Errorf("expected attribute changes %v, got %v", expected, attributes)
+	}
+
+	// Tables missing from either revision have no view
+	if views, err := diff.db.AllViews(diff.newContext(sc)); err != nil {
+		t.Fatal(err)
+	} else if len(views) != 2 {
+		t.Errorf("expected views for the 2 tables in both revisions, got %d", len(views))
+	}
+}
@@ -7,20 +7,30 @@ import (
 	"github.com/project-chip/alchemy/asciidoc"
 	"github.com/project-chip/alchemy/matter"
 	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
 )
 
 func (h *Host) indexDoc(ctx context.Context, spec *spec.Specification, doc *asciidoc.Document) (*sectionInfo, error) {
+	var dt matter.DocType
+	var entities []types.Entity
 	library, ok := spec.LibraryForDocument(doc)
-	if !ok {
-		return nil, fmt.Errorf("unable to find library for document %s", doc.Path.Relative)
+	if ok {
+		dt, _ = library.DocType(doc)
+		entities = library.Spec.EntitiesForDocument(doc)
+	} else {
+		// Specifications imported from ZAP templates or data model XML have no libraries
+		entities = spec.EntitiesForDocument(doc)
+		if len(entities) == 0 {
+			return nil, fmt.Errorf("unable to find library for document %s", doc.Path.Relative)
+		}
+		dt = docTypeForEntities(entities)
 	}
 	ds := h.newSectionInfo(documentTable, nil, &dbRow{}, nil)
-	dt, _ := library.DocType(doc)
 	dts := matter.DocTypeNames[dt]
 	ds.values.values = map[matter.TableColumn]any{matter.TableColumnName: doc.Path.Base(), matter.TableColumnType: dts}
-	ds.values.extras = map[string]any{"path": doc.Path.Absolute}
+	// Paths are relative to the root, so that revisions checked out into temporary worktrees can be compared
+	ds.values.extras = map[string]any{"path": doc.Path.Relative}
 
-	entities := library.Spec.EntitiesForDocument(doc)
 	for _, m := range entities {
 		var err error
 		switch v := m.(type) {
@@ -45,3 +55,17 @@ func (h *Host) indexDoc(ctx context.Context, spec *spec.Specification, doc *asci
 
 	return ds, nil
 }
+
+func docTypeForEntities(entities []types.Entity) matter.DocType {
+	for _, e := range entities {
+		switch e.(type) {
+		case *matter.Cluster, *matter.ClusterGroup:
+			return matter.DocTypeCluster
+		case *matter.DeviceType:
+			return matter.DocTypeDeviceType
+		case *matter.Namespace:
+			return matter.DocTypeNamespace
+		}
+	}
+	return matter.DocTypeUnknown
+}
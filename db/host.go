@@ -9,6 +9,7 @@ import (
 	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/memory"
 	"github.com/dolthub/go-mysql-server/server"
+	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/project-chip/alchemy/matter"
 )
 
@@ -23,8 +24,11 @@ type Host struct {
 	ids map[string]int32
 }
 
-var (
-	dbName = "MatterSpec"
+// The names of the schemas built from each source of the data model; every schema has the same tables
+const (
+	SpecSchema      = "MatterSpec"
+	ZAPSchema       = "ZAP"
+	DataModelSchema = "DataModel"
 )
 
 type dbRow struct {
@@ -36,10 +40,11 @@ func newDBRow() *dbRow {
 	return &dbRow{values: make(map[matter.TableColumn]any)}
 }
 
-func New() *Host {
+// New creates an empty schema with the given name
+func New(name string) *Host {
 
 	h := &Host{
-		db:     memory.NewDatabase(dbName),
+		db:     memory.NewDatabase(name),
 		tables: make(map[string]*memory.Table),
 		ids:    make(map[string]int32),
 	}
@@ -48,12 +53,17 @@ func New() *Host {
 	return h
 }
 
-func (h *Host) Run(address string, port int) error {
+// Run serves each of the hosts' schemas from a single database server
+func Run(address string, port int, hosts ...*Host) error {
 	config := server.Config{
 		Protocol: "tcp",
 		Address:  fmt.Sprintf("%s:%d", address, port),
 	}
-	pro := memory.NewDBProvider(h.db)
+	dbs := make([]sql.Database, 0, len(hosts))
+	for _, h := range hosts {
+		dbs = append(dbs, h.db)
+	}
+	pro := memory.NewDBProvider(dbs...)
 
 	engine := sqle.NewDefault(pro)
 
@@ -0,0 +1,313 @@
+package db
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	mms "github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+// Snapshots are zip files containing a JSON description of each schema's tables and views, and each table's rows as
+// CSV; a NULL is written as \N, and values which start with a backslash have another one added, so that no value
+// can be read back as a NULL
+const (
+	snapshotSchemaFile = "schema.json"
+	snapshotNull       = `\N`
+	snapshotEscape     = `\`
+)
+
+type snapshotSchema struct {
+	Databases []snapshotDatabase `json:"databases"`
+}
+
+type snapshotDatabase struct {
+	Name   string          `json:"name"`
+	Tables []snapshotTable `json:"tables,omitempty"`
+	Views  []snapshotView  `json:"views,omitempty"`
+}
+
+type snapshotView struct {
+	Name       string `json:"name"`
+	Definition string `json:"definition"`
+}
+
+type snapshotTable struct {
+	Name    string           `json:"name"`
+	Columns []snapshotColumn `json:"columns"`
+}
+
+type snapshotColumn struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Nullable   bool   `json:"nullable"`
+	PrimaryKey bool   `json:"primaryKey"`
+}
+
+var snapshotColumnTypes = make(map[string]mms.Type)
+
+func init() {
+	for _, t := range []mms.Type{types.Boolean, types.Int8, types.Int32, types.Int64, types.Uint64, types.Text} {
+		snapshotColumnTypes[t.String()] = t
+	}
+}
+
+// Snapshot writes the contents of every table in each of the hosts' schemas to a zip file at path, which can be loaded with LoadSnapshot
+func Snapshot(sc *mms.Context, path string, hosts ...*Host) (err error) {
+	var f *os.File
+	f, err = os.Create(path)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+
+	zw := zip.NewWriter(f)
+	var schema snapshotSchema
+	for _, h := range hosts {
+		var sd snapshotDatabase
+		sd, err = h.writeSnapshot(sc, zw)
+		if err != nil {
+			return
+		}
+		schema.Databases = append(schema.Databases, sd)
+	}
+
+	var w io.Writer
+	w, err = zw.Create(snapshotSchemaFile)
+	if err != nil {
+		return
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(schema)
+	if err != nil {
+		return
+	}
+	return zw.Close()
+}
+
+func (h *Host) writeSnapshot(sc *mms.Context, zw *zip.Writer) (sd snapshotDatabase, err error) {
+	ctx := h.newContext(sc)
+	sd.Name = h.db.Name()
+	for _, tableName := range h.tableNames {
+		t, ok := h.tables[tableName]
+		if !ok {
+			continue
+		}
+		st := snapshotTable{Name: tableName}
+		for _, col := range t.Schema() {
+			st.Columns = append(st.Columns, snapshotColumn{Name: col.Name, Type: col.Type.String(), Nullable: col.Nullable, PrimaryKey: col.PrimaryKey})
+		}
+		sd.Tables = append(sd.Tables, st)
+		err = writeSnapshotTable(ctx, zw, sd.Name, t)
+		if err != nil {
+			err = fmt.Errorf("error writing table \"%s.%s\" to snapshot: %w", sd.Name, tableName, err)
+			return
+		}
+	}
+	var views []mms.ViewDefinition
+	views, err = h.db.AllViews(ctx)
+	if err != nil {
+		return
+	}
+	slices.SortFunc(views, func(a, b mms.ViewDefinition) int { return strings.Compare(a.Name, b.Name) })
+	for _, v := range views {
+		sd.Views = append(sd.Views, snapshotView{Name: v.Name, Definition: v.TextDefinition})
+	}
+	return
+}
+
+func snapshotTablePath(database string, table string) string {
+	return database + "/" + table + ".csv"
+}
+
+func writeSnapshotTable(ctx *mms.Context, zw *zip.Writer, database string, t *memory.Table) (err error) {
+	var w io.Writer
+	w, err = zw.Create(snapshotTablePath(database, t.Name()))
+	if err != nil {
+		return
+	}
+	cw := csv.NewWriter(w)
+	schema := t.Schema()
+	record := make([]string, len(schema))
+	for i, col := range schema {
+		record[i] = col.Name
+	}
+	err = cw.Write(record)
+	if err != nil {
+		return
+	}
+
+	var partitions mms.PartitionIter
+	partitions, err = t.Partitions(ctx)
+	if err != nil {
+		return
+	}
+	defer partitions.Close(ctx)
+	for {
+		var partition mms.Partition
+		partition, err = partitions.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return
+		}
+		var rows mms.RowIter
+		rows, err = t.PartitionRows(ctx, partition)
+		if err != nil {
+			return
+		}
+		err = writeSnapshotRows(ctx, cw, rows, record)
+		rows.Close(ctx)
+		if err != nil {
+			return
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeSnapshotRows(ctx *mms.Context, cw *csv.Writer, rows mms.RowIter, record []string) (err error) {
+	for {
+		var row mms.Row
+		row, err = rows.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return
+		}
+		for i, v := range row {
+			switch v := v.(type) {
+			case nil:
+				record[i] = snapshotNull
+			case bool:
+				record[i] = strconv.FormatBool(v)
+			default:
+				record[i] = fmt.Sprint(v)
+				if strings.HasPrefix(record[i], snapshotEscape) {
+					record[i] = snapshotEscape + record[i]
+				}
+			}
+		}
+		err = cw.Write(record)
+		if err != nil {
+			return
+		}
+	}
+}
+
+// LoadSnapshot creates a host for each schema stored in a snapshot written by Snapshot, in place of Build
+func LoadSnapshot(sc *mms.Context, path string) (hosts []*Host, err error) {
+	var zr *zip.ReadCloser
+	zr, err = zip.OpenReader(path)
+	if err != nil {
+		return
+	}
+	defer zr.Close()
+
+	var schema snapshotSchema
+	var r io.ReadCloser
+	r, err = zr.Open(snapshotSchemaFile)
+	if err != nil {
+		err = fmt.Errorf("error reading snapshot schema: %w", err)
+		return
+	}
+	err = json.NewDecoder(r).Decode(&schema)
+	r.Close()
+	if err != nil {
+		err = fmt.Errorf("error reading snapshot schema: %w", err)
+		return
+	}
+
+	for _, sd := range schema.Databases {
+		h := New(sd.Name)
+		ctx := h.newContext(sc)
+		for _, st := range sd.Tables {
+			slog.InfoContext(sc, "Loading table", "database", sd.Name, "name", st.Name)
+			err = h.loadSnapshotTable(ctx, zr, st)
+			if err != nil {
+				err = fmt.Errorf("error loading table \"%s.%s\" from snapshot: %w", sd.Name, st.Name, err)
+				return
+			}
+		}
+		for _, sv := range sd.Views {
+			err = h.db.CreateView(ctx, sv.Name, sv.Definition, fmt.Sprintf("CREATE VIEW `%s` AS %s", sv.Name, sv.Definition))
+			if err != nil {
+				err = fmt.Errorf("error loading view \"%s.%s\" from snapshot: %w", sd.Name, sv.Name, err)
+				return
+			}
+		}
+		hosts = append(hosts, h)
+	}
+	return
+}
+
+func (h *Host) loadSnapshotTable(ctx *mms.Context, zr *zip.ReadCloser, st snapshotTable) (err error) {
+	schema := make(mms.Schema, 0, len(st.Columns))
+	for _, sc := range st.Columns {
+		colType, ok := snapshotColumnTypes[sc.Type]
+		if !ok {
+			return fmt.Errorf("unknown type \"%s\" for column \"%s\"", sc.Type, sc.Name)
+		}
+		schema = append(schema, &mms.Column{Name: sc.Name, Type: colType, Nullable: sc.Nullable, Source: st.Name, PrimaryKey: sc.PrimaryKey})
+	}
+	t := memory.NewTable(h.db, st.Name, mms.NewPrimaryKeySchema(schema), h.db.GetForeignKeyCollection())
+	h.tableNames = append(h.tableNames, st.Name)
+	h.tables[st.Name] = t
+	h.db.AddTable(st.Name, t)
+
+	var r io.ReadCloser
+	r, err = zr.Open(snapshotTablePath(h.db.Name(), st.Name))
+	if err != nil {
+		return
+	}
+	defer r.Close()
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(schema)
+	var header []string
+	header, err = cr.Read()
+	if err != nil {
+		return
+	}
+	for i, name := range header {
+		if name != schema[i].Name {
+			return fmt.Errorf("unexpected column \"%s\"; expected \"%s\"", name, schema[i].Name)
+		}
+	}
+	for {
+		var record []string
+		record, err = cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return
+		}
+		row := make(mms.Row, len(record))
+		for i, v := range record {
+			if v == snapshotNull {
+				continue
+			}
+			v = strings.TrimPrefix(v, snapshotEscape)
+			row[i], _, err = schema[i].Type.Convert(v)
+			if err != nil {
+				return fmt.Errorf("error converting value for column \"%s\": %w", schema[i].Name, err)
+			}
+		}
+		err = t.Insert(ctx, row)
+		if err != nil {
+			return fmt.Errorf("error inserting table row: %w", err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package db
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/memory"
+	mms "github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+func newTestTable(t *testing.T, h *Host, name string, schema mms.Schema, rows ...mms.Row) {
+	t.Helper()
+	for _, col := range schema {
+		col.Source = name
+	}
+	table := memory.NewTable(h.db, name, mms.NewPrimaryKeySchema(schema), h.db.GetForeignKeyCollection())
+	h.tableNames = append(h.tableNames, name)
+	h.tables[name] = table
+	h.db.AddTable(name, table)
+	ctx := h.newContext(mms.NewEmptyContext())
+	for _, row := range rows {
+		if err := table.Insert(ctx, row); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func tableRows(t *testing.T, h *Host, name string) (rows []mms.Row) {
+	t.Helper()
+	ctx := h.newContext(mms.NewEmptyContext())
+	table := h.tables[name]
+	partitions, err := table.Partitions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer partitions.Close(ctx)
+	for {
+		partition, err := partitions.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		iter, err := table.PartitionRows(ctx, partition)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := mms.RowIterToRows(ctx, iter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows = append(rows, r...)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	h := New(SpecSchema)
+	newTestTable(t, h, "cluster", mms.Schema{
+		{Name: "id", Type: types.Int32, PrimaryKey: true},
+		{Name: "name", Type: types.Text, Nullable: true},
+		{Name: "provisional", Type: types.Boolean},
+	},
+		mms.Row{int32(1), "On/Off", int8(0)},
+		mms.Row{int32(2), nil, int8(1)},
+		// Text which looks like the NULL marker, or like an escaped value, must be read back as it was written
+		mms.Row{int32(3), `\N`, int8(0)},
+		mms.Row{int32(4), `\\N`, int8(0)},
+		mms.Row{int32(5), `\`, int8(0)},
+		mms.Row{int32(6), "", int8(0)},
+	)
+	expected := tableRows(t, h, "cluster")
+
+	path := filepath.Join(t.TempDir(), "snapshot.zip")
+	sc := mms.NewEmptyContext()
+	if err := Snapshot(sc, path, h); err != nil {
+		t.Fatal(err)
+	}
+	hosts, err := LoadSnapshot(sc, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hosts) != 1 || hosts[0].db.Name() != SpecSchema {
+		t.Fatalf("expected one %s schema, got %d", SpecSchema, len(hosts))
+	}
+	actual := tableRows(t, hosts[0], "cluster")
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d rows, got %d", len(expected), len(actual))
+	}
+	for i, row := range expected {
+		for j, v := range row {
+			if actual[i][j] != v {
+				t.Errorf("row %d column %d: expected %#v, got %#v", i, j, v, actual[i][j])
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// readCluster reads a cluster file, which describes one cluster for each of its cluster IDs
+func readCluster(el *etree.Element) (entities []types.Entity, err error) {
+	ids := el.FindElements("clusterIds/clusterId")
+	if len(ids) == 0 {
+		var cluster *matter.Cluster
+		cluster, err = readClusterDefinition(el, readID(el, "id"), strings.TrimSuffix(el.SelectAttrValue("name", ""), " Cluster"), "")
+		if err != nil {
+			return
+		}
+		entities = append(entities, cluster)
+		return
+	}
+	for _, idx := range ids {
+		var cluster *matter.Cluster
+		cluster, err = readClusterDefinition(el, readID(idx, "id"), idx.SelectAttrValue("name", ""), idx.SelectAttrValue("picsCode", ""))
+		if err != nil {
+			return
+		}
+		entities = append(entities, cluster)
+	}
+	return
+}
+
+func readClusterDefinition(el *etree.Element, id *matter.Number, name string, pics string) (cluster *matter.Cluster, err error) {
+	cluster = matter.NewCluster(nil)
+	cluster.ID = id
+	cluster.Name = name
+	cluster.Revisions = readRevisions(el, cluster)
+	if class := el.SelectElement("classification"); class != nil {
+		switch hierarchy := class.SelectAttrValue("hierarchy", ""); hierarchy {
+		case "derived":
+			cluster.Hierarchy = class.SelectAttrValue("baseCluster", "")
+		default:
+			cluster.Hierarchy = capitalize(hierarchy)
+		}
+		cluster.Role = capitalize(class.SelectAttrValue("role", ""))
+		cluster.Scope = class.SelectAttrValue("scope", "")
+		cluster.PICS = class.SelectAttrValue("picsCode", "")
+	}
+	if pics != "" {
+		cluster.PICS = pics
+	}
+	if features := el.SelectElement("features"); features != nil {
+		cluster.Features = readFeatures(features, cluster)
+	}
+	if dataTypes := el.SelectElement("dataTypes"); dataTypes != nil {
+		for _, dt := range dataTypes.ChildElements() {
+			switch dt.Tag {
+			case "bitmap":
+				cluster.AddBitmaps(readBitmap(dt, cluster))
+			case "enum":
+				cluster.AddEnums(readEnum(dt, cluster))
+			case "struct":
+				cluster.AddStructs(readStruct(dt, cluster))
+			case "number":
+				cluster.AddTypeDefs(readTypeDef(dt, cluster))
+			default:
+				err = fmt.Errorf("unexpected dataTypes element: %s", dt.Tag)
+				return
+			}
+		}
+	}
+	for _, ax := range el.FindElements("attributes/attribute") {
+		attribute := matter.NewAttribute(nil, cluster)
+		readField(ax, attribute)
+		cluster.Attributes = append(cluster.Attributes, attribute)
+	}
+	for _, cx := range el.FindElements("commands/command") {
+		cluster.Commands = append(cluster.Commands, readCommand(cx, cluster))
+	}
+	for _, ex := range el.FindElements("events/event") {
+		cluster.Events = append(cluster.Events, readEvent(ex, cluster))
+	}
+	return
+}
+
+func readFeatures(el *etree.Element, cluster *matter.Cluster) (features *matter.Features) {
+	features = matter.NewFeatures(nil, cluster)
+	for _, fx := range el.SelectElements("feature") {
+		feature := matter.NewFeature(nil, fx.SelectAttrValue("bit", ""), fx.SelectAttrValue("name", ""), fx.SelectAttrValue("code", ""), fx.SelectAttrValue("summary", ""), readConformance(fx))
+		features.AddFeatureBit(feature)
+	}
+	return
+}
+
+func readCommand(el *etree.Element, parent types.Entity) (command *matter.Command) {
+	command = matter.NewCommand(nil, parent)
+	command.ID = readID(el, "id")
+	command.Name = el.SelectAttrValue("name", "")
+	switch el.SelectAttrValue("direction", "") {
+	case "commandToServer":
+		command.Direction = matter.InterfaceServer
+	case "responseFromServer":
+		command.Direction = matter.InterfaceClient
+	}
+	if response := el.SelectAttrValue("response", ""); response != "" {
+		command.Response = types.NewCustomDataType(response, types.DataTypeRankScalar)
+	}
+	readAccess(el, &command.Access)
+	command.Quality = readQuality(el)
+	command.Conformance = readConformance(el)
+	for _, fx := range el.SelectElements("field") {
+		field := matter.NewField(nil, command, types.EntityTypeCommandField)
+		readField(fx, field)
+		command.Fields = append(command.Fields, field)
+	}
+	return
+}
+
+func readEvent(el *etree.Element, parent types.Entity) (event *matter.Event) {
+	event = matter.NewEvent(nil, parent)
+	event.ID = readID(el, "id")
+	event.Name = el.SelectAttrValue("name", "")
+	event.Priority = capitalize(el.SelectAttrValue("priority", ""))
+	readAccess(el, &event.Access)
+	event.Conformance = readConformance(el)
+	for _, fx := range el.SelectElements("field") {
+		field := matter.NewField(nil, event, types.EntityTypeEventField)
+		readField(fx, field)
+		event.Fields = append(event.Fields, field)
+	}
+	return
+}
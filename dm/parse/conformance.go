@@ -0,0 +1,177 @@
+package parse
+
+import (
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/internal/text"
+	"github.com/project-chip/alchemy/matter/conformance"
+)
+
+// readConformance reads the conformance element of el, if any. The element is converted to its AsciiDoc form
+// and parsed by the spec's conformance parser, so entity references are resolved the same way as in the spec.
+func readConformance(el *etree.Element) conformance.Set {
+	for _, child := range el.ChildElements() {
+		if !isConformanceElement(child) {
+			continue
+		}
+		s := conformanceString(child)
+		if s == "" {
+			return nil
+		}
+		return conformance.ParseConformance(s)
+	}
+	return nil
+}
+
+func isConformanceElement(el *etree.Element) bool {
+	switch el.Tag {
+	case "optionalConform",
+		"mandatoryConform",
+		"disallowConform",
+		"provisionalConform",
+		"deprecateConform",
+		"describedConform",
+		"obsoleteConform",
+		"otherwiseConform":
+		return true
+	default:
+		return false
+	}
+}
+
+func conformanceString(el *etree.Element) string {
+	switch el.Tag {
+	case "mandatoryConform":
+		if exp := firstExpressionString(el); exp != "" {
+			return text.TrimUnnecessaryParens(exp)
+		}
+		return "M"
+	case "optionalConform":
+		var s strings.Builder
+		if exp := firstExpressionString(el); exp != "" {
+			s.WriteString("[")
+			s.WriteString(text.TrimUnnecessaryParens(exp))
+			s.WriteString("]")
+		} else {
+			s.WriteString("O")
+		}
+		if choice := el.SelectAttrValue("choice", ""); choice != "" {
+			s.WriteString(".")
+			s.WriteString(choice)
+			s.WriteString(choiceLimitString(el))
+		}
+		return s.String()
+	case "provisionalConform":
+		return "P"
+	case "disallowConform":
+		return "X"
+	case "deprecateConform":
+		return "D"
+	case "describedConform":
+		return "desc"
+	case "obsoleteConform":
+		return "Z"
+	case "otherwiseConform":
+		var parts []string
+		for _, child := range el.ChildElements() {
+			if s := conformanceString(child); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	return ""
+}
+
+func choiceLimitString(el *etree.Element) string {
+	min := el.SelectAttrValue("min", "")
+	max := el.SelectAttrValue("max", "")
+	more := el.SelectAttrValue("more", "") == "true"
+	switch {
+	case min != "" && max != "" && min == max:
+		if min == "1" {
+			return ""
+		}
+		return min
+	case min != "" && max != "":
+		return min + "-" + max
+	case min != "" && more:
+		if min == "1" {
+			return "+"
+		}
+		return min + "+"
+	case max != "":
+		if max == "1" {
+			return "-"
+		}
+		return max + "-"
+	}
+	return ""
+}
+
+func firstExpressionString(el *etree.Element) string {
+	for _, child := range el.ChildElements() {
+		if s := expressionString(child); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func expressionString(el *etree.Element) string {
+	switch el.Tag {
+	case "feature", "attribute", "command", "event", "condition", "field", "struct", "typeDef":
+		name := el.SelectAttrValue("name", "")
+		if field := el.SelectAttrValue("field", ""); field != "" {
+			return name + "." + field
+		}
+		return name
+	case "enum", "bitmap":
+		if value := el.SelectAttrValue("value", ""); value != "" {
+			return el.SelectAttrValue("name", "") + "." + value
+		}
+		return el.SelectAttrValue("name", "")
+	case "value", "status":
+		return el.SelectAttrValue("name", "")
+	case "literal":
+		return el.SelectAttrValue("value", "")
+	case "revision":
+		value := el.SelectAttrValue("value", "")
+		if value == "current" {
+			return "Rev"
+		}
+		return "v" + value
+	case "notTerm":
+		return "!" + firstExpressionString(el)
+	case "andTerm":
+		return joinExpressions(el, " & ")
+	case "orTerm":
+		return joinExpressions(el, " | ")
+	case "xorTerm":
+		return joinExpressions(el, " ^ ")
+	case "equalTerm":
+		return joinExpressions(el, " == ")
+	case "notEqualTerm":
+		return joinExpressions(el, " != ")
+	case "greaterTerm":
+		return joinExpressions(el, " > ")
+	case "greaterOrEqualTerm":
+		return joinExpressions(el, " >= ")
+	case "lessTerm":
+		return joinExpressions(el, " < ")
+	case "lessOrEqualTerm":
+		return joinExpressions(el, " <= ")
+	}
+	return ""
+}
+
+func joinExpressions(el *etree.Element, operator string) string {
+	var operands []string
+	for _, child := range el.ChildElements() {
+		if s := expressionString(child); s != "" {
+			operands = append(operands, s)
+		}
+	}
+	return "(" + strings.Join(operands, operator) + ")"
+}
@@ -0,0 +1,104 @@
+package parse
+
+import (
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/matter/constraint"
+)
+
+// readConstraint reads the constraint elements of el, and of its list entry element, if any. As with
+// conformance, the elements are converted to their AsciiDoc form and parsed by the spec's constraint parser.
+func readConstraint(el *etree.Element) constraint.Constraint {
+	s := constraintString(el)
+	if entry := el.SelectElement("entry"); entry != nil {
+		if entryConstraint := constraintString(entry); entryConstraint != "" {
+			if s == "" {
+				s = "all"
+			}
+			s += "[" + entryConstraint + "]"
+		}
+	}
+	if s == "" {
+		return nil
+	}
+	return constraint.ParseString(s)
+}
+
+func constraintString(el *etree.Element) string {
+	var parts []string
+	for _, cx := range el.SelectElements("constraint") {
+		for _, child := range cx.ChildElements() {
+			switch child.Tag {
+			case "desc":
+				parts = append(parts, "desc")
+			case "allowed":
+				parts = append(parts, limitString(child))
+			case "between", "lengthBetween", "countBetween":
+				from := child.SelectElement("from")
+				to := child.SelectElement("to")
+				if from == nil || to == nil {
+					continue
+				}
+				parts = append(parts, limitString(from)+" to "+limitString(to))
+			case "min", "minLength", "minCount":
+				parts = append(parts, "min "+limitString(child))
+			case "max", "maxLength", "maxCount":
+				parts = append(parts, "max "+limitString(child))
+			case "maxCodePoints":
+				// The code point limit is written after the byte limit it qualifies, e.g. max 64{16}
+				if len(parts) > 0 && strings.HasPrefix(parts[len(parts)-1], "max ") {
+					parts[len(parts)-1] += "{" + limitString(child) + "}"
+				}
+			}
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func limitString(el *etree.Element) string {
+	if value := el.SelectAttrValue("value", ""); value != "" {
+		return value
+	}
+	for _, child := range el.ChildElements() {
+		switch child.Tag {
+		case "attribute", "field", "constant":
+			name := child.SelectAttrValue("name", "")
+			if field := child.SelectElement("field"); field != nil {
+				name += "." + field.SelectAttrValue("name", "")
+			}
+			return name
+		case "struct", "command", "event":
+			if field := child.SelectElement("field"); field != nil {
+				return field.SelectAttrValue("name", "")
+			}
+		case "enum":
+			return child.SelectAttrValue("value", "")
+		case "compute":
+			operation := child.SelectElement("operation")
+			if operation == nil {
+				return ""
+			}
+			var operator string
+			switch operation.Text() {
+			case "add":
+				operator = " + "
+			case "subtract":
+				operator = " - "
+			case "multiply":
+				operator = " * "
+			case "divide":
+				operator = " / "
+			default:
+				return ""
+			}
+			left := child.SelectElement("left")
+			right := child.SelectElement("right")
+			if left == nil || right == nil {
+				return ""
+			}
+			return "(" + limitString(left) + operator + limitString(right) + ")"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+package parse
+
+import (
+	"fmt"
+	"log/slog"
+	"math/bits"
+
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/internal/parse"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func readField(el *etree.Element, field *matter.Field) {
+	field.ID = readID(el, "id")
+	field.Name = el.SelectAttrValue("name", "")
+	field.Type = readDataType(el)
+	if def := el.SelectAttrValue("default", ""); def != "" {
+		field.Fallback = constraint.ParseLimit(def)
+	}
+	readAccess(el, &field.Access)
+	field.Quality = readQuality(el)
+	field.Conformance = readConformance(el)
+	field.Constraint = readConstraint(el)
+}
+
+// readDataType reads the type of a field; lists specify their entry type in a child element
+func readDataType(el *etree.Element) *types.DataType {
+	typeName := el.SelectAttrValue("type", "")
+	if typeName != "list" {
+		return types.ParseDataType(typeName, types.DataTypeRankScalar)
+	}
+	entry := el.SelectElement("entry")
+	if entry == nil {
+		return types.NewDataType(types.BaseDataTypeList, types.DataTypeRankScalar)
+	}
+	return types.ParseDataType(entry.SelectAttrValue("type", ""), types.DataTypeRankList)
+}
+
+func readBitmap(el *etree.Element, parent types.Entity) (bitmap *matter.Bitmap) {
+	bitmap = matter.NewBitmap(nil, parent)
+	bitmap.Name = el.SelectAttrValue("name", "")
+	var maxBit uint64
+	for _, bx := range el.SelectElements("bitfield") {
+		bit, to := readBit(bx)
+		if to > maxBit {
+			maxBit = to
+		}
+		bitmap.Bits = append(bitmap.Bits, matter.NewBitmapBit(nil, bitmap, bit, bx.SelectAttrValue("name", ""), bx.SelectAttrValue("summary", ""), readConformance(bx)))
+	}
+	// The data model doesn't record the size of bitmaps, so use the smallest which holds every bit
+	switch {
+	case maxBit < 8:
+		bitmap.Type = types.NewDataType(types.BaseDataTypeMap8, types.DataTypeRankScalar)
+	case maxBit < 16:
+		bitmap.Type = types.NewDataType(types.BaseDataTypeMap16, types.DataTypeRankScalar)
+	case maxBit < 32:
+		bitmap.Type = types.NewDataType(types.BaseDataTypeMap32, types.DataTypeRankScalar)
+	default:
+		bitmap.Type = types.NewDataType(types.BaseDataTypeMap64, types.DataTypeRankScalar)
+	}
+	return
+}
+
+// readBit returns the bit or range of bits of a bitfield, which is written either as a bit number or as a mask
+func readBit(el *etree.Element) (bit string, to uint64) {
+	if b := el.SelectAttrValue("bit", ""); b != "" {
+		to, _ = parse.HexOrDec(b)
+		return b, to
+	}
+	var from uint64
+	if mask := el.SelectAttrValue("mask", ""); mask != "" {
+		m, err := parse.HexOrDec(mask)
+		if err != nil || m == 0 {
+			slog.Warn("invalid bitfield mask", slog.String("name", el.SelectAttrValue("name", "")), slog.String("mask", mask))
+			return
+		}
+		from = uint64(bits.TrailingZeros64(m))
+		to = uint64(63 - bits.LeadingZeros64(m))
+	} else {
+		f, _ := parse.HexOrDec(el.SelectAttrValue("from", ""))
+		t, _ := parse.HexOrDec(el.SelectAttrValue("to", ""))
+		if f == 0 || t == 0 {
+			return
+		}
+		from = uint64(bits.TrailingZeros64(f))
+		to = uint64(63 - bits.LeadingZeros64(t))
+	}
+	if from == to {
+		return fmt.Sprintf("%d", from), to
+	}
+	return fmt.Sprintf("%d..%d", from, to), to
+}
+
+func readEnum(el *etree.Element, parent types.Entity) (en *matter.Enum) {
+	en = matter.NewEnum(nil, parent)
+	en.Name = el.SelectAttrValue("name", "")
+	var maxValue uint64
+	for _, ix := range el.SelectElements("item") {
+		value := ix.SelectAttrValue("value", "")
+		if value == "" {
+			// Ranges of values are reserved, rather than named
+			continue
+		}
+		ev := matter.NewEnumValue(nil, en)
+		ev.Value = matter.ParseNumber(value)
+		ev.Name = ix.SelectAttrValue("name", "")
+		ev.Summary = ix.SelectAttrValue("summary", "")
+		ev.Conformance = readConformance(ix)
+		if ev.Value.Valid() && ev.Value.Value() > maxValue {
+			maxValue = ev.Value.Value()
+		}
+		en.Values = append(en.Values, ev)
+	}
+	if maxValue > 0xFF {
+		en.Type = types.NewDataType(types.BaseDataTypeEnum16, types.DataTypeRankScalar)
+	} else {
+		en.Type = types.NewDataType(types.BaseDataTypeEnum8, types.DataTypeRankScalar)
+	}
+	return
+}
+
+func readStruct(el *etree.Element, parent types.Entity) (s *matter.Struct) {
+	s = matter.NewStruct(nil, parent)
+	s.Name = el.SelectAttrValue("name", "")
+	if ax := el.SelectElement("access"); ax != nil && ax.SelectAttrValue("fabricScoped", "") == "true" {
+		s.FabricScoping = matter.FabricScopingScoped
+	}
+	for _, fx := range el.SelectElements("field") {
+		field := matter.NewField(nil, s, types.EntityTypeStructField)
+		readField(fx, field)
+		s.Fields = append(s.Fields, field)
+	}
+	return
+}
+
+func readTypeDef(el *etree.Element, parent types.Entity) (td *matter.TypeDef) {
+	td = matter.NewTypeDef(nil, parent)
+	td.Name = el.SelectAttrValue("name", "")
+	td.Type = types.ParseDataType(el.SelectAttrValue("type", ""), types.DataTypeRankScalar)
+	return
+}
@@ -0,0 +1,84 @@
+package parse
+
+import (
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func readDeviceType(el *etree.Element) (deviceType *matter.DeviceType) {
+	deviceType = matter.NewDeviceType(nil)
+	deviceType.ID = readID(el, "id")
+	deviceType.Name = el.SelectAttrValue("name", "")
+	deviceType.Revisions = readRevisions(el, deviceType)
+	if class := el.SelectElement("classification"); class != nil {
+		deviceType.Class = capitalize(class.SelectAttrValue("class", ""))
+		deviceType.Scope = capitalize(class.SelectAttrValue("scope", ""))
+		deviceType.SupersetOf = class.SelectAttrValue("superset", "")
+	}
+	for _, cx := range el.FindElements("conditions/condition") {
+		condition := matter.NewCondition(nil, deviceType)
+		condition.Feature = cx.SelectAttrValue("name", "")
+		condition.Description = cx.SelectAttrValue("summary", "")
+		deviceType.Conditions = append(deviceType.Conditions, condition)
+	}
+	// Requirements on the clusters of composed device types are not read, as they can't be associated
+	// with a cluster requirement until every device type has been read
+	for _, cx := range el.FindElements("clusters/cluster") {
+		readClusterRequirement(cx, deviceType)
+	}
+	return
+}
+
+func readClusterRequirement(el *etree.Element, deviceType *matter.DeviceType) {
+	cr := matter.NewClusterRequirement(deviceType, nil)
+	cr.ClusterID = readID(el, "id")
+	cr.ClusterName = el.SelectAttrValue("name", "")
+	switch el.SelectAttrValue("side", "") {
+	case "client":
+		cr.Interface = matter.InterfaceClient
+	case "server":
+		cr.Interface = matter.InterfaceServer
+	}
+	cr.Quality = readQuality(el)
+	cr.Conformance = readConformance(el)
+	deviceType.ClusterRequirements = append(deviceType.ClusterRequirements, cr)
+
+	addElementRequirement := func(element types.EntityType, name string, field string, ex *etree.Element) {
+		er := matter.NewElementRequirement(deviceType, nil)
+		er.ClusterID = cr.ClusterID.Clone()
+		er.ClusterName = cr.ClusterName
+		er.Element = element
+		er.Name = name
+		er.Field = field
+		er.Conformance = readConformance(ex)
+		deviceType.ElementRequirements = append(deviceType.ElementRequirements, &er)
+	}
+	for _, fx := range el.FindElements("features/feature") {
+		addElementRequirement(types.EntityTypeFeature, fx.SelectAttrValue("code", ""), "", fx)
+	}
+	for _, ax := range el.FindElements("attributes/attribute") {
+		er := matter.NewElementRequirement(deviceType, nil)
+		er.ClusterID = cr.ClusterID.Clone()
+		er.ClusterName = cr.ClusterName
+		er.Element = types.EntityTypeAttribute
+		er.Name = ax.SelectAttrValue("name", "")
+		readAccess(ax, &er.Access)
+		er.Quality = readQuality(ax)
+		er.Conformance = readConformance(ax)
+		er.Constraint = readConstraint(ax)
+		deviceType.ElementRequirements = append(deviceType.ElementRequirements, &er)
+	}
+	for _, cx := range el.FindElements("commands/command") {
+		name := cx.SelectAttrValue("name", "")
+		if readConformance(cx) != nil {
+			addElementRequirement(types.EntityTypeCommand, name, "", cx)
+		}
+		for _, fx := range cx.SelectElements("field") {
+			addElementRequirement(types.EntityTypeCommandField, name, fx.SelectAttrValue("name", ""), fx)
+		}
+	}
+	for _, ex := range el.FindElements("events/event") {
+		addElementRequirement(types.EntityTypeEvent, ex.SelectAttrValue("name", ""), "", ex)
+	}
+}
@@ -0,0 +1,82 @@
+package parse
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// Import parses the data model XML files in dmRoot and builds a specification from them. As with ZAP
+// templates, each file is represented by an empty document.
+func Import(cxt context.Context, dmRoot string, processingOptions pipeline.ProcessingOptions, options ...spec.BuilderOption) (specification *spec.Specification, docs spec.DocSet, err error) {
+	var paths pipeline.Paths
+	paths, err = pipeline.Start(cxt, Targeter(dmRoot))
+	if err != nil {
+		return
+	}
+
+	var dataModelFiles pipeline.FileSet
+	dataModelFiles, err = pipeline.Parallel(cxt, processingOptions, files.NewReader("Reading data model XML"), paths)
+	if err != nil {
+		return
+	}
+
+	var parsed pipeline.Map[string, *pipeline.Data[[]types.Entity]]
+	parsed, err = pipeline.Parallel(cxt, processingOptions, NewDataModelParser(), dataModelFiles)
+	if err != nil {
+		return
+	}
+
+	entities := make(map[*asciidoc.Document][]types.Entity, parsed.Size())
+	docs = spec.NewDocSet()
+	parsed.Range(func(path string, data *pipeline.Data[[]types.Entity]) bool {
+		if len(data.Content) == 0 {
+			return true
+		}
+		var p asciidoc.Path
+		p, err = asciidoc.NewPath(path, dmRoot)
+		if err != nil {
+			return false
+		}
+		doc := &asciidoc.Document{Path: p}
+		entities[doc] = data.Content
+		docs.Store(p.Absolute, pipeline.NewData(p.Absolute, doc))
+		return true
+	})
+	if err != nil {
+		return
+	}
+
+	specification, err = spec.Import(cxt, dmRoot, nil, nil, entities, options...)
+	return
+}
+
+// Targeter returns the paths of the data model XML files in dmRoot
+func Targeter(dmRoot string) pipeline.Targeter {
+	return func(cxt context.Context) (paths []string, err error) {
+		for _, dir := range []string{"clusters", "device_types", "namespaces", "globals"} {
+			var entries []os.DirEntry
+			entries, err = os.ReadDir(filepath.Join(dmRoot, dir))
+			if os.IsNotExist(err) {
+				err = nil
+				continue
+			} else if err != nil {
+				return
+			}
+			for _, e := range entries {
+				if e.IsDir() || !strings.HasSuffix(e.Name(), ".xml") {
+					continue
+				}
+				paths = append(paths, filepath.Join(dmRoot, dir, e.Name()))
+			}
+		}
+		return
+	}
+}
@@ -0,0 +1,164 @@
+package parse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestImport(t *testing.T) {
+	specification, docs, err := Import(context.Background(), "testdata/dm", pipeline.ProcessingOptions{Serial: true, NoProgress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if docs.Size() != 4 {
+		t.Errorf("expected a document for each of the 4 data model files, got %d", docs.Size())
+	}
+
+	onOff, ok := specification.ClustersByID[0x0006]
+	if !ok {
+		t.Fatalf("expected On/Off cluster")
+	}
+	if onOff.Name != "On/Off" || onOff.PICS != "OO" || onOff.Hierarchy != "Base" || onOff.Role != "Application" {
+		t.Errorf("unexpected On/Off classification: name %q, PICS %q, hierarchy %q, role %q", onOff.Name, onOff.PICS, onOff.Hierarchy, onOff.Role)
+	}
+	if r := onOff.Revisions.MostRecent(); r == nil || r.Number.Value() != 6 || len(onOff.Revisions) != 3 {
+		t.Errorf("expected 3 On/Off revisions up to 6, got %d", len(onOff.Revisions))
+	}
+	for _, c := range []struct {
+		entity      types.Entity
+		conformance string
+	}{
+		{findFeature(onOff, "LT"), "O.a"},
+		{findField(onOff.Attributes, "OnTime"), "LT"},
+		{findCommand(onOff.Commands, "On"), "!OFFONLY"},
+		{findCommand(onOff.Commands, "Off"), "M"},
+	} {
+		if c.entity == nil {
+			t.Errorf("missing entity for conformance %q", c.conformance)
+			continue
+		}
+		if got := matter.EntityConformance(c.entity).ASCIIDocString(); got != c.conformance {
+			t.Errorf("expected conformance %q for %s, got %q", c.conformance, matter.EntityName(c.entity), got)
+		}
+	}
+
+	onOffAttribute := findField(onOff.Attributes, "OnOff")
+	if onOffAttribute == nil || !onOffAttribute.Quality.Has(matter.QualityScene|matter.QualityNonVolatile|matter.QualityReportable) || onOffAttribute.Quality.Has(matter.QualityNullable) {
+		t.Errorf("unexpected OnOff quality")
+	}
+	startUp := findField(onOff.Attributes, "StartUpOnOff")
+	if startUp == nil || startUp.Type == nil || startUp.Type.Name != "StartUpOnOffEnum" || startUp.Type.Entity == nil {
+		t.Fatalf("expected StartUpOnOff to be resolved to StartUpOnOffEnum")
+	}
+	if !startUp.Quality.Has(matter.QualityNullable) || startUp.Access.Write != matter.PrivilegeManage {
+		t.Errorf("expected StartUpOnOff to be nullable and written with manage privilege")
+	}
+	onWithTimedOff := findCommand(onOff.Commands, "OnWithTimedOff")
+	if onWithTimedOff == nil || len(onWithTimedOff.Fields) != 2 {
+		t.Fatalf("expected OnWithTimedOff with 2 fields")
+	}
+	if c := onWithTimedOff.Fields[1].Constraint; c == nil || c.ASCIIDocString(onWithTimedOff.Fields[1].Type) != "max 65534" {
+		t.Errorf("expected OnTime field constraint \"max 65534\", got %v", c)
+	}
+	if onWithTimedOff.Fields[0].Type.Entity == nil {
+		t.Errorf("expected OnOffControl field type to be resolved to OnOffControlBitmap")
+	}
+
+	// A file with more than one cluster ID describes a cluster for each
+	for id, name := range map[uint64]string{0x0071: "HEPA Filter Monitoring", 0x0072: "Activated Carbon Filter Monitoring"} {
+		c, ok := specification.ClustersByID[id]
+		if !ok {
+			t.Errorf("expected cluster %s", name)
+			continue
+		}
+		if c.Name != name {
+			t.Errorf("expected cluster 0x%04X to be named %q, got %q", id, name, c.Name)
+		}
+		list := findField(c.Attributes, "ReplacementProductList")
+		if list == nil || !list.Type.IsArray() || list.Type.EntryType == nil || list.Type.EntryType.Name != "ReplacementProductStruct" {
+			t.Errorf("expected ReplacementProductList on %s to be a list of ReplacementProductStruct", name)
+		} else if got := list.Constraint.ASCIIDocString(list.Type); got != "max 5" {
+			t.Errorf("expected ReplacementProductList constraint \"max 5\", got %q", got)
+		}
+	}
+	if hepa := specification.ClustersByID[0x0071]; hepa != nil && hepa.PICS != "HEPAFREMON" {
+		t.Errorf("expected the cluster ID's PICS code to override the classification's, got %q", hepa.PICS)
+	}
+
+	booleanState, ok := specification.ClustersByID[0x0045]
+	if !ok || len(booleanState.Events) != 1 {
+		t.Fatalf("expected Boolean State cluster with one event")
+	}
+	if e := booleanState.Events[0]; e.Name != "StateChange" || e.Priority != "Info" || len(e.Fields) != 1 {
+		t.Errorf("unexpected StateChange event: %s priority %s with %d fields", e.Name, e.Priority, len(e.Fields))
+	}
+
+	light, ok := specification.DeviceTypesByID[0x0101]
+	if !ok {
+		t.Fatalf("expected Dimmable Light device type")
+	}
+	if light.Name != "Dimmable Light" || light.Class != "Simple" || light.Scope != "Endpoint" {
+		t.Errorf("unexpected Dimmable Light classification: %q %q %q", light.Name, light.Class, light.Scope)
+	}
+	requirements := make(map[string]string)
+	for _, cr := range light.ClusterRequirements {
+		requirements[cr.ClusterName+" "+cr.Interface.String()] = cr.Conformance.ASCIIDocString()
+	}
+	expectedRequirements := map[string]string{
+		"On/Off server":                 "M",
+		"Boolean State client":          "O",
+		"HEPA Filter Monitoring server": "O",
+	}
+	if len(requirements) != len(expectedRequirements) {
+		t.Errorf("expected cluster requirements %v, got %v", expectedRequirements, requirements)
+	}
+	for name, c := range expectedRequirements {
+		if requirements[name] != c {
+			t.Errorf("expected %s requirement %q, got %q", name, c, requirements[name])
+		}
+	}
+	elements := make(map[string]string)
+	for _, er := range light.ElementRequirements {
+		elements[er.ClusterName+" "+er.Element.String()+" "+er.Name] = er.Conformance.ASCIIDocString()
+	}
+	for key, c := range map[string]string{
+		"On/Off feature LT":                          "M",
+		"On/Off command On":                          "M",
+		"HEPA Filter Monitoring attribute Condition": "M",
+	} {
+		if got, ok := elements[key]; !ok || got != c {
+			t.Errorf("expected element requirement %s with conformance %q; got %v", key, c, elements)
+		}
+	}
+}
+
+func findField(fields matter.FieldSet, name string) *matter.Field {
+	for _, f := range fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func findCommand(commands matter.CommandSet, name string) *matter.Command {
+	for _, c := range commands {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func findFeature(c *matter.Cluster, code string) *matter.Feature {
+	for f := range c.Features.FeatureBits() {
+		if f.Code == code {
+			return f
+		}
+	}
+	return nil
+}
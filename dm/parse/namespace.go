@@ -0,0 +1,22 @@
+package parse
+
+import (
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/matter"
+)
+
+func readNamespace(el *etree.Element) (namespace *matter.Namespace) {
+	namespace = matter.NewNamespace(nil)
+	namespace.ID = readID(el, "id")
+	namespace.Name = el.SelectAttrValue("name", "")
+	for _, tx := range el.FindElements("tags/tag") {
+		tag := matter.NewSemanticTag(namespace, nil)
+		tag.ID = readID(tx, "id")
+		tag.Name = tx.SelectAttrValue("name", "")
+		if description := tx.SelectElement("description"); description != nil {
+			tag.Description = description.Text()
+		}
+		namespace.SemanticTags = append(namespace.SemanticTags, tag)
+	}
+	return
+}
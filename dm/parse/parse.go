@@ -0,0 +1,214 @@
+package parse
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// DataModelParser reads the entities described by data model XML files, as written by the dm renderer
+type DataModelParser struct {
+}
+
+func NewDataModelParser() *DataModelParser {
+	return &DataModelParser{}
+}
+
+func (dp *DataModelParser) Name() string {
+	return "Parsing data model XML"
+}
+
+func (dp *DataModelParser) Process(cxt context.Context, input *pipeline.Data[[]byte], index int32, total int32) (outputs []*pipeline.Data[[]types.Entity], extras []*pipeline.Data[[]byte], err error) {
+	doc := etree.NewDocument()
+	err = doc.ReadFromBytes(input.Content)
+	if err != nil {
+		err = fmt.Errorf("error reading %s: %w", input.Path, err)
+		return
+	}
+	root := doc.Root()
+	if root == nil {
+		err = fmt.Errorf("error parsing %s: missing root element", input.Path)
+		return
+	}
+	var entities []types.Entity
+	switch root.Tag {
+	case "cluster":
+		entities, err = readCluster(root)
+	case "deviceType":
+		entities = append(entities, readDeviceType(root))
+	case "namespace":
+		entities = append(entities, readNamespace(root))
+	case "bitmaps", "enums", "structs", "typeDefs", "commands", "events":
+		entities, err = readGlobals(root)
+	default:
+		err = fmt.Errorf("unexpected top level element: %s", root.Tag)
+	}
+	if err != nil {
+		err = fmt.Errorf("error parsing %s: %w", input.Path, err)
+		return
+	}
+	outputs = append(outputs, pipeline.NewData[[]types.Entity](input.Path, entities))
+	return
+}
+
+func readGlobals(root *etree.Element) (entities []types.Entity, err error) {
+	for _, el := range root.ChildElements() {
+		switch el.Tag {
+		case "bitmap":
+			entities = append(entities, readBitmap(el, nil))
+		case "enum":
+			entities = append(entities, readEnum(el, nil))
+		case "struct":
+			entities = append(entities, readStruct(el, nil))
+		case "number":
+			entities = append(entities, readTypeDef(el, nil))
+		case "command":
+			entities = append(entities, readCommand(el, nil))
+		case "event":
+			entities = append(entities, readEvent(el, nil))
+		default:
+			err = fmt.Errorf("unexpected %s element: %s", root.Tag, el.Tag)
+			return
+		}
+	}
+	return
+}
+
+func readRevisions(el *etree.Element, parent types.Entity) (revisions matter.Revisions) {
+	history := el.SelectElement("revisionHistory")
+	if history == nil {
+		return
+	}
+	for _, rx := range history.SelectElements("revision") {
+		rev := matter.NewRevision(parent, nil)
+		rev.Number = matter.ParseNumber(rx.SelectAttrValue("revision", ""))
+		rev.Description = rx.SelectAttrValue("summary", "")
+		revisions = append(revisions, rev)
+	}
+	return
+}
+
+func readAccess(el *etree.Element, access *matter.Access) {
+	ax := el.SelectElement("access")
+	if ax == nil {
+		return
+	}
+	for _, a := range ax.Attr {
+		switch a.Key {
+		case "read":
+			if a.Value == "true" && access.Read == matter.PrivilegeUnknown {
+				access.Read = matter.PrivilegeView
+			}
+		case "write":
+			switch a.Value {
+			case "true":
+			case "optional":
+				access.OptionalWrite = true
+			default:
+				continue
+			}
+			if access.Write == matter.PrivilegeUnknown {
+				access.Write = matter.PrivilegeOperate
+			}
+		case "readPrivilege":
+			access.Read = privilege(a.Value)
+		case "writePrivilege":
+			access.Write = privilege(a.Value)
+		case "invokePrivilege":
+			access.Invoke = privilege(a.Value)
+		case "timed":
+			if a.Value == "true" {
+				access.Timing = matter.TimingTimed
+			}
+		case "fabricScoped":
+			if a.Value == "true" {
+				access.FabricScoping = matter.FabricScopingScoped
+			}
+		case "fabricSensitive":
+			if a.Value == "true" {
+				access.FabricSensitivity = matter.FabricSensitivitySensitive
+			}
+		}
+	}
+}
+
+func privilege(p string) matter.Privilege {
+	switch p {
+	case "view":
+		return matter.PrivilegeView
+	case "operate":
+		return matter.PrivilegeOperate
+	case "manage":
+		return matter.PrivilegeManage
+	case "admin", "administer":
+		return matter.PrivilegeAdminister
+	default:
+		return matter.PrivilegeUnknown
+	}
+}
+
+func readQuality(el *etree.Element) (q matter.Quality) {
+	qx := el.SelectElement("quality")
+	if qx == nil {
+		return
+	}
+	for _, a := range qx.Attr {
+		if a.Key == "persistence" {
+			switch a.Value {
+			case "fixed":
+				q |= matter.QualityFixed
+			case "nonVolatile":
+				q |= matter.QualityNonVolatile
+			}
+			continue
+		}
+		if v, _ := strconv.ParseBool(a.Value); !v {
+			continue
+		}
+		switch a.Key {
+		case "changeOmitted":
+			q |= matter.QualityChangedOmitted
+		case "nullable":
+			q |= matter.QualityNullable
+		case "scene":
+			q |= matter.QualityScene
+		case "reportable":
+			q |= matter.QualityReportable
+		case "singleton":
+			q |= matter.QualitySingleton
+		case "atomicWrite":
+			q |= matter.QualityAtomicWrite
+		case "diagnostics":
+			q |= matter.QualityDiagnostics
+		case "quieterReporting":
+			q |= matter.QualityQuieterReporting
+		case "sourceAttribution":
+			q |= matter.QualitySourceAttribution
+		case "largeMessage":
+			q |= matter.QualityLargeMessage
+		}
+	}
+	return
+}
+
+func readID(el *etree.Element, name string) *matter.Number {
+	id := el.SelectAttrValue(name, "")
+	if id == "" {
+		return matter.InvalidID
+	}
+	return matter.ParseNumber(id)
+}
+
+// capitalize restores the casing used in the spec for values the data model writes in lower case
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
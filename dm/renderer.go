@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log/slog"
 	"os"
 	"path/filepath"
 	"slices"
@@ -40,12 +39,7 @@ func (p *Renderer) Name() string {
 
 func (p *Renderer) Process(cxt context.Context, input *pipeline.Data[*asciidoc.Document], index int32, total int32) (outputs []*pipeline.Data[string], extra []*pipeline.Data[*asciidoc.Document], err error) {
 	doc := input.Content
-	library, ok := p.spec.LibraryForDocument(doc)
-	if !ok {
-		slog.ErrorContext(cxt, "missing library for doc", "doc", doc.Path)
-		err = nil
-	}
-	entities := library.Spec.EntitiesForDocument(doc)
+	entities := p.spec.EntitiesForDocument(doc)
 	var appClusters []types.Entity
 	var deviceTypes []*matter.DeviceType
 	var namespaces []*matter.Namespace
@@ -0,0 +1,257 @@
+package drift
+
+import (
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// The qualities which can be expressed in ZAP XML; any others are only ever present in the spec
+var comparableQualities matter.Quality = matter.QualityNullable | matter.QualityNonVolatile | matter.QualityFixed | matter.QualityScene |
+	matter.QualityReportable | matter.QualityChangedOmitted | matter.QualitySingleton | matter.QualityDiagnostics |
+	matter.QualityLargeMessage | matter.QualitySourceAttribution | matter.QualityAtomicWrite
+
+func (c *comparer) compareClusters() {
+	for id, specCluster := range c.spec.ClustersByID {
+		sdkCluster, ok := c.sdk.ClustersByID[id]
+		if !ok {
+			c.add(MismatchTypeMissingInSDK, specCluster, nil, specCluster.Name, "")
+			continue
+		}
+		c.compareCluster(specCluster, sdkCluster)
+	}
+	for id, sdkCluster := range c.sdk.ClustersByID {
+		if _, ok := c.spec.ClustersByID[id]; !ok {
+			c.add(MismatchTypeMissingInSpec, nil, sdkCluster, "", sdkCluster.Name)
+		}
+	}
+}
+
+func (c *comparer) compareCluster(specCluster *matter.Cluster, sdkCluster *matter.Cluster) {
+	compareFieldSets(c, specCluster.Attributes, sdkCluster.Attributes)
+	matchByID(c, specCluster.Commands, sdkCluster.Commands, commandKey, c.compareCommand)
+	matchByID(c, specCluster.Events, sdkCluster.Events, eventKey, c.compareEvent)
+}
+
+func (c *comparer) compareCommand(specCommand *matter.Command, sdkCommand *matter.Command) {
+	c.compareConformance(specCommand, sdkCommand, specCommand.Conformance, sdkCommand.Conformance)
+	c.compareAccess(specCommand, sdkCommand, specCommand.Access, sdkCommand.Access)
+	compareFieldSets(c, specCommand.Fields, sdkCommand.Fields)
+}
+
+func (c *comparer) compareEvent(specEvent *matter.Event, sdkEvent *matter.Event) {
+	c.compareConformance(specEvent, sdkEvent, specEvent.Conformance, sdkEvent.Conformance)
+	c.compareAccess(specEvent, sdkEvent, specEvent.Access, sdkEvent.Access)
+	compareFieldSets(c, specEvent.Fields, sdkEvent.Fields)
+}
+
+func compareFieldSets(c *comparer, specFields matter.FieldSet, sdkFields matter.FieldSet) {
+	matchByID(c, specFields, sdkFields, fieldKey, func(specField *matter.Field, sdkField *matter.Field) {
+		c.compareField(specField, specFields, sdkField, sdkFields)
+	})
+}
+
+func (c *comparer) compareField(specField *matter.Field, specFields matter.FieldSet, sdkField *matter.Field, sdkFields matter.FieldSet) {
+	if !dataTypesMatch(specField.Type, sdkField.Type) {
+		c.add(MismatchTypeType, specField, sdkField, dataTypeString(specField.Type), dataTypeString(sdkField.Type))
+	}
+	c.compareConstraint(specField, specFields, sdkField, sdkFields)
+	c.compareConformance(specField, sdkField, specField.Conformance, sdkField.Conformance)
+	if specField.EntityType() == types.EntityTypeAttribute {
+		c.compareAccess(specField, sdkField, specField.Access, sdkField.Access)
+	}
+	specQuality := specField.Quality & comparableQualities
+	sdkQuality := sdkField.Quality & comparableQualities
+	if specQuality != sdkQuality {
+		c.add(MismatchTypeQuality, specField, sdkField, specQuality.String(), sdkQuality.String())
+	}
+	c.compareDefault(specField, specFields, sdkField, sdkFields)
+}
+
+// compareConstraint compares the effective range of each field, rather than how the range is written; ZAP only
+// expresses minimums and maximums, so a mismatch is only reported when both sides have an extreme defined
+func (c *comparer) compareConstraint(specField *matter.Field, specFields matter.FieldSet, sdkField *matter.Field, sdkFields matter.FieldSet) {
+	if specField.Constraint == nil || sdkField.Constraint == nil {
+		return
+	}
+	specContext := matter.NewConstraintContext(specField, specFields)
+	sdkContext := matter.NewConstraintContext(sdkField, sdkFields)
+	if extremesMatch(specField.Constraint.Min(specContext), sdkField.Constraint.Min(sdkContext)) &&
+		extremesMatch(specField.Constraint.Max(specContext), sdkField.Constraint.Max(sdkContext)) {
+		return
+	}
+	c.add(MismatchTypeConstraint, specField, sdkField, specField.Constraint.ASCIIDocString(specField.Type), sdkField.Constraint.ASCIIDocString(sdkField.Type))
+}
+
+func (c *comparer) compareDefault(specField *matter.Field, specFields matter.FieldSet, sdkField *matter.Field, sdkFields matter.FieldSet) {
+	if constraint.IsBlankLimit(specField.Fallback) || constraint.IsBlankLimit(sdkField.Fallback) {
+		return
+	}
+	if constraint.IsGenericLimit(specField.Fallback) || constraint.IsGenericLimit(sdkField.Fallback) {
+		return
+	}
+	specDefault := specField.Fallback.Fallback(matter.NewConstraintContext(specField, specFields))
+	sdkDefault := sdkField.Fallback.Fallback(matter.NewConstraintContext(sdkField, sdkFields))
+	if specDefault.Defined() && sdkDefault.Defined() {
+		if extremesMatch(specDefault, sdkDefault) {
+			return
+		}
+	} else if strings.EqualFold(specField.Fallback.DataModelString(specField.Type), sdkField.Fallback.DataModelString(sdkField.Type)) {
+		return
+	}
+	c.add(MismatchTypeDefault, specField, sdkField, specField.Fallback.DataModelString(specField.Type), sdkField.Fallback.DataModelString(sdkField.Type))
+}
+
+func (c *comparer) compareConformance(specEntity types.Entity, sdkEntity types.Entity, specConformance conformance.Set, sdkConformance conformance.Set) {
+	if specConformance.Equal(sdkConformance) {
+		return
+	}
+	c.add(MismatchTypeConformance, specEntity, sdkEntity, specConformance.ASCIIDocString(), sdkConformance.ASCIIDocString())
+}
+
+func (c *comparer) compareAccess(specEntity types.Entity, sdkEntity types.Entity, specAccess matter.Access, sdkAccess matter.Access) {
+	if !accessMatches(specAccess, sdkAccess) {
+		c.add(MismatchTypeAccess, specEntity, sdkEntity, specAccess.String(), sdkAccess.String())
+	}
+}
+
+// accessMatches compares two access definitions, ignoring any component which either side leaves unspecified
+func accessMatches(a matter.Access, b matter.Access) bool {
+	if a.Read != matter.PrivilegeUnknown && b.Read != matter.PrivilegeUnknown && a.Read != b.Read {
+		return false
+	}
+	if a.Write != matter.PrivilegeUnknown && b.Write != matter.PrivilegeUnknown && a.Write != b.Write {
+		return false
+	}
+	if (a.Write == matter.PrivilegeUnknown) != (b.Write == matter.PrivilegeUnknown) {
+		return false
+	}
+	if a.Invoke != matter.PrivilegeUnknown && b.Invoke != matter.PrivilegeUnknown && a.Invoke != b.Invoke {
+		return false
+	}
+	if a.FabricScoping != matter.FabricScopingUnknown && b.FabricScoping != matter.FabricScopingUnknown && a.FabricScoping != b.FabricScoping {
+		return false
+	}
+	if a.FabricSensitivity != matter.FabricSensitivityUnknown && b.FabricSensitivity != matter.FabricSensitivityUnknown && a.FabricSensitivity != b.FabricSensitivity {
+		return false
+	}
+	if a.Timing != matter.TimingUnknown && b.Timing != matter.TimingUnknown && a.Timing != b.Timing {
+		return false
+	}
+	return true
+}
+
+// dataTypesMatch compares two data types by their base type, and by name for custom types; the spec
+// and ZAP spell the same base types differently, e.g. "uint8" and "int8u"
+func dataTypesMatch(a *types.DataType, b *types.DataType) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if a.BaseType != b.BaseType {
+		return false
+	}
+	switch a.BaseType {
+	case types.BaseDataTypeCustom:
+		return strings.EqualFold(a.Name, b.Name)
+	case types.BaseDataTypeList:
+		return dataTypesMatch(a.EntryType, b.EntryType)
+	}
+	return true
+}
+
+func dataTypeString(dt *types.DataType) string {
+	if dt == nil {
+		return ""
+	}
+	if dt.IsArray() && dt.EntryType != nil {
+		return "list[" + dataTypeString(dt.EntryType) + "]"
+	}
+	return dt.Name
+}
+
+func extremesMatch(a types.DataTypeExtreme, b types.DataTypeExtreme) bool {
+	if !a.Defined() || !b.Defined() {
+		return true
+	}
+	if a.IsNumeric() && b.IsNumeric() {
+		return a.ValueEquals(b)
+	}
+	return a.Type == b.Type
+}
+
+type idKey struct {
+	id        uint64
+	direction matter.Interface
+}
+
+func fieldKey(f *matter.Field) (idKey, bool) {
+	if !f.ID.Valid() {
+		return idKey{}, false
+	}
+	return idKey{id: f.ID.Value()}, true
+}
+
+func commandKey(cmd *matter.Command) (idKey, bool) {
+	if !cmd.ID.Valid() {
+		return idKey{}, false
+	}
+	return idKey{id: cmd.ID.Value(), direction: cmd.Direction}, true
+}
+
+func eventKey(e *matter.Event) (idKey, bool) {
+	if !e.ID.Valid() {
+		return idKey{}, false
+	}
+	return idKey{id: e.ID.Value()}, true
+}
+
+// matchByID pairs up the entities in the spec and SDK sets with the same ID, and reports the entities
+// present on only one side
+func matchByID[T types.Entity](c *comparer, specEntities []T, sdkEntities []T, key func(T) (idKey, bool), compare func(specEntity T, sdkEntity T)) {
+	sdkByID := make(map[idKey]T, len(sdkEntities))
+	for _, e := range sdkEntities {
+		if k, ok := key(e); ok {
+			sdkByID[k] = e
+		}
+	}
+	matched := make(map[idKey]struct{}, len(specEntities))
+	for _, specEntity := range specEntities {
+		k, ok := key(specEntity)
+		if !ok {
+			continue
+		}
+		sdkEntity, ok := sdkByID[k]
+		if !ok {
+			c.add(MismatchTypeMissingInSDK, specEntity, nil, entityName(specEntity), "")
+			continue
+		}
+		matched[k] = struct{}{}
+		compare(specEntity, sdkEntity)
+	}
+	for _, sdkEntity := range sdkEntities {
+		k, ok := key(sdkEntity)
+		if !ok {
+			continue
+		}
+		if _, ok := matched[k]; !ok {
+			c.add(MismatchTypeMissingInSpec, nil, sdkEntity, "", entityName(sdkEntity))
+		}
+	}
+}
+
+func entityName(entity types.Entity) string {
+	switch entity := entity.(type) {
+	case *matter.Cluster:
+		return entity.Name
+	case *matter.Field:
+		return entity.Name
+	case *matter.Command:
+		return entity.Name
+	case *matter.Event:
+		return entity.Name
+	}
+	return ""
+}
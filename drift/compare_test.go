@@ -0,0 +1,108 @@
+package drift
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func newTestCluster(id uint64, name string) *matter.Cluster {
+	c := matter.NewCluster(nil)
+	c.ID = matter.NewNumber(id)
+	c.Name = name
+	return c
+}
+
+func newTestAttribute(c *matter.Cluster, id uint64, name string, dataType *types.DataType) *matter.Field {
+	a := matter.NewAttribute(nil, c)
+	a.ID = matter.NewNumber(id)
+	a.Name = name
+	a.Type = dataType
+	a.Access = matter.DefaultAccess(types.EntityTypeAttribute)
+	a.Conformance = conformance.Set{&conformance.Mandatory{}}
+	c.Attributes = append(c.Attributes, a)
+	return a
+}
+
+func newTestCommand(c *matter.Cluster, id uint64, name string) *matter.Command {
+	cmd := matter.NewCommand(nil, c)
+	cmd.ID = matter.NewNumber(id)
+	cmd.Name = name
+	cmd.Direction = matter.InterfaceServer
+	cmd.Access = matter.DefaultAccess(types.EntityTypeCommand)
+	cmd.Conformance = conformance.Set{&conformance.Mandatory{}}
+	c.Commands = append(c.Commands, cmd)
+	return cmd
+}
+
+func newTestSpec(clusters ...*matter.Cluster) *spec.Specification {
+	s := &spec.Specification{ClustersByID: make(map[uint64]*matter.Cluster), DocRefs: make(map[types.Entity]*asciidoc.Document)}
+	for _, c := range clusters {
+		s.ClustersByID[c.ID.Value()] = c
+	}
+	return s
+}
+
+func TestCompare(t *testing.T) {
+	specCluster := newTestCluster(0x0006, "On/Off")
+	newTestAttribute(specCluster, 0x0000, "OnOff", types.NewDataType(types.BaseDataTypeBoolean, types.DataTypeRankScalar)).Quality = matter.QualityReportable | matter.QualityQuieterReporting
+	newTestAttribute(specCluster, 0x4003, "StartUpOnOff", types.NewCustomDataType("StartUpOnOffEnum", types.DataTypeRankScalar))
+	onTime := newTestAttribute(specCluster, 0x4001, "OnTime", types.NewDataType(types.BaseDataTypeUInt16, types.DataTypeRankScalar))
+	onTime.Fallback = constraint.ParseLimit("0")
+	onTime.Constraint = constraint.ParseString("max 1000")
+	newTestCommand(specCluster, 0x01, "On")
+	newTestCommand(specCluster, 0x40, "OffWithEffect").Conformance = conformance.Set{&conformance.Optional{}}
+	newTestAttribute(specCluster, 0x4000, "GlobalSceneControl", types.NewDataType(types.BaseDataTypeBoolean, types.DataTypeRankScalar)).Conformance = conformance.ParseConformance("LT, O.a")
+	newTestAttribute(specCluster, 0x4002, "OffWaitTime", types.NewDataType(types.BaseDataTypeUInt16, types.DataTypeRankScalar)).Conformance = conformance.ParseConformance("[LT]")
+	specOnlyCluster := newTestCluster(0x0008, "Level Control")
+
+	sdkCluster := newTestCluster(0x0006, "On/Off")
+	newTestAttribute(sdkCluster, 0x0000, "OnOff", types.NewDataType(types.BaseDataTypeBoolean, types.DataTypeRankScalar)).Quality = matter.QualityReportable
+	newTestAttribute(sdkCluster, 0x4003, "StartUpOnOff", types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankScalar))
+	sdkOnTime := newTestAttribute(sdkCluster, 0x4001, "OnTime", types.NewDataType(types.BaseDataTypeUInt16, types.DataTypeRankScalar))
+	sdkOnTime.Fallback = constraint.ParseLimit("0x0001")
+	sdkOnTime.Constraint = constraint.ParseString("0 to 1000")
+	sdkOnTime.Access.Write = matter.PrivilegeOperate
+	newTestCommand(sdkCluster, 0x02, "Toggle")
+	newTestCommand(sdkCluster, 0x40, "OffWithEffect")
+	// Only the choice differs
+	newTestAttribute(sdkCluster, 0x4000, "GlobalSceneControl", types.NewDataType(types.BaseDataTypeBoolean, types.DataTypeRankScalar)).Conformance = conformance.ParseConformance("LT, O.b")
+	newTestAttribute(sdkCluster, 0x4002, "OffWaitTime", types.NewDataType(types.BaseDataTypeUInt16, types.DataTypeRankScalar)).Conformance = conformance.Set{&conformance.Optional{Expression: &conformance.IdentifierExpression{ID: "LT"}}}
+	sdkOnlyCluster := newTestCluster(0x0045, "Boolean State")
+
+	report := Compare(newTestSpec(specCluster, specOnlyCluster), newTestSpec(sdkCluster, sdkOnlyCluster))
+
+	type expectedMismatch struct {
+		mismatchType MismatchType
+		name         string
+	}
+	expected := []expectedMismatch{
+		{MismatchTypeMissingInSDK, "Level Control"},
+		{MismatchTypeMissingInSpec, "Boolean State"},
+		{MismatchTypeType, "StartUpOnOff"},
+		{MismatchTypeAccess, "OnTime"},
+		{MismatchTypeDefault, "OnTime"},
+		{MismatchTypeMissingInSDK, "On"},
+		{MismatchTypeMissingInSpec, "Toggle"},
+		{MismatchTypeConformance, "OffWithEffect"},
+		{MismatchTypeConformance, "GlobalSceneControl"},
+	}
+	var actual []expectedMismatch
+	for _, m := range report.Mismatches {
+		actual = append(actual, expectedMismatch{m.Type, m.Entity.Name})
+	}
+	for _, e := range expected {
+		if !slices.Contains(actual, e) {
+			t.Errorf("missing expected %s mismatch for %s", e.mismatchType, e.name)
+		}
+	}
+	if len(actual) != len(expected) {
+		t.Errorf("unexpected mismatch count: expected %d, got %d: %v", len(expected), len(actual), actual)
+	}
+}
@@ -0,0 +1,64 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteText writes one line per mismatch, prefixed with the location of the entity in the spec
+func (r *Report) WriteText(w io.Writer) (err error) {
+	for _, m := range r.Mismatches {
+		var sb strings.Builder
+		if m.Path != "" {
+			sb.WriteString(m.Path)
+			if m.Line > 0 {
+				fmt.Fprintf(&sb, ":%d", m.Line)
+			}
+			sb.WriteString(": ")
+		}
+		sb.WriteString(m.Entity.String())
+		sb.WriteString(": ")
+		sb.WriteString(m.Type.String())
+		switch m.Type {
+		case MismatchTypeMissingInSDK, MismatchTypeMissingInSpec:
+		default:
+			fmt.Fprintf(&sb, ": spec %q, sdk %q", m.Spec, m.SDK)
+		}
+		if m.SDKPath != "" {
+			fmt.Fprintf(&sb, " (%s)", m.SDKPath)
+		}
+		sb.WriteRune('\n')
+		_, err = io.WriteString(w, sb.String())
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (e Entity) String() string {
+	var sb strings.Builder
+	sb.WriteString(e.Type.String())
+	sb.WriteRune(' ')
+	if e.Cluster != "" && e.Cluster != e.Name {
+		sb.WriteString(e.Cluster)
+		sb.WriteRune('.')
+	}
+	if e.Parent != "" {
+		sb.WriteString(e.Parent)
+		sb.WriteRune('.')
+	}
+	sb.WriteString(e.Name)
+	if e.ID != "" {
+		fmt.Fprintf(&sb, " (%s)", e.ID)
+	}
+	return sb.String()
+}
@@ -0,0 +1,178 @@
+package drift
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type MismatchType uint8
+
+const (
+	MismatchTypeNone MismatchType = iota
+	MismatchTypeMissingInSDK
+	MismatchTypeMissingInSpec
+	MismatchTypeType
+	MismatchTypeConstraint
+	MismatchTypeConformance
+	MismatchTypeAccess
+	MismatchTypeQuality
+	MismatchTypeDefault
+)
+
+var mismatchTypeNames = map[MismatchType]string{
+	MismatchTypeNone:          "none",
+	MismatchTypeMissingInSDK:  "missing-in-sdk",
+	MismatchTypeMissingInSpec: "missing-in-spec",
+	MismatchTypeType:          "type",
+	MismatchTypeConstraint:    "constraint",
+	MismatchTypeConformance:   "conformance",
+	MismatchTypeAccess:        "access",
+	MismatchTypeQuality:       "quality",
+	MismatchTypeDefault:       "default",
+}
+
+func (mt MismatchType) String() string {
+	if name, ok := mismatchTypeNames[mt]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(mt))
+}
+
+func (mt MismatchType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mt.String())
+}
+
+// Report is the set of differences between the entities built from the spec and the entities
+// read from the SDK's ZAP templates
+type Report struct {
+	SpecRoot   string     `json:"specRoot,omitempty"`
+	SDKRoot    string     `json:"sdkRoot,omitempty"`
+	Mismatches []Mismatch `json:"mismatches"`
+}
+
+type Mismatch struct {
+	Type    MismatchType `json:"type"`
+	Entity  Entity       `json:"entity"`
+	Spec    string       `json:"spec,omitempty"`
+	SDK     string       `json:"sdk,omitempty"`
+	Path    string       `json:"path,omitempty"`
+	Line    int          `json:"line,omitempty"`
+	SDKPath string       `json:"sdkPath,omitempty"`
+}
+
+type Entity struct {
+	Type    types.EntityType `json:"type"`
+	Name    string           `json:"name,omitempty"`
+	ID      string           `json:"id,omitempty"`
+	Cluster string           `json:"cluster,omitempty"`
+	Parent  string           `json:"parent,omitempty"`
+}
+
+// Compare matches the clusters in the spec against the clusters in the SDK by ID, and reports
+// any differences between their attributes, commands and events
+func Compare(specification *spec.Specification, sdk *spec.Specification) *Report {
+	c := &comparer{spec: specification, sdk: sdk, report: &Report{SpecRoot: specification.Root, SDKRoot: sdk.Root, Mismatches: []Mismatch{}}}
+	c.compareClusters()
+	slices.SortStableFunc(c.report.Mismatches, func(a Mismatch, b Mismatch) int {
+		return cmp.Or(cmp.Compare(a.Entity.Cluster, b.Entity.Cluster),
+			cmp.Compare(a.Path, b.Path),
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.Entity.Parent, b.Entity.Parent),
+			cmp.Compare(a.Entity.ID, b.Entity.ID),
+			cmp.Compare(a.Entity.Name, b.Entity.Name),
+			cmp.Compare(a.Type, b.Type))
+	})
+	return c.report
+}
+
+type comparer struct {
+	spec   *spec.Specification
+	sdk    *spec.Specification
+	report *Report
+}
+
+func (c *comparer) add(mt MismatchType, specEntity types.Entity, sdkEntity types.Entity, specValue string, sdkValue string) {
+	m := Mismatch{Type: mt, Spec: specValue, SDK: sdkValue}
+	entity := specEntity
+	if entity == nil {
+		entity = sdkEntity
+	}
+	m.Entity = newEntity(entity)
+	m.Path, m.Line = c.specOrigin(specEntity, sdkEntity)
+	m.SDKPath = c.sdkPath(sdkEntity)
+	c.report.Mismatches = append(c.report.Mismatches, m)
+}
+
+// specOrigin returns the location in the spec of the entity, or of the closest ancestor which exists in the spec
+func (c *comparer) specOrigin(specEntity types.Entity, sdkEntity types.Entity) (path string, line int) {
+	if specEntity == nil {
+		specEntity = c.specCluster(sdkEntity)
+		if specEntity == nil {
+			return
+		}
+	}
+	path, line = specEntity.Origin()
+	path = spec.RelativePath(c.spec.Root, path)
+	if line < 0 {
+		line = 0
+	}
+	return
+}
+
+func (c *comparer) specCluster(sdkEntity types.Entity) types.Entity {
+	cluster := matter.EntityCluster(sdkEntity)
+	if cluster == nil || !cluster.ID.Valid() {
+		return nil
+	}
+	if specCluster, ok := c.spec.ClustersByID[cluster.ID.Value()]; ok {
+		return specCluster
+	}
+	return nil
+}
+
+func (c *comparer) sdkPath(sdkEntity types.Entity) string {
+	cluster := matter.EntityCluster(sdkEntity)
+	if cluster == nil {
+		return ""
+	}
+	doc, ok := c.sdk.DocRefs[cluster]
+	if !ok {
+		return ""
+	}
+	return filepath.ToSlash(doc.Path.Relative)
+}
+
+func newEntity(entity types.Entity) (e Entity) {
+	e.Type = entity.EntityType()
+	switch entity := entity.(type) {
+	case *matter.Cluster:
+		e.Name = entity.Name
+		e.ID = entity.ID.ValidHexString()
+	case *matter.Field:
+		e.Name = entity.Name
+		e.ID = entity.ID.ValidHexString()
+		switch parent := entity.Parent().(type) {
+		case *matter.Command:
+			e.Parent = parent.Name
+		case *matter.Event:
+			e.Parent = parent.Name
+		}
+	case *matter.Command:
+		e.Name = entity.Name
+		e.ID = entity.ID.ValidHexString()
+	case *matter.Event:
+		e.Name = entity.Name
+		e.ID = entity.ID.ValidHexString()
+	}
+	if cluster := matter.EntityCluster(entity); cluster != nil {
+		e.Cluster = cluster.Name
+	}
+	return
+}
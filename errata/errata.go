@@ -16,6 +16,9 @@ type Collection struct {
 }
 
 func (c *Collection) Get(path string) *Errata {
+	if c == nil {
+		return DefaultErrata
+	}
 	errata, ok := c.errata[path]
 	if ok {
 		return errata
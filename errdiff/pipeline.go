@@ -15,11 +15,12 @@ type ComparableError interface {
 	ComparableEntity() types.Entity
 }
 
-func Pipeline(cxt context.Context, baseRoot string, headRoot string, docPaths []string, pipelineOptions pipeline.ProcessingOptions) (violations map[string][]spec.Violation, err error) {
-	specs, err := spec.LoadSpecPullRequest(cxt, baseRoot, headRoot, pipelineOptions)
+func Pipeline(cxt context.Context, options spec.PullRequestOptions, docPaths []string, pipelineOptions pipeline.ProcessingOptions) (violations map[string][]spec.Violation, err error) {
+	specs, cleanup, err := spec.LoadSpecPullRequest(cxt, options, pipelineOptions)
 	if err != nil {
 		return
 	}
+	defer cleanup()
 
 	violations = ProcessComparison(&specs)
 	return
@@ -0,0 +1,443 @@
+package featuremap
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+)
+
+// MaxSearch is the most steps Enumerate takes through the combinations of a cluster's features while counting
+// the valid ones; clusters with more are sampled instead, and their count is only a lower bound
+const MaxSearch = 1 << 16
+
+// sampleAttempts is how many random descents sampling makes for each combination it is asked for, before settling
+// for fewer
+const sampleAttempts = 64
+
+// Feature describes one of the features of a cluster
+type Feature struct {
+	Bit         string `json:"bit"`
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Conformance string `json:"conformance"`
+}
+
+// Element is an attribute, command or event of a cluster
+type Element struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Conformance string `json:"conformance"`
+}
+
+// Combination is a valid value of a cluster's FeatureMap, with the elements the cluster must and may implement
+// when it supports exactly those features
+type Combination struct {
+	FeatureMap uint64    `json:"featureMap"`
+	Features   []string  `json:"features,omitempty"`
+	Mandatory  []Element `json:"mandatory,omitempty"`
+	Optional   []Element `json:"optional,omitempty"`
+}
+
+// Combinations lists the valid FeatureMap values of a cluster; if Sampled, Combinations holds a random sample of
+// the Count valid values. If Partial, there were too many combinations to count, and Count is only a lower bound.
+type Combinations struct {
+	Cluster      string         `json:"cluster"`
+	ID           string         `json:"id"`
+	Features     []Feature      `json:"features"`
+	Count        int            `json:"count"`
+	Partial      bool           `json:"partial,omitempty"`
+	Sampled      bool           `json:"sampled"`
+	Combinations []*Combination `json:"combinations"`
+}
+
+type Options struct {
+	// Limit is the most combinations to list; if more are valid, a random sample of Limit of them is listed. Zero
+	// lists every valid combination.
+	Limit int
+	// Seed seeds the random sample, so that a sample can be reproduced
+	Seed uint64
+}
+
+// Enumerate finds every value of cluster's FeatureMap which satisfies the conformance of its features, including
+// choice constraints such as O.a+, and lists the mandatory and optional elements of each. Features are decided one
+// at a time, so combinations are only checked in full if no feature decided so far rules them out. If that takes
+// more than MaxSearch steps, combinations are sampled by random descents through the features instead.
+func Enumerate(cluster *matter.Cluster, options Options) (fc *Combinations, err error) {
+	features := slices.Collect(cluster.Features.FeatureBits())
+	if len(features) > 64 {
+		return nil, fmt.Errorf("cluster %s has %d features; at most 64 can be enumerated", cluster.Name, len(features))
+	}
+	fc = &Combinations{Cluster: cluster.Name, ID: cluster.ID.HexString(), Features: make([]Feature, 0, len(features))}
+	masks := make([]uint64, len(features))
+	for i, f := range features {
+		masks[i], err = f.Mask()
+		if err != nil {
+			return nil, fmt.Errorf("invalid bit for feature %s on cluster %s: %w", f.Code, cluster.Name, err)
+		}
+		fc.Features = append(fc.Features, Feature{Bit: f.Bit(), Code: f.Code, Name: f.Name(), Conformance: f.Conformance().ASCIIDocString()})
+	}
+
+	var s *search
+	s, err = newSearch(cluster, features)
+	if err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewPCG(options.Seed, options.Seed))
+	// Subsets are bitsets of indexes into features, rather than FeatureMap values
+	var selected []uint64
+	var steps int
+	var complete bool
+	complete, err = s.walk(func(subset uint64) {
+		fc.Count++
+		if options.Limit <= 0 || len(selected) < options.Limit {
+			selected = append(selected, subset)
+			return
+		}
+		// Reservoir sampling, so that every valid combination is equally likely to be listed
+		if i := rng.IntN(fc.Count); i < options.Limit {
+			selected[i] = subset
+		}
+	}, &steps)
+	if err != nil {
+		return nil, err
+	}
+	if complete {
+		fc.Sampled = options.Limit > 0 && fc.Count > options.Limit
+	} else {
+		if options.Limit <= 0 {
+			return nil, fmt.Errorf("cluster %s has too many combinations of features to list them all; set a limit to sample them", cluster.Name)
+		}
+		fc.Partial = true
+		fc.Sampled = true
+		selected, err = s.sample(rng, options.Limit)
+		if err != nil {
+			return nil, err
+		}
+		fc.Count = max(fc.Count, len(selected))
+	}
+
+	fc.Combinations = make([]*Combination, 0, len(selected))
+	for _, subset := range selected {
+		var c *Combination
+		c, err = combination(cluster, features, masks, subset)
+		if err != nil {
+			return nil, err
+		}
+		fc.Combinations = append(fc.Combinations, c)
+	}
+	slices.SortFunc(fc.Combinations, func(a *Combination, b *Combination) int {
+		return cmp.Compare(a.FeatureMap, b.FeatureMap)
+	})
+	return
+}
+
+// search decides the features of a cluster in order; features not yet decided are possible in its context, so
+// conformance which depends on them is not definite, and can't rule out the features decided so far
+type search struct {
+	features []*matter.Feature
+	cxt      *conformance.BasicContext
+	decided  int
+	subset   uint64
+}
+
+func newSearch(cluster *matter.Cluster, features []*matter.Feature) (*search, error) {
+	var implementation matter.ClusterImplementation
+	cxt, err := implementation.Context(cluster)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range features {
+		cxt.Values[f.Code] = conformance.ConfidencePossible
+	}
+	return &search{features: features, cxt: cxt}, nil
+}
+
+func (s *search) decide(supported bool) {
+	i := s.decided
+	s.cxt.Values[s.features[i].Code] = supported
+	if supported {
+		s.subset |= 1 << i
+	}
+	s.decided++
+}
+
+func (s *search) undecide() {
+	s.decided--
+	i := s.decided
+	s.cxt.Values[s.features[i].Code] = conformance.ConfidencePossible
+	s.subset &^= 1 << i
+}
+
+// next returns the values the next feature may take: only supported if its conformance is definitely mandatory,
+// only unsupported if it is definitely disallowed, and either otherwise
+func (s *search) next() ([]bool, error) {
+	cs := s.features[s.decided].Conformance()
+	if !conformance.IsBlank(cs) {
+		state, err := cs.Eval(s.cxt)
+		if err != nil {
+			return nil, err
+		}
+		if state.Confidence == conformance.ConfidenceDefinite {
+			switch state.State {
+			case conformance.StateMandatory:
+				return []bool{true}, nil
+			case conformance.StateDisallowed:
+				return []bool{false}, nil
+			}
+		}
+	}
+	return []bool{false, true}, nil
+}
+
+// walk decides the remaining features in every consistent way, passing each valid subset to found; it returns
+// false if it gave up after MaxSearch steps
+func (s *search) walk(found func(subset uint64), steps *int) (complete bool, err error) {
+	if s.decided == len(s.features) {
+		found(s.subset)
+		return true, nil
+	}
+	var values []bool
+	values, err = s.next()
+	if err != nil {
+		return
+	}
+	for _, supported := range values {
+		*steps++
+		if *steps > MaxSearch {
+			return false, nil
+		}
+		s.decide(supported)
+		var ok bool
+		ok, err = s.consistent()
+		if err == nil && ok {
+			complete, err = s.walk(found, steps)
+		} else {
+			complete = true
+		}
+		s.undecide()
+		if err != nil || !complete {
+			return
+		}
+	}
+	return true, nil
+}
+
+// sample descends through the features at random, choosing among the values which keep the features decided so
+// far consistent, until it has found limit distinct valid subsets or run out of attempts
+func (s *search) sample(rng *rand.Rand, limit int) (selected []uint64, err error) {
+	seen := make(map[uint64]struct{}, limit)
+	for range limit * sampleAttempts {
+		if len(selected) == limit {
+			break
+		}
+		var subset uint64
+		var ok bool
+		subset, ok, err = s.descend(rng)
+		for s.decided > 0 {
+			s.undecide()
+		}
+		if err != nil {
+			return
+		}
+		if !ok {
+			continue
+		}
+		if _, taken := seen[subset]; !taken {
+			seen[subset] = struct{}{}
+			selected = append(selected, subset)
+		}
+	}
+	return
+}
+
+func (s *search) descend(rng *rand.Rand) (subset uint64, ok bool, err error) {
+	for s.decided < len(s.features) {
+		var values []bool
+		values, err = s.next()
+		if err != nil {
+			return
+		}
+		rng.Shuffle(len(values), func(i, j int) { values[i], values[j] = values[j], values[i] })
+		ok = false
+		for _, supported := range values {
+			s.decide(supported)
+			ok, err = s.consistent()
+			if err != nil || ok {
+				break
+			}
+			s.undecide()
+		}
+		if err != nil || !ok {
+			return
+		}
+	}
+	return s.subset, true, nil
+}
+
+// consistent checks that no decided feature is definitely mandatory but unsupported or definitely disallowed but
+// supported, and that every choice set can still have an allowed number of supported features. Once every
+// feature is decided, each choice set must have an allowed number.
+func (s *search) consistent() (bool, error) {
+	complete := s.decided == len(s.features)
+	choices := make(map[string]*choiceCount)
+	// Features which may yet be supported members of a choice set, although which set isn't known
+	var open int64
+	for i, f := range s.features {
+		cs := f.Conformance()
+		if conformance.IsBlank(cs) {
+			continue
+		}
+		decided := i < s.decided
+		supported := s.subset&(1<<i) != 0
+		if decided {
+			state, err := cs.Eval(s.cxt)
+			if err != nil {
+				return false, err
+			}
+			// Conformance which depends on values the features don't decide can't rule out a combination
+			if state.Confidence == conformance.ConfidenceDefinite {
+				switch state.State {
+				case conformance.StateMandatory:
+					if !supported {
+						return false, nil
+					}
+				case conformance.StateDisallowed:
+					if supported {
+						return false, nil
+					}
+				}
+			}
+		}
+		choice, definite := activeChoice(cs, s.cxt)
+		if !definite && !complete {
+			if supported || !decided {
+				open++
+			}
+			continue
+		}
+		if choice == nil {
+			continue
+		}
+		cc, ok := choices[choice.Set]
+		if !ok {
+			cc = &choiceCount{limit: choice.Limit}
+			choices[choice.Set] = cc
+		}
+		switch {
+		case supported:
+			cc.count++
+		case !decided:
+			cc.open++
+		}
+	}
+	for _, cc := range choices {
+		lo, hi := cc.bounds()
+		if hi >= 0 && cc.count > hi {
+			return false, nil
+		}
+		if complete {
+			if cc.count < lo {
+				return false, nil
+			}
+		} else if cc.count+cc.open+open < lo {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// activeChoice returns the choice of the conformance which applies to an element, if it is an optional choice;
+// as in Set.Eval, the first conformance with a known state applies. The choice is definite if the state of that
+// conformance is.
+func activeChoice(cs conformance.Set, cxt conformance.Context) (choice *conformance.Choice, definite bool) {
+	for _, c := range cs {
+		state, err := c.Eval(cxt)
+		if err != nil {
+			return nil, false
+		}
+		if state.State == conformance.StateUnknown {
+			continue
+		}
+		definite = state.Confidence == conformance.ConfidenceDefinite
+		o, ok := c.(*conformance.Optional)
+		if !ok || o.Choice == nil || o.Choice.Set == "" {
+			return nil, definite
+		}
+		return o.Choice, definite
+	}
+	return nil, true
+}
+
+// choiceCount counts the supported members of a choice set, and those which are not yet decided
+type choiceCount struct {
+	limit conformance.ChoiceLimit
+	count int64
+	open  int64
+}
+
+// bounds returns the fewest and most members of the choice set which may be supported; hi is negative if there
+// is no most
+func (cc *choiceCount) bounds() (lo int64, hi int64) {
+	switch limit := cc.limit.(type) {
+	case nil:
+		// A choice without a limit, e.g. O.a, is a choice of exactly one
+		return 1, 1
+	case *conformance.ChoiceExactLimit:
+		return limit.Limit, limit.Limit
+	case *conformance.ChoiceMinLimit:
+		return limit.Min, -1
+	case *conformance.ChoiceMaxLimit:
+		return 0, limit.Max
+	case *conformance.ChoiceRangeLimit:
+		return limit.Min, limit.Max
+	default:
+		return 0, -1
+	}
+}
+
+// combination resolves the conformance of the attributes, commands and events of cluster when it supports the
+// features in subset; elements which are mandatory only under conditions the features don't decide are listed as
+// optional
+func combination(cluster *matter.Cluster, features []*matter.Feature, masks []uint64, subset uint64) (c *Combination, err error) {
+	c = &Combination{Features: supportedCodes(features, subset)}
+	for i := range features {
+		if subset&(1<<i) != 0 {
+			c.FeatureMap |= masks[i]
+		}
+	}
+	var elements []matter.ElementConformance
+	elements, err = matter.EvaluateClusterConformance(cluster, matter.ClusterImplementation{Features: c.Features})
+	if err != nil {
+		return
+	}
+	for _, e := range elements {
+		if e.Parent != cluster {
+			continue
+		}
+		el := Element{Type: e.Entity.EntityType().String(), Name: matter.EntityName(e.Entity), Conformance: e.Conformance.ASCIIDocString()}
+		switch e.State.State {
+		case conformance.StateMandatory:
+			if e.State.Confidence == conformance.ConfidenceDefinite {
+				c.Mandatory = append(c.Mandatory, el)
+			} else {
+				c.Optional = append(c.Optional, el)
+			}
+		case conformance.StateOptional:
+			c.Optional = append(c.Optional, el)
+		}
+	}
+	return
+}
+
+func supportedCodes(features []*matter.Feature, subset uint64) (codes []string) {
+	for i, f := range features {
+		if subset&(1<<i) != 0 {
+			codes = append(codes, f.Code)
+		}
+	}
+	return
+}
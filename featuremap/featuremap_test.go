@@ -0,0 +1,173 @@
+package featuremap
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+)
+
+func TestEnumerate(t *testing.T) {
+	cluster := matter.NewCluster(nil)
+	cluster.ID = matter.NewNumber(0xFFF1)
+	cluster.Name = "Sample"
+	cluster.Features = matter.NewFeatures(nil, cluster)
+	for i, f := range []struct{ code, conformance string }{
+		{"LT", "O.a+"},
+		{"DF", "O.a+"},
+		{"XX", "LT & DF"},
+		{"OF", "[LT]"},
+	} {
+		cluster.Features.AddFeatureBit(matter.NewFeature(nil, strconv.Itoa(i), f.code, f.code, "", conformance.ParseConformance(f.conformance)))
+	}
+	for _, a := range []struct{ name, conformance string }{
+		{"OnOff", "M"},
+		{"Level", "LT"},
+		{"Offset", "OF"},
+	} {
+		attribute := matter.NewAttribute(nil, cluster)
+		attribute.Name = a.name
+		attribute.Conformance = conformance.ParseConformance(a.conformance)
+		cluster.Attributes = append(cluster.Attributes, attribute)
+	}
+
+	combinations, err := Enumerate(cluster, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range combinations.Combinations {
+		got = append(got, strings.Join(c.Features, "|"))
+	}
+	expected := []string{"LT", "DF", "LT|DF|XX", "LT|OF", "LT|DF|XX|OF"}
+	if combinations.Count != len(expected) || !slices.Equal(got, expected) {
+		t.Fatalf("expected combinations %v, got %d: %v", expected, combinations.Count, got)
+	}
+	if fm := combinations.Combinations[4].FeatureMap; fm != 0xF {
+		t.Errorf("expected FeatureMap 0xF, got 0x%X", fm)
+	}
+	var mandatory []string
+	for _, e := range combinations.Combinations[3].Mandatory {
+		mandatory = append(mandatory, e.Name)
+	}
+	if !slices.Equal(mandatory, []string{"OnOff", "Level", "Offset"}) {
+		t.Errorf("unexpected mandatory elements for LT|OF: %v", mandatory)
+	}
+
+	sampled, err := Enumerate(cluster, Options{Limit: 2, Seed: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sampled.Sampled || sampled.Count != len(expected) || len(sampled.Combinations) != 2 {
+		t.Errorf("expected a sample of 2 of %d combinations, got %d of %d", len(expected), len(sampled.Combinations), sampled.Count)
+	}
+	again, err := Enumerate(cluster, Options{Limit: 2, Seed: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range again.Combinations {
+		if again.Combinations[i].FeatureMap != sampled.Combinations[i].FeatureMap {
+			t.Errorf("expected the same seed to give the same sample")
+		}
+	}
+}
+
+func TestEnumeratePrunes(t *testing.T) {
+	// Without pruning, each of these would take more than MaxSearch steps
+	for _, test := range []struct {
+		name     string
+		features func(i int) string
+		count    int
+	}{
+		// Mandatory and disallowed features only have one value, leaving the last two
+		{"fixed", func(i int) string {
+			switch {
+			case i >= 28:
+				return "O"
+			case i%2 == 0:
+				return "M"
+			default:
+				return "X"
+			}
+		}, 4},
+		// No more than one member of a choice of one is supported
+		{"choice", func(i int) string { return "O.a" }, 30},
+		// Features which depend on others are decided by them
+		{"dependent", func(i int) string {
+			if i == 0 {
+				return "O"
+			}
+			return "F0"
+		}, 2},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := largeCluster(30, test.features)
+			combinations, err := Enumerate(cluster, Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if combinations.Partial || combinations.Count != test.count || len(combinations.Combinations) != test.count {
+				t.Errorf("expected all %d combinations, got %d of %d (partial: %v)", test.count, len(combinations.Combinations), combinations.Count, combinations.Partial)
+			}
+		})
+	}
+}
+
+func TestEnumerateSamplesLargeClusters(t *testing.T) {
+	cluster := largeCluster(32, func(i int) string {
+		switch i {
+		case 0:
+			return "M"
+		case 1:
+			return "X"
+		case 2, 3:
+			return "O.b"
+		default:
+			return "O"
+		}
+	})
+	combinations, err := Enumerate(cluster, Options{Limit: 16, Seed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !combinations.Partial || !combinations.Sampled || len(combinations.Combinations) != 16 || combinations.Count < 16 {
+		t.Fatalf("expected a sample of 16 from a partial count, got %d of %d (partial: %v, sampled: %v)", len(combinations.Combinations), combinations.Count, combinations.Partial, combinations.Sampled)
+	}
+	for i, c := range combinations.Combinations {
+		if i > 0 && c.FeatureMap == combinations.Combinations[i-1].FeatureMap {
+			t.Errorf("expected distinct combinations, got 0x%X twice", c.FeatureMap)
+		}
+		if c.FeatureMap&0x1 == 0 || c.FeatureMap&0x2 != 0 || (c.FeatureMap>>2)&0x3 == 0 || (c.FeatureMap>>2)&0x3 == 0x3 {
+			t.Errorf("invalid combination 0x%X", c.FeatureMap)
+		}
+	}
+	again, err := Enumerate(cluster, Options{Limit: 16, Seed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range again.Combinations {
+		if again.Combinations[i].FeatureMap != combinations.Combinations[i].FeatureMap {
+			t.Errorf("expected the same seed to give the same sample")
+		}
+	}
+
+	_, err = Enumerate(cluster, Options{})
+	if err == nil {
+		t.Errorf("expected an error listing every combination of a cluster with too many to count")
+	}
+}
+
+func largeCluster(count int, featureConformance func(i int) string) *matter.Cluster {
+	cluster := matter.NewCluster(nil)
+	cluster.ID = matter.NewNumber(0xFFF1)
+	cluster.Name = "Large"
+	cluster.Features = matter.NewFeatures(nil, cluster)
+	for i := range count {
+		code := "F" + strconv.Itoa(i)
+		cluster.Features.AddFeatureBit(matter.NewFeature(nil, strconv.Itoa(i), code, code, "", conformance.ParseConformance(featureConformance(i))))
+	}
+	return cluster
+}
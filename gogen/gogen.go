@@ -0,0 +1,472 @@
+package gogen
+
+import (
+	"fmt"
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dave/dst"
+	"github.com/project-chip/alchemy/internal/generate"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// RenderCluster generates the source of a Go package declaring the IDs and data types of a cluster; global data
+// types which the cluster refers to are declared in the package as well, so that each package stands alone
+func RenderCluster(cluster *matter.Cluster) (string, error) {
+	g := &generator{cluster: cluster, seen: make(map[types.Entity]struct{})}
+	g.collect()
+
+	file := &dst.File{Name: dst.NewIdent(PackageName(cluster))}
+	file.Decs.Start.Append("// Code generated by Alchemy from the Matter specification. DO NOT EDIT.", "\n")
+	file.Decs.Start.Append(fmt.Sprintf("// Package %s declares the IDs and data types of the %s cluster.", PackageName(cluster), cluster.Name))
+
+	if len(g.enums) > 0 {
+		file.Decls = append(file.Decls, &dst.GenDecl{
+			Tok:   token.IMPORT,
+			Specs: []dst.Spec{&dst.ImportSpec{Path: stringLit("fmt")}},
+		})
+	}
+	file.Decls = append(file.Decls, g.clusterDecl())
+	file.Decls = append(file.Decls, g.idDecls()...)
+	if cluster.Features != nil {
+		if bits := matter.IncludedBits(cluster.Features.Bits); len(bits) > 0 {
+			file.Decls = append(file.Decls, bitmapDecls("Feature", "Feature is the feature map of the cluster.", "uint32", bits)...)
+		}
+	}
+	for _, e := range g.enums {
+		file.Decls = append(file.Decls, enumDecls(e)...)
+	}
+	for _, bm := range g.bitmaps {
+		file.Decls = append(file.Decls, bitmapDecls(matter.Case(bm.Name), bm.Description, scalarType(bm.Type), matter.IncludedBits(bm.Bits))...)
+	}
+	for _, td := range g.typeDefs {
+		file.Decls = append(file.Decls, typeDecl(matter.Case(td.Name), td.Description, goType(td.Type)))
+	}
+	for _, s := range g.structs {
+		file.Decls = append(file.Decls, structDecl(matter.Case(s.Name), s.Description, s.Fields, s.FabricScoping == matter.FabricScopingScoped))
+	}
+	for _, cmd := range g.commands {
+		name := matter.WithSuffix(matter.Case(cmd.Name), "Request")
+		if cmd.Direction == matter.InterfaceClient {
+			name = commandName(cmd)
+		}
+		file.Decls = append(file.Decls, structDecl(name, cmd.Description, cmd.Fields, false))
+	}
+	for _, e := range g.events {
+		file.Decls = append(file.Decls, structDecl(matter.WithSuffix(matter.Case(e.Name), "Event"), e.Description, e.Fields, false))
+	}
+
+	source, err := generate.Source(file)
+	if err != nil {
+		return "", fmt.Errorf("error printing Go package for %s: %w", cluster.Name, err)
+	}
+	return source, nil
+}
+
+// PackageName returns the name of the Go package for a cluster; package names are lower case, without underscores
+func PackageName(cluster *matter.Cluster) string {
+	var name strings.Builder
+	for _, r := range matter.Case(cluster.Name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			name.WriteRune(unicode.ToLower(r))
+		}
+	}
+	if name.Len() == 0 || !unicode.IsLetter([]rune(name.String())[0]) {
+		return "cluster" + name.String()
+	}
+	return name.String()
+}
+
+type generator struct {
+	cluster  *matter.Cluster
+	seen     map[types.Entity]struct{}
+	enums    []*matter.Enum
+	bitmaps  []*matter.Bitmap
+	structs  []*matter.Struct
+	typeDefs []*matter.TypeDef
+
+	attributes matter.FieldSet
+	commands   []*matter.Command
+	events     []*matter.Event
+}
+
+func (g *generator) collect() {
+	for _, en := range g.cluster.Enums {
+		g.add(en)
+	}
+	for _, bm := range g.cluster.Bitmaps {
+		g.add(bm)
+	}
+	for _, s := range g.cluster.Structs {
+		g.add(s)
+	}
+	for _, td := range g.cluster.TypeDefs {
+		g.add(td)
+	}
+	for _, a := range g.cluster.Attributes {
+		if matter.IsIncluded(a.Conformance) {
+			g.attributes = append(g.attributes, a)
+			g.addType(a.Type)
+		}
+	}
+	for _, cmd := range g.cluster.Commands {
+		if matter.IsIncluded(cmd.Conformance) {
+			g.commands = append(g.commands, cmd)
+			g.addFields(cmd.Fields)
+		}
+	}
+	for _, e := range g.cluster.Events {
+		if matter.IsIncluded(e.Conformance) {
+			g.events = append(g.events, e)
+			g.addFields(e.Fields)
+		}
+	}
+}
+
+func (g *generator) add(entity types.Entity) {
+	if _, ok := g.seen[entity]; ok {
+		return
+	}
+	g.seen[entity] = struct{}{}
+	switch entity := entity.(type) {
+	case *matter.Enum:
+		g.enums = append(g.enums, entity)
+	case *matter.Bitmap:
+		g.bitmaps = append(g.bitmaps, entity)
+	case *matter.Struct:
+		g.structs = append(g.structs, entity)
+		g.addFields(entity.Fields)
+	case *matter.TypeDef:
+		g.typeDefs = append(g.typeDefs, entity)
+		g.addType(entity.Type)
+	}
+}
+
+func (g *generator) addFields(fields matter.FieldSet) {
+	for _, f := range fields {
+		if matter.IsIncluded(f.Conformance) {
+			g.addType(f.Type)
+		}
+	}
+}
+
+func (g *generator) addType(dataType *types.DataType) {
+	if dataType == nil {
+		return
+	}
+	if dataType.IsArray() {
+		g.addType(dataType.EntryType)
+		return
+	}
+	switch dataType.Entity.(type) {
+	case *matter.Enum, *matter.Bitmap, *matter.Struct, *matter.TypeDef:
+		g.add(dataType.Entity)
+	}
+}
+
+func (g *generator) clusterDecl() dst.Decl {
+	specs := []dst.Spec{constSpec("ClusterID", "uint32", idLit(g.cluster.ID))}
+	if r := g.cluster.Revisions.MostRecent(); r != nil && r.Number.Valid() {
+		specs = append(specs, constSpec("ClusterRevision", "uint16", &dst.BasicLit{Kind: token.INT, Value: r.Number.IntString()}))
+	}
+	return &dst.GenDecl{Tok: token.CONST, Lparen: true, Rparen: true, Specs: specs}
+}
+
+// idDecls declares a constant for the ID of each attribute, command and event of the cluster
+func (g *generator) idDecls() (decls []dst.Decl) {
+	var attributes, commands, events []dst.Spec
+	for _, a := range g.attributes {
+		if a.ID.Valid() {
+			attributes = append(attributes, constSpec("Attribute"+suffixIdentifier(matter.Case(a.Name)), "uint32", idLit(a.ID)))
+		}
+	}
+	for _, cmd := range g.commands {
+		if cmd.ID.Valid() {
+			commands = append(commands, constSpec("Command"+suffixIdentifier(commandName(cmd)), "uint32", idLit(cmd.ID)))
+		}
+	}
+	for _, e := range g.events {
+		if e.ID.Valid() {
+			events = append(events, constSpec("Event"+suffixIdentifier(matter.Case(e.Name)), "uint32", idLit(e.ID)))
+		}
+	}
+	for _, group := range []struct {
+		comment string
+		specs   []dst.Spec
+	}{
+		{"// Attribute IDs", attributes},
+		{"// Command IDs", commands},
+		{"// Event IDs", events},
+	} {
+		if len(group.specs) == 0 {
+			continue
+		}
+		decl := &dst.GenDecl{Tok: token.CONST, Lparen: true, Rparen: true, Specs: group.specs}
+		decl.Decs.Before = dst.EmptyLine
+		decl.Decs.Start.Append(group.comment)
+		decls = append(decls, decl)
+	}
+	return
+}
+
+// commandName names a command; responses are suffixed with Response, as a response may share the name of its request
+func commandName(cmd *matter.Command) string {
+	if cmd.Direction == matter.InterfaceClient {
+		return matter.WithSuffix(matter.Case(cmd.Name), "Response")
+	}
+	return matter.Case(cmd.Name)
+}
+
+// enumDecls declares an enum type, a constant for each of its values, and a String method naming them
+func enumDecls(e *matter.Enum) []dst.Decl {
+	name := matter.Case(e.Name)
+	underlying := "uint8"
+	if e.Type != nil {
+		underlying = scalarType(e.Type)
+	}
+	var specs []dst.Spec
+	var cases []dst.Stmt
+	for _, ev := range e.Values {
+		if !ev.Value.Valid() || !matter.IsIncluded(ev.Conformance) {
+			continue
+		}
+		constName := name + suffixIdentifier(matter.Case(ev.Name))
+		spec := constSpec(constName, name, idLit(ev.Value))
+		if ev.Summary != "" {
+			spec.Decs.End.Append("// " + oneLine(ev.Summary))
+		}
+		specs = append(specs, spec)
+		cases = append(cases, &dst.CaseClause{
+			List: []dst.Expr{dst.NewIdent(constName)},
+			Body: []dst.Stmt{&dst.ReturnStmt{Results: []dst.Expr{stringLit(ev.Name)}}},
+		})
+	}
+	decls := []dst.Decl{typeDecl(name, e.Description, dst.NewIdent(underlying))}
+	if len(specs) > 0 {
+		decls = append(decls, constDecl(specs))
+	}
+
+	var body []dst.Stmt
+	if len(cases) > 0 {
+		body = append(body, &dst.SwitchStmt{Tag: dst.NewIdent("v"), Body: &dst.BlockStmt{List: cases}})
+	}
+	body = append(body, &dst.ReturnStmt{Results: []dst.Expr{&dst.CallExpr{
+		Fun: &dst.SelectorExpr{X: dst.NewIdent("fmt"), Sel: dst.NewIdent("Sprintf")},
+		Args: []dst.Expr{
+			stringLit(name + "(%d)"),
+			&dst.CallExpr{Fun: dst.NewIdent(underlying), Args: []dst.Expr{dst.NewIdent("v")}},
+		},
+	}}})
+	method := &dst.FuncDecl{
+		Recv: &dst.FieldList{List: []*dst.Field{{Names: []*dst.Ident{dst.NewIdent("v")}, Type: dst.NewIdent(name)}}},
+		Name: dst.NewIdent("String"),
+		Type: &dst.FuncType{Params: &dst.FieldList{}, Results: &dst.FieldList{List: []*dst.Field{{Type: dst.NewIdent("string")}}}},
+		Body: &dst.BlockStmt{List: body},
+	}
+	method.Decs.Before = dst.EmptyLine
+	return append(decls, method)
+}
+
+// bitmapDecls declares a bitmap type and a constant for the mask of each of its bits
+func bitmapDecls(name string, description string, underlying string, bits matter.BitSet) []dst.Decl {
+	var specs []dst.Spec
+	for _, b := range bits {
+		mask, err := b.Mask()
+		if err != nil {
+			continue
+		}
+		spec := constSpec(name+suffixIdentifier(matter.Case(b.Name())), name, &dst.BasicLit{Kind: token.INT, Value: fmt.Sprintf("0x%X", mask)})
+		if b.Summary() != "" {
+			spec.Decs.End.Append("// " + oneLine(b.Summary()))
+		}
+		specs = append(specs, spec)
+	}
+	decls := []dst.Decl{typeDecl(name, description, dst.NewIdent(underlying))}
+	if len(specs) > 0 {
+		decls = append(decls, constDecl(specs))
+	}
+	return decls
+}
+
+// structDecl declares a struct whose fields carry their TLV tags as struct tags; fields which are optional or
+// nullable are pointers, and are marked as such in their tags
+func structDecl(name string, description string, fields matter.FieldSet, fabricScoped bool) dst.Decl {
+	st := &dst.StructType{Fields: &dst.FieldList{}}
+	var hasFabricIndex bool
+	for _, f := range fields {
+		if !matter.IsIncluded(f.Conformance) || !f.ID.Valid() {
+			continue
+		}
+		if f.ID.Value() == matter.FabricIndexFieldID {
+			hasFabricIndex = true
+		}
+		var options []string
+		if !conformance.IsMandatory(f.Conformance) {
+			options = append(options, "optional")
+		}
+		if f.Quality.Has(matter.QualityNullable) {
+			options = append(options, "nullable")
+		}
+		t := goType(f.Type)
+		if len(options) > 0 && !f.Type.IsArray() {
+			t = &dst.StarExpr{X: t}
+		}
+		st.Fields.List = append(st.Fields.List, &dst.Field{
+			Names: []*dst.Ident{dst.NewIdent(identifier(matter.Case(f.Name)))},
+			Type:  t,
+			Tag:   tlvTag(f.ID.Value(), options),
+		})
+	}
+	if fabricScoped && !hasFabricIndex {
+		st.Fields.List = append(st.Fields.List, &dst.Field{
+			Names: []*dst.Ident{dst.NewIdent("FabricIndex")},
+			Type:  dst.NewIdent("uint8"),
+			Tag:   tlvTag(matter.FabricIndexFieldID, nil),
+		})
+	}
+	return typeDecl(name, description, st)
+}
+
+func tlvTag(id uint64, options []string) *dst.BasicLit {
+	tag := strconv.FormatUint(id, 10)
+	if len(options) > 0 {
+		tag += "," + strings.Join(options, ",")
+	}
+	return &dst.BasicLit{Kind: token.STRING, Value: "`tlv:\"" + tag + "\"`"}
+}
+
+func typeDecl(name string, description string, t dst.Expr) dst.Decl {
+	decl := &dst.GenDecl{Tok: token.TYPE, Specs: []dst.Spec{&dst.TypeSpec{Name: dst.NewIdent(name), Type: t}}}
+	decl.Decs.Before = dst.EmptyLine
+	decl.Decs.Start.Append(docComment(name, description)...)
+	return decl
+}
+
+func constDecl(specs []dst.Spec) dst.Decl {
+	decl := &dst.GenDecl{Tok: token.CONST, Lparen: true, Rparen: true, Specs: specs}
+	decl.Decs.Before = dst.EmptyLine
+	return decl
+}
+
+func constSpec(name string, typeName string, value dst.Expr) *dst.ValueSpec {
+	return &dst.ValueSpec{Names: []*dst.Ident{dst.NewIdent(name)}, Type: dst.NewIdent(typeName), Values: []dst.Expr{value}}
+}
+
+// goType returns the Go type of a Matter data type; integers are given the smallest type which holds them
+func goType(dataType *types.DataType) dst.Expr {
+	if dataType == nil {
+		return dst.NewIdent("any")
+	}
+	if dataType.IsArray() {
+		return &dst.ArrayType{Elt: goType(dataType.EntryType)}
+	}
+	switch entity := dataType.Entity.(type) {
+	case *matter.Enum:
+		return dst.NewIdent(matter.Case(entity.Name))
+	case *matter.Bitmap:
+		return dst.NewIdent(matter.Case(entity.Name))
+	case *matter.Struct:
+		return dst.NewIdent(matter.Case(entity.Name))
+	case *matter.TypeDef:
+		return dst.NewIdent(matter.Case(entity.Name))
+	}
+	switch dataType.BaseType {
+	case types.BaseDataTypeCustom:
+		return dst.NewIdent("any")
+	case types.BaseDataTypeOctStr, types.BaseDataTypeIPAddress, types.BaseDataTypeIPv4Address, types.BaseDataTypeIPv6Address,
+		types.BaseDataTypeIPv6Prefix, types.BaseDataTypeHardwareAddress, types.BaseDataTypeMessageID:
+		return &dst.ArrayType{Elt: dst.NewIdent("byte")}
+	}
+	return dst.NewIdent(scalarType(dataType))
+}
+
+func scalarType(dataType *types.DataType) string {
+	switch dataType.BaseType {
+	case types.BaseDataTypeBoolean:
+		return "bool"
+	case types.BaseDataTypeSingle:
+		return "float32"
+	case types.BaseDataTypeDouble:
+		return "float64"
+	case types.BaseDataTypeString:
+		return "string"
+	}
+	min := types.Min(dataType.BaseType, types.NullabilityNonNull)
+	prefix := "uint"
+	if min.IsNegative() {
+		prefix = "int"
+	}
+	switch size := dataType.Size(); {
+	case size == 1:
+		return prefix + "8"
+	case size == 2:
+		return prefix + "16"
+	case size == 3 || size == 4:
+		return prefix + "32"
+	default:
+		return prefix + "64"
+	}
+}
+
+func idLit(id *matter.Number) dst.Expr {
+	if !id.Valid() {
+		return &dst.BasicLit{Kind: token.INT, Value: "0"}
+	}
+	return &dst.BasicLit{Kind: token.INT, Value: id.HexString()}
+}
+
+func stringLit(s string) *dst.BasicLit {
+	return &dst.BasicLit{Kind: token.STRING, Value: strconv.Quote(s)}
+}
+
+// docComment starts a doc comment with the name it documents, as Go convention expects, and wraps it
+func docComment(name string, description string) []string {
+	words := strings.Fields(description)
+	if len(words) == 0 {
+		return nil
+	}
+	if words[0] != name {
+		words = append([]string{name + ":"}, words...)
+	}
+	var lines []string
+	var line strings.Builder
+	for _, word := range words {
+		if line.Len() > 0 && line.Len()+len(word) > 100 {
+			lines = append(lines, "// "+line.String())
+			line.Reset()
+		}
+		if line.Len() > 0 {
+			line.WriteRune(' ')
+		}
+		line.WriteString(word)
+	}
+	return append(lines, "// "+line.String())
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// identifier makes a name usable as an exported Go identifier
+func identifier(name string) string {
+	s := suffixIdentifier(name)
+	if s == "" || !unicode.IsUpper([]rune(s)[0]) {
+		return "X" + s
+	}
+	return s
+}
+
+// suffixIdentifier strips a name of characters which can not appear in a Go identifier, for use after a prefix
+func suffixIdentifier(name string) string {
+	var id strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			id.WriteRune(r)
+		}
+	}
+	return id.String()
+}
@@ -0,0 +1,195 @@
+package gogen
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	gotypes "go/types"
+	"strings"
+	"testing"
+
+	"github.com/dave/dst"
+	"github.com/project-chip/alchemy/internal/generate"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestPackageName(t *testing.T) {
+	tests := map[string]string{
+		"On/Off":                         "onoff",
+		"Wi-Fi Network Diagnostics":      "wifinetworkdiagnostics",
+		"Pump Configuration and Control": "pumpconfigurationandcontrol",
+		// Package names can't start with a digit
+		"2D Cartesian Zone": "cluster2dcartesianzone",
+	}
+	for name, expected := range tests {
+		cluster := matter.NewCluster(nil)
+		cluster.Name = name
+		if pkg := PackageName(cluster); pkg != expected {
+			t.Errorf("expected package name of %s to be %s, got %s", name, expected, pkg)
+		}
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"OnOff":           "OnOff",
+		"Wood-Fill":       "WoodFill",
+		"ABS (High-Temp)": "ABSHighTemp",
+		"2.4GHz":          "X24GHz",
+		"lower":           "Xlower",
+		"":                "X",
+	}
+	for name, expected := range tests {
+		if id := identifier(name); id != expected {
+			t.Errorf("expected %q to be written as %s, got %s", name, expected, id)
+		}
+	}
+}
+
+func TestDocComment(t *testing.T) {
+	if comment := docComment("OnTime", ""); comment != nil {
+		t.Errorf("expected no comment without a description, got %v", comment)
+	}
+	if comment := docComment("OnTime", "OnTime is the time the light stays on"); len(comment) != 1 || comment[0] != "// OnTime is the time the light stays on" {
+		t.Errorf("expected a description starting with the name to be left as it is, got %v", comment)
+	}
+	comment := docComment("OnTime", "The time the light stays on, in tenths of a second, before it turns off again; this is long enough to wrap")
+	if len(comment) != 2 || comment[0] != "// OnTime: The time the light stays on, in tenths of a second, before it turns off again; this is long" || comment[1] != "// enough to wrap" {
+		t.Errorf("expected the comment to start with the name and wrap, got %q", comment)
+	}
+}
+
+// TestStructDecl checks the Go type and TLV tag of struct fields; optional and nullable fields are pointers, unless
+// they're lists, which can already be nil
+func TestStructDecl(t *testing.T) {
+	effect := matter.NewEnum(nil, nil)
+	effect.Name = "EffectIdentifierEnum"
+	tests := []struct {
+		field    *matter.Field
+		expected string
+	}{
+		{&matter.Field{ID: matter.NewNumber(0), Name: "OnTime", Type: types.NewDataType(types.BaseDataTypeUInt16, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")}, "\tOnTime uint16 `tlv:\"0\"`\n"},
+		{&matter.Field{ID: matter.NewNumber(1), Name: "Level", Type: types.NewDataType(types.BaseDataTypeUInt24, types.DataTypeRankScalar), Quality: matter.QualityNullable, Conformance: conformance.ParseConformance("M")}, "\tLevel *uint32 `tlv:\"1,nullable\"`\n"},
+		{&matter.Field{ID: matter.NewNumber(2), Name: "Offset", Type: types.NewDataType(types.BaseDataTypeInt40, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("O")}, "\tOffset *int64 `tlv:\"2,optional\"`\n"},
+		{&matter.Field{ID: matter.NewNumber(3), Name: "Effect", Type: &types.DataType{BaseType: types.BaseDataTypeCustom, Name: effect.Name, Entity: effect}, Quality: matter.QualityNullable, Conformance: conformance.ParseConformance("LT")}, "\tEffect *EffectIdentifierEnum `tlv:\"3,optional,nullable\"`\n"},
+		{&matter.Field{ID: matter.NewNumber(4), Name: "Temperatures", Type: types.NewDataType(types.BaseDataTypeTemperature, types.DataTypeRankList), Conformance: conformance.ParseConformance("O")}, "\tTemperatures []int16 `tlv:\"4,optional\"`\n"},
+		{&matter.Field{ID: matter.NewNumber(5), Name: "HardwareAddress", Type: types.NewDataType(types.BaseDataTypeHardwareAddress, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")}, "\tHardwareAddress []byte `tlv:\"5\"`\n"},
+		{&matter.Field{ID: matter.NewNumber(6), Name: "Mystery", Type: types.NewCustomDataType("MysteryType", types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")}, "\tMystery any `tlv:\"6\"`\n"},
+		{&matter.Field{ID: matter.NewNumber(7), Name: "Legacy", Type: types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("X")}, "struct {\n}\n"},
+		{&matter.Field{ID: matter.InvalidID, Name: "NoID", Type: types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")}, "struct {\n}\n"},
+	}
+	for _, test := range tests {
+		source := renderDecl(t, structDecl("TestStruct", "", matter.FieldSet{test.field}, false))
+		if !strings.Contains(source, test.expected) {
+			t.Errorf("%s: expected %q in:\n%s", test.field.Name, test.expected, source)
+		}
+	}
+
+	// Fabric-scoped structs get the FabricIndex they don't declare
+	fields := matter.FieldSet{{ID: matter.NewNumber(1), Name: "Node", Type: types.NewDataType(types.BaseDataTypeNodeID, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")}}
+	if source := renderDecl(t, structDecl("TargetStruct", "", fields, true)); !strings.Contains(source, "\tNode        uint64 `tlv:\"1\"`\n\tFabricIndex uint8  `tlv:\"254\"`\n") {
+		t.Errorf("expected an implicit FabricIndex in:\n%s", source)
+	}
+}
+
+func renderDecl(t *testing.T, decl dst.Decl) string {
+	t.Helper()
+	source, err := generate.Source(&dst.File{Name: dst.NewIdent("test"), Decls: []dst.Decl{decl}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return source
+}
+
+// TestRenderCluster checks that the package generated for a cluster type-checks, as well as what it declares
+func TestRenderCluster(t *testing.T) {
+	cluster := matter.NewCluster(nil)
+	cluster.Name = "On/Off"
+	cluster.ID = matter.NewNumber(0x0006)
+	revision := matter.NewRevision(cluster, nil)
+	revision.Number = matter.NewNumber(6)
+	cluster.Revisions = matter.Revisions{revision}
+
+	cluster.Features = matter.NewFeatures(nil, cluster)
+	cluster.Features.Bits = matter.BitSet{
+		matter.NewFeature(nil, "0", "Lighting", "LT", "Behavior that supports lighting applications", conformance.ParseConformance("O.a")),
+		matter.NewFeature(nil, "1", "DeadFrontBehavior", "DF", "", conformance.ParseConformance("O.a")),
+		matter.NewFeature(nil, "3", "Legacy", "LG", "", conformance.ParseConformance("X")),
+	}
+
+	startUp := matter.NewEnum(nil, cluster)
+	startUp.Name = "StartUpOnOffEnum"
+	startUp.Type = types.NewDataType(types.BaseDataTypeEnum8, types.DataTypeRankScalar)
+	startUp.Values = matter.EnumValueSet{
+		{Name: "Off", Summary: "Set the OnOff attribute to FALSE", Value: matter.NewNumber(0), Conformance: conformance.ParseConformance("M")},
+		{Name: "On", Value: matter.NewNumber(1), Conformance: conformance.ParseConformance("M")},
+		{Name: "Toggle", Value: matter.NewNumber(2), Conformance: conformance.ParseConformance("M")},
+	}
+	// Enums whose values are all left out still get a String method
+	reserved := matter.NewEnum(nil, cluster)
+	reserved.Name = "ReservedEnum"
+	cluster.Enums = append(cluster.Enums, startUp, reserved)
+
+	control := matter.NewBitmap(nil, cluster)
+	control.Name = "OnOffControlBitmap"
+	control.Type = types.NewDataType(types.BaseDataTypeMap8, types.DataTypeRankScalar)
+	control.Bits = matter.BitSet{matter.NewBitmapBit(nil, control, "0", "AcceptOnlyWhenOn", "", conformance.ParseConformance("M"))}
+	cluster.Bitmaps = append(cluster.Bitmaps, control)
+
+	cluster.Attributes = matter.FieldSet{
+		{ID: matter.NewNumber(0x0000), Name: "OnOff", Type: types.NewDataType(types.BaseDataTypeBoolean, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")},
+		{ID: matter.NewNumber(0x4003), Name: "StartUpOnOff", Type: &types.DataType{BaseType: types.BaseDataTypeCustom, Name: startUp.Name, Entity: startUp}, Quality: matter.QualityNullable, Conformance: conformance.ParseConformance("LT")},
+	}
+
+	onWithTimedOff := matter.NewCommand(nil, cluster)
+	onWithTimedOff.Name = "OnWithTimedOff"
+	onWithTimedOff.ID = matter.NewNumber(0x42)
+	onWithTimedOff.Direction = matter.InterfaceServer
+	onWithTimedOff.Fields = matter.FieldSet{
+		{ID: matter.NewNumber(0), Name: "OnOffControl", Type: &types.DataType{BaseType: types.BaseDataTypeCustom, Name: control.Name, Entity: control}, Conformance: conformance.ParseConformance("M")},
+		{ID: matter.NewNumber(1), Name: "OnTime", Type: types.NewDataType(types.BaseDataTypeUInt16, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")},
+	}
+	toggle := matter.NewCommand(nil, cluster)
+	toggle.Name = "Toggle"
+	toggle.ID = matter.NewNumber(0x02)
+	toggle.Direction = matter.InterfaceServer
+	cluster.Commands = append(cluster.Commands, toggle, onWithTimedOff)
+
+	out, err := RenderCluster(cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "onoff.go", out, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, out)
+	}
+	config := gotypes.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err = config.Check("onoff", fset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("generated source does not type-check: %v\n%s", err, out)
+	}
+
+	for _, expected := range []string{
+		"package onoff\n",
+		"ClusterRevision uint16 = 6\n",
+		"AttributeStartUpOnOff uint32 = 0x4003\n",
+		"CommandOnWithTimedOff uint32 = 0x0042\n",
+		"FeatureLighting          Feature = 0x1 // Behavior that supports lighting applications\n",
+		"StartUpOnOffEnumOff    StartUpOnOffEnum = 0x0000 // Set the OnOff attribute to FALSE\n",
+		"type OnOffControlBitmap uint8\n",
+		"OnOffControlBitmapAcceptOnlyWhenOn OnOffControlBitmap = 0x1\n",
+		"return fmt.Sprintf(\"ReservedEnum(%d)\", uint8(v))",
+		"type ToggleRequest struct {\n}\n",
+		"type OnWithTimedOffRequest struct {\n\tOnOffControl OnOffControlBitmap `tlv:\"0\"`\n\tOnTime       uint16             `tlv:\"1\"`\n}\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected output to contain %q; got:\n%s", expected, out)
+		}
+	}
+	if strings.Contains(out, "FeatureLegacy") {
+		t.Errorf("expected disallowed feature to be left out; got:\n%s", out)
+	}
+}
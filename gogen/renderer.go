@@ -0,0 +1,56 @@
+package gogen
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+type Options struct {
+	GoRoot string `default:"go" help:"where to place the Go packages; each cluster is written to its own directory" group:"Go:"`
+}
+
+type Renderer struct {
+	spec    *spec.Specification
+	options Options
+}
+
+func NewRenderer(spec *spec.Specification, options Options) *Renderer {
+	return &Renderer{spec: spec, options: options}
+}
+
+func (r *Renderer) Name() string {
+	return "Rendering Go packages"
+}
+
+func (r *Renderer) Process(cxt context.Context, input *pipeline.Data[*asciidoc.Document], index int32, total int32) (outputs []*pipeline.Data[string], extra []*pipeline.Data[*asciidoc.Document], err error) {
+	for _, e := range r.spec.EntitiesForDocument(input.Content) {
+		var clusters []*matter.Cluster
+		switch e := e.(type) {
+		case *matter.Cluster:
+			clusters = append(clusters, e)
+		case *matter.ClusterGroup:
+			clusters = append(clusters, e.Clusters...)
+		}
+		for _, c := range clusters {
+			var s string
+			s, err = RenderCluster(c)
+			if err != nil {
+				err = fmt.Errorf("failed rendering Go package for %s: %w", input.Path, err)
+				return
+			}
+			outputs = append(outputs, pipeline.NewData(r.packagePath(c), s))
+		}
+	}
+	return
+}
+
+func (r *Renderer) packagePath(cluster *matter.Cluster) string {
+	name := PackageName(cluster)
+	return filepath.Join(r.options.GoRoot, name, name+".go")
+}
@@ -0,0 +1,154 @@
+package parse
+
+import (
+	"slices"
+	"strings"
+)
+
+// The grammar produces a syntax tree which mirrors the IDL closely; the tree is then built into entities once a
+// whole cluster has been read, since commands refer to request and response structs by name
+
+type qualities []string
+
+func (q qualities) has(quality string) bool {
+	return slices.Contains(q, quality)
+}
+
+type dataTypeDecl struct {
+	name      string
+	maxLength *uint64
+}
+
+type fieldDecl struct {
+	description string
+	qualities   qualities
+	dataType    *dataTypeDecl
+	name        string
+	list        bool
+	id          uint64
+}
+
+type constantDecl struct {
+	description string
+	qualities   qualities
+	name        string
+	value       uint64
+	specName    string
+}
+
+type enumDecl struct {
+	description string
+	qualities   qualities
+	name        string
+	base        string
+	values      []*constantDecl
+}
+
+type bitmapDecl struct {
+	description string
+	qualities   qualities
+	name        string
+	base        string
+	bits        []*constantDecl
+}
+
+type structKind uint8
+
+const (
+	structKindPlain structKind = iota
+	structKindRequest
+	structKindResponse
+)
+
+type structDecl struct {
+	description string
+	qualities   qualities
+	kind        structKind
+	name        string
+	id          *uint64
+	fields      []*fieldDecl
+}
+
+type accessDecl struct {
+	operation string
+	privilege string
+}
+
+type attributeDecl struct {
+	description string
+	qualities   qualities
+	access      []*accessDecl
+	field       *fieldDecl
+}
+
+type commandDecl struct {
+	description string
+	qualities   qualities
+	access      []*accessDecl
+	name        string
+	request     string
+	response    string
+	id          uint64
+}
+
+type eventDecl struct {
+	description string
+	qualities   qualities
+	priority    string
+	access      []*accessDecl
+	name        string
+	id          uint64
+	fields      []*fieldDecl
+}
+
+type clusterDecl struct {
+	description string
+	qualities   qualities
+	name        string
+	id          uint64
+	revision    *uint64
+	members     []any
+}
+
+type fileDecl struct {
+	definitions []any
+}
+
+func toQualities(q any) (qs qualities) {
+	for _, i := range q.([]any) {
+		qs = append(qs, i.(string))
+	}
+	return
+}
+
+func toFields(f any) (fields []*fieldDecl) {
+	for _, i := range f.([]any) {
+		fields = append(fields, i.([]any)[0].(*fieldDecl))
+	}
+	return
+}
+
+func toAccess(a any) []*accessDecl {
+	if a == nil {
+		return nil
+	}
+	return a.([]*accessDecl)
+}
+
+func toDescription(d any) string {
+	var description string
+	for _, i := range d.([]any) {
+		// Only the comment immediately preceding a declaration describes it
+		description = i.([]any)[0].(string)
+	}
+	return description
+}
+
+func docComment(text []byte) string {
+	comment := strings.TrimSuffix(strings.TrimPrefix(string(text), "/**"), "*/")
+	lines := strings.Split(comment, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(strings.TrimSpace(line), "*")
+	}
+	return strings.Join(strings.Fields(strings.Join(lines, " ")), " ")
+}
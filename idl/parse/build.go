@@ -0,0 +1,420 @@
+package parse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/zap"
+)
+
+func build(f *fileDecl) (idl *IDL, err error) {
+	idl = &IDL{}
+	var clusters []*clusterDecl
+	for _, d := range f.definitions {
+		switch d := d.(type) {
+		case *clusterDecl:
+			clusters = append(clusters, d)
+		case *Endpoint:
+			idl.Endpoints = append(idl.Endpoints, d)
+		default:
+			var entity types.Entity
+			entity, err = buildDataType(d, nil)
+			if err != nil {
+				return
+			}
+			idl.AddDataTypes(entity)
+		}
+	}
+	for _, s := range idl.Structs {
+		resolveFields(s.Fields, &idl.AssociatedDataTypes)
+	}
+	for _, cd := range clusters {
+		var cluster *matter.Cluster
+		cluster, err = buildCluster(cd, idl)
+		if err != nil {
+			return
+		}
+		idl.Clusters = append(idl.Clusters, cluster)
+	}
+	return
+}
+
+func buildCluster(cd *clusterDecl, idl *IDL) (cluster *matter.Cluster, err error) {
+	cluster = matter.NewCluster(nil)
+	cluster.Name = cd.name
+	cluster.ID = matter.NewNumber(cd.id)
+	cluster.Description = cd.description
+	if cd.qualities.has("provisional") {
+		cluster.Conformance = conformance.Set{&conformance.Provisional{}}
+	}
+	if cd.revision != nil {
+		revision := matter.NewRevision(cluster, nil)
+		revision.Number = matter.NewNumber(*cd.revision)
+		cluster.Revisions = append(cluster.Revisions, revision)
+	}
+
+	requests := make(map[string]*structDecl)
+	for _, m := range cd.members {
+		switch m := m.(type) {
+		case *bitmapDecl:
+			if m.name == "Feature" {
+				cluster.Features, err = buildFeatures(m, cluster)
+				if err != nil {
+					return
+				}
+				continue
+			}
+			var entity types.Entity
+			entity, err = buildDataType(m, cluster)
+			if err != nil {
+				return
+			}
+			cluster.AddDataTypes(entity)
+		case *enumDecl:
+			var entity types.Entity
+			entity, err = buildDataType(m, cluster)
+			if err != nil {
+				return
+			}
+			cluster.AddDataTypes(entity)
+		case *structDecl:
+			switch m.kind {
+			case structKindRequest:
+				requests[m.name] = m
+			case structKindResponse:
+				if m.id == nil {
+					err = fmt.Errorf("response struct %s in cluster %s has no ID", m.name, cd.name)
+					return
+				}
+				command := matter.NewCommand(nil, cluster)
+				command.ID = matter.NewNumber(*m.id)
+				command.Name = m.name
+				command.Description = m.description
+				command.Direction = matter.InterfaceClient
+				command.Response = types.NewCustomDataType("N", types.DataTypeRankScalar)
+				command.Access = matter.DefaultAccess(types.EntityTypeCommand)
+				command.Conformance = buildConformance(m.qualities, false)
+				command.Fields = buildFields(m.fields, command, types.EntityTypeCommandField)
+				cluster.Commands = append(cluster.Commands, command)
+			default:
+				var entity types.Entity
+				entity, err = buildDataType(m, cluster)
+				if err != nil {
+					return
+				}
+				cluster.AddDataTypes(entity)
+			}
+		}
+	}
+
+	for _, m := range cd.members {
+		switch m := m.(type) {
+		case *attributeDecl:
+			attribute := buildField(m.field, cluster, types.EntityTypeAttribute)
+			if !m.qualities.has("readonly") {
+				attribute.Access.Write = matter.PrivilegeOperate
+			}
+			if m.qualities.has("timedwrite") {
+				attribute.Access.Timing = matter.TimingTimed
+			}
+			err = applyAccess(&attribute.Access, m.access)
+			if err != nil {
+				return
+			}
+			if m.qualities.has("provisional") && !m.field.qualities.has("provisional") {
+				attribute.Conformance = append(conformance.Set{&conformance.Provisional{}}, attribute.Conformance...)
+			}
+			cluster.Attributes = append(cluster.Attributes, attribute)
+		case *commandDecl:
+			command := matter.NewCommand(nil, cluster)
+			command.ID = matter.NewNumber(m.id)
+			command.Name = m.name
+			command.Description = m.description
+			command.Direction = matter.InterfaceServer
+			command.Conformance = buildConformance(m.qualities, m.qualities.has("optional"))
+			command.Access = matter.DefaultAccess(types.EntityTypeCommand)
+			if m.qualities.has("timed") {
+				command.Access.Timing = matter.TimingTimed
+			}
+			if m.qualities.has("fabric") {
+				command.Access.FabricScoping = matter.FabricScopingScoped
+			}
+			err = applyAccess(&command.Access, m.access)
+			if err != nil {
+				return
+			}
+			if m.response == "DefaultSuccess" {
+				command.Response = types.NewCustomDataType("Y", types.DataTypeRankScalar)
+			} else {
+				command.Response = types.NewCustomDataType(m.response, types.DataTypeRankScalar)
+			}
+			if m.request != "" {
+				request, ok := requests[m.request]
+				if !ok {
+					err = fmt.Errorf("command %s in cluster %s refers to unknown request struct %s", m.name, cd.name, m.request)
+					return
+				}
+				command.Fields = buildFields(request.fields, command, types.EntityTypeCommandField)
+			}
+			cluster.Commands = append(cluster.Commands, command)
+		case *eventDecl:
+			event := matter.NewEvent(nil, cluster)
+			event.ID = matter.NewNumber(m.id)
+			event.Name = m.name
+			event.Description = m.description
+			event.Priority = upperFirst(m.priority)
+			event.Conformance = buildConformance(m.qualities, m.qualities.has("optional"))
+			event.Access = matter.DefaultAccess(types.EntityTypeEvent)
+			if m.qualities.has("fabric_sensitive") {
+				event.Access.FabricSensitivity = matter.FabricSensitivitySensitive
+			}
+			err = applyAccess(&event.Access, m.access)
+			if err != nil {
+				return
+			}
+			event.Fields = buildFields(m.fields, event, types.EntityTypeEventField)
+			cluster.Events = append(cluster.Events, event)
+		}
+	}
+
+	for _, s := range cluster.Structs {
+		resolveFields(s.Fields, &cluster.AssociatedDataTypes, &idl.AssociatedDataTypes)
+	}
+	resolveFields(cluster.Attributes, &cluster.AssociatedDataTypes, &idl.AssociatedDataTypes)
+	for _, command := range cluster.Commands {
+		resolveFields(command.Fields, &cluster.AssociatedDataTypes, &idl.AssociatedDataTypes)
+	}
+	for _, event := range cluster.Events {
+		resolveFields(event.Fields, &cluster.AssociatedDataTypes, &idl.AssociatedDataTypes)
+	}
+	return
+}
+
+func buildDataType(d any, parent types.Entity) (types.Entity, error) {
+	switch d := d.(type) {
+	case *enumDecl:
+		e := matter.NewEnum(nil, parent)
+		e.Name = d.name
+		e.Description = d.description
+		e.Type = buildType(d.base, false)
+		for _, v := range d.values {
+			ev := matter.NewEnumValue(nil, e)
+			ev.Name = constantName(v)
+			ev.Value = matter.NewNumber(v.value)
+			ev.Summary = v.description
+			ev.Conformance = buildConformance(v.qualities, false)
+			e.Values = append(e.Values, ev)
+		}
+		return e, nil
+	case *bitmapDecl:
+		bm := matter.NewBitmap(nil, parent)
+		bm.Name = d.name
+		bm.Description = d.description
+		bm.Type = buildType(d.base, false)
+		for _, b := range d.bits {
+			bit, err := maskBits(b.value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid bit %s in bitmap %s: %w", b.name, d.name, err)
+			}
+			bm.AddBit(matter.NewBitmapBit(nil, bm, bit, constantName(b), b.description, buildConformance(b.qualities, false)))
+		}
+		return bm, nil
+	case *structDecl:
+		s := matter.NewStruct(nil, parent)
+		s.Name = d.name
+		s.Description = d.description
+		if d.qualities.has("fabric_scoped") {
+			s.FabricScoping = matter.FabricScopingScoped
+		} else {
+			s.FabricScoping = matter.FabricScopingUnscoped
+		}
+		s.Fields = buildFields(d.fields, s, types.EntityTypeStructField)
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unexpected definition: %T", d)
+	}
+}
+
+func buildFeatures(bd *bitmapDecl, cluster *matter.Cluster) (*matter.Features, error) {
+	features := matter.NewFeatures(nil, cluster)
+	for _, b := range bd.bits {
+		bit, err := maskBits(b.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid feature %s: %w", b.name, err)
+		}
+		// IDL does not carry feature codes, only names
+		features.AddFeatureBit(matter.NewFeature(nil, bit, constantName(b), "", b.description, buildConformance(b.qualities, false)))
+	}
+	return features, nil
+}
+
+func buildFields(fds []*fieldDecl, parent types.Entity, entityType types.EntityType) (fields matter.FieldSet) {
+	for _, fd := range fds {
+		fields = append(fields, buildField(fd, parent, entityType))
+	}
+	return
+}
+
+func buildField(fd *fieldDecl, parent types.Entity, entityType types.EntityType) *matter.Field {
+	field := matter.NewField(nil, parent, entityType)
+	field.ID = matter.NewNumber(fd.id)
+	field.Name = upperFirst(fd.name)
+	field.Type = buildType(fd.dataType.name, fd.list)
+	field.Access = matter.DefaultAccess(entityType)
+	field.Conformance = buildConformance(fd.qualities, fd.qualities.has("optional"))
+	if fd.qualities.has("nullable") {
+		field.Quality |= matter.QualityNullable
+	}
+	if fd.qualities.has("fabric_sensitive") {
+		field.Access.FabricSensitivity = matter.FabricSensitivitySensitive
+	}
+	if fd.dataType.maxLength != nil {
+		field.Constraint = &constraint.MaxConstraint{Maximum: &constraint.IntLimit{Value: int64(*fd.dataType.maxLength)}}
+	}
+	return field
+}
+
+func buildType(name string, list bool) *types.DataType {
+	rank := types.DataTypeRankScalar
+	if list {
+		rank = types.DataTypeRankList
+	}
+	switch name {
+	case "long_octet_string":
+		return types.NewDataType(types.BaseDataTypeOctStr, rank)
+	}
+	baseType := zap.ToBaseDataType(name)
+	if baseType == types.BaseDataTypeCustom {
+		return types.NewCustomDataType(name, rank)
+	}
+	return types.NewDataType(baseType, rank)
+}
+
+func buildConformance(q qualities, optional bool) (cs conformance.Set) {
+	if q.has("provisional") {
+		cs = append(cs, &conformance.Provisional{})
+	}
+	if optional {
+		cs = append(cs, &conformance.Optional{})
+	} else {
+		cs = append(cs, &conformance.Mandatory{})
+	}
+	return
+}
+
+func applyAccess(access *matter.Access, ads []*accessDecl) error {
+	for _, ad := range ads {
+		var privilege matter.Privilege
+		switch ad.privilege {
+		case "view":
+			privilege = matter.PrivilegeView
+		case "operate":
+			privilege = matter.PrivilegeOperate
+		case "manage":
+			privilege = matter.PrivilegeManage
+		case "administer":
+			privilege = matter.PrivilegeAdminister
+		default:
+			return fmt.Errorf("unknown privilege: %s", ad.privilege)
+		}
+		switch ad.operation {
+		case "read":
+			access.Read = privilege
+		case "write":
+			access.Write = privilege
+		case "invoke":
+			access.Invoke = privilege
+		}
+	}
+	return nil
+}
+
+// resolveFields links custom data types to the enums, bitmaps and structs they name, searching each set of data
+// types in turn
+func resolveFields(fields matter.FieldSet, dataTypes ...*matter.AssociatedDataTypes) {
+	for _, f := range fields {
+		dt := f.Type
+		if dt != nil && dt.IsArray() {
+			dt = dt.EntryType
+		}
+		if dt == nil || dt.BaseType != types.BaseDataTypeCustom {
+			continue
+		}
+		for _, adt := range dataTypes {
+			if entity := findDataType(adt, dt.Name); entity != nil {
+				dt.Entity = entity
+				break
+			}
+		}
+	}
+}
+
+func findDataType(adt *matter.AssociatedDataTypes, name string) types.Entity {
+	for _, e := range adt.Enums {
+		if e.Name == name {
+			return e
+		}
+	}
+	for _, bm := range adt.Bitmaps {
+		if bm.Name == name {
+			return bm
+		}
+	}
+	for _, s := range adt.Structs {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func constantName(cd *constantDecl) string {
+	if cd.specName != "" {
+		return cd.specName
+	}
+	return strings.TrimPrefix(cd.name, "k")
+}
+
+// maskBits converts a bit mask to the bit or range of bits it covers
+func maskBits(mask uint64) (string, error) {
+	if mask == 0 {
+		return "", fmt.Errorf("empty mask")
+	}
+	start := -1
+	end := -1
+	for offset := 0; offset < 64; offset++ {
+		if mask&(1<<offset) == 0 {
+			if start >= 0 && end < 0 {
+				end = offset - 1
+			}
+			continue
+		}
+		if start < 0 {
+			start = offset
+		} else if end >= 0 {
+			return "", fmt.Errorf("mask 0x%X is not contiguous", mask)
+		}
+	}
+	if end < 0 {
+		end = 63
+	}
+	if start == end {
+		return strconv.Itoa(start), nil
+	}
+	return fmt.Sprintf("%d..%d", start, end), nil
+}
+
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
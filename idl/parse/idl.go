@@ -0,0 +1,70 @@
+package parse
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/project-chip/alchemy/matter"
+)
+
+// IDL is the content of a .matter file; data types declared outside of a cluster are shared between clusters
+type IDL struct {
+	matter.AssociatedDataTypes
+
+	Clusters  []*matter.Cluster
+	Endpoints []*Endpoint
+}
+
+type Endpoint struct {
+	ID          uint64
+	DeviceTypes []*EndpointDeviceType
+	Bindings    []string
+	Servers     []*ServerCluster
+}
+
+type EndpointDeviceType struct {
+	Name    string
+	ID      uint64
+	Version uint64
+}
+
+type ServerCluster struct {
+	Name       string
+	Attributes []*AttributeInstance
+	Commands   []string
+	Events     []string
+}
+
+type AttributeInstance struct {
+	Name    string
+	Storage string
+	Default string
+}
+
+type emittedEvent struct {
+	name string
+}
+
+type handledCommand struct {
+	name string
+}
+
+func ParseIDLFile(path string) (*IDL, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseIDL(path, b)
+}
+
+func ParseIDL(path string, b []byte) (*IDL, error) {
+	f, err := Parse(path, b)
+	if err != nil {
+		return nil, err
+	}
+	idl, err := build(f.(*fileDecl))
+	if err != nil {
+		return nil, fmt.Errorf("error building %s: %w", path, err)
+	}
+	return idl, nil
+}
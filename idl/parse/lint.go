@@ -0,0 +1,167 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+)
+
+// Lint reports problems in a parsed IDL file which the grammar itself can not catch: duplicate names and IDs, and
+// endpoint instantiations of elements which the declared cluster does not have
+func Lint(idl *IDL) (problems []string) {
+	clusterNames := make(map[string]*matter.Cluster)
+	clusterIDs := make(map[uint64]*matter.Cluster)
+	for _, c := range idl.Clusters {
+		if _, ok := clusterNames[c.Name]; ok {
+			problems = append(problems, fmt.Sprintf("duplicate cluster name %s", c.Name))
+		}
+		clusterNames[c.Name] = c
+		if other, ok := clusterIDs[c.ID.Value()]; ok {
+			problems = append(problems, fmt.Sprintf("cluster %s has the same ID as cluster %s: %s", c.Name, other.Name, c.ID.HexString()))
+		}
+		clusterIDs[c.ID.Value()] = c
+		problems = append(problems, lintCluster(c)...)
+	}
+	problems = append(problems, lintDataTypes("", &idl.AssociatedDataTypes)...)
+
+	endpointIDs := make(map[uint64]struct{})
+	for _, e := range idl.Endpoints {
+		if _, ok := endpointIDs[e.ID]; ok {
+			problems = append(problems, fmt.Sprintf("duplicate endpoint %d", e.ID))
+		}
+		endpointIDs[e.ID] = struct{}{}
+		for _, s := range e.Servers {
+			c, ok := clusterNames[s.Name]
+			if !ok {
+				continue
+			}
+			problems = append(problems, lintServerCluster(e, s, c)...)
+		}
+	}
+	return
+}
+
+func lintCluster(c *matter.Cluster) (problems []string) {
+	prefix := fmt.Sprintf("cluster %s: ", c.Name)
+	problems = append(problems, lintFields(prefix+"attribute", c.Attributes)...)
+	problems = append(problems, lintDataTypes(prefix, &c.AssociatedDataTypes)...)
+
+	commandIDs := make(map[matter.Interface]map[uint64]string)
+	commandNames := make(map[string]struct{})
+	for _, cmd := range c.Commands {
+		if _, ok := commandNames[cmd.Name]; ok {
+			problems = append(problems, fmt.Sprintf("%sduplicate command name %s", prefix, cmd.Name))
+		}
+		commandNames[cmd.Name] = struct{}{}
+		ids, ok := commandIDs[cmd.Direction]
+		if !ok {
+			ids = make(map[uint64]string)
+			commandIDs[cmd.Direction] = ids
+		}
+		if other, ok := ids[cmd.ID.Value()]; ok {
+			problems = append(problems, fmt.Sprintf("%scommand %s has the same ID as command %s: %s", prefix, cmd.Name, other, cmd.ID.HexString()))
+		}
+		ids[cmd.ID.Value()] = cmd.Name
+		problems = append(problems, lintFields(fmt.Sprintf("%scommand %s field", prefix, cmd.Name), cmd.Fields)...)
+	}
+
+	eventIDs := make(map[uint64]string)
+	for _, e := range c.Events {
+		if other, ok := eventIDs[e.ID.Value()]; ok {
+			problems = append(problems, fmt.Sprintf("%sevent %s has the same ID as event %s: %s", prefix, e.Name, other, e.ID.HexString()))
+		}
+		eventIDs[e.ID.Value()] = e.Name
+		problems = append(problems, lintFields(fmt.Sprintf("%sevent %s field", prefix, e.Name), e.Fields)...)
+	}
+	return
+}
+
+func lintDataTypes(prefix string, adt *matter.AssociatedDataTypes) (problems []string) {
+	names := make(map[string]struct{})
+	checkName := func(kind string, name string) {
+		if _, ok := names[name]; ok {
+			problems = append(problems, fmt.Sprintf("%sduplicate %s name %s", prefix, kind, name))
+		}
+		names[name] = struct{}{}
+	}
+	for _, e := range adt.Enums {
+		checkName("enum", e.Name)
+		values := make(map[uint64]string)
+		for _, v := range e.Values {
+			if other, ok := values[v.Value.Value()]; ok {
+				problems = append(problems, fmt.Sprintf("%senum %s value %s has the same value as %s: %d", prefix, e.Name, v.Name, other, v.Value.Value()))
+			}
+			values[v.Value.Value()] = v.Name
+		}
+	}
+	for _, bm := range adt.Bitmaps {
+		checkName("bitmap", bm.Name)
+		var used uint64
+		for _, b := range bm.Bits {
+			mask, err := b.Mask()
+			if err != nil {
+				continue
+			}
+			if used&mask != 0 {
+				problems = append(problems, fmt.Sprintf("%sbitmap %s bit %s overlaps another bit: 0x%X", prefix, bm.Name, b.Name(), mask))
+			}
+			used |= mask
+		}
+	}
+	for _, s := range adt.Structs {
+		checkName("struct", s.Name)
+		problems = append(problems, lintFields(fmt.Sprintf("%sstruct %s field", prefix, s.Name), s.Fields)...)
+	}
+	return
+}
+
+func lintFields(prefix string, fields matter.FieldSet) (problems []string) {
+	ids := make(map[uint64]string)
+	names := make(map[string]struct{})
+	for _, f := range fields {
+		if _, ok := names[f.Name]; ok {
+			problems = append(problems, fmt.Sprintf("%s %s is declared more than once", prefix, f.Name))
+		}
+		names[f.Name] = struct{}{}
+		if other, ok := ids[f.ID.Value()]; ok {
+			problems = append(problems, fmt.Sprintf("%s %s has the same ID as %s: %s", prefix, f.Name, other, f.ID.HexString()))
+		}
+		ids[f.ID.Value()] = f.Name
+	}
+	return
+}
+
+func lintServerCluster(e *Endpoint, s *ServerCluster, c *matter.Cluster) (problems []string) {
+	prefix := fmt.Sprintf("endpoint %d: server cluster %s", e.ID, s.Name)
+	for _, a := range s.Attributes {
+		if c.Attributes.Get(upperFirst(a.Name)) == nil {
+			problems = append(problems, fmt.Sprintf("%s instantiates unknown attribute %s", prefix, a.Name))
+		}
+	}
+	for _, name := range s.Commands {
+		var found bool
+		for _, cmd := range c.Commands {
+			if cmd.Direction == matter.InterfaceServer && strings.EqualFold(cmd.Name, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s handles unknown command %s", prefix, name))
+		}
+	}
+	for _, name := range s.Events {
+		var found bool
+		for _, ev := range c.Events {
+			if strings.EqualFold(ev.Name, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s emits unknown event %s", prefix, name))
+		}
+	}
+	return
+}
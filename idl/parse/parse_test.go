@@ -0,0 +1,177 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+var testIDL = `// This IDL was generated automatically by Alchemy.
+
+enum AreaTypeTag : enum8 {
+  kAisle = 0;
+  kAttic = 1;
+}
+
+/** Attributes and commands for switching devices between 'On' and 'Off' states. */
+cluster OnOff = 6 {
+  revision 6;
+
+  enum DelayedAllOffEffectVariantEnum : enum8 {
+    kDelayedOffFastFade = 0;
+    kDelayedOffSlowFade = 2 [spec_name = "Delayed Off Slow Fade"];
+  }
+
+  bitmap Feature : bitmap32 {
+    kLighting = 0x1;
+    kOffOnly = 0x4;
+  }
+
+  bitmap ControlBitmap : bitmap8 {
+    kAcceptOnlyWhenOn = 0x1;
+    kRange = 0x6;
+  }
+
+  fabric_scoped struct ThingStruct {
+    nullable optional char_string<32> label = 0;
+    AreaTypeTag tags[] = 1;
+    fabric_idx fabricIndex = 254;
+  }
+
+  info event access(read: manage) StateChanged = 0 {
+    boolean onOff = 0;
+  }
+
+  readonly attribute boolean onOff = 0;
+  attribute access(write: manage) optional int16u onTime = 16385;
+  provisional attribute nullable ControlBitmap control = 16386;
+
+  request struct OffWithEffectRequest {
+    DelayedAllOffEffectVariantEnum effectIdentifier = 0;
+  }
+
+  response struct ThingResponse = 5 {
+    long_octet_string<400> data = 0;
+  }
+
+  /** On receipt of this command, a device SHALL enter its 'Off' state. */
+  command Off(): DefaultSuccess = 0;
+  timed fabric command access(invoke: administer) OffWithEffect(OffWithEffectRequest): ThingResponse = 64;
+}
+
+endpoint 1 {
+  device type ma_onofflight = 256, version 3;
+  binding cluster OnOff;
+
+  server cluster OnOff {
+    emits event StateChanged;
+    persist attribute onOff default = 0;
+    ram      attribute bogus default = "a; b";
+    handle command Off;
+  }
+}
+`
+
+func TestParseIDL(t *testing.T) {
+	idl, err := ParseIDL("test.matter", []byte(testIDL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(idl.Enums) != 1 || len(idl.Clusters) != 1 || len(idl.Endpoints) != 1 {
+		t.Fatalf("unexpected definitions: %d enums, %d clusters, %d endpoints", len(idl.Enums), len(idl.Clusters), len(idl.Endpoints))
+	}
+
+	cluster := idl.Clusters[0]
+	if cluster.Name != "OnOff" || cluster.ID.Value() != 6 || cluster.Revisions.MostRecent().Number.Value() != 6 {
+		t.Errorf("unexpected cluster: %s %s", cluster.Name, cluster.ID.HexString())
+	}
+	if cluster.Description != "Attributes and commands for switching devices between 'On' and 'Off' states." {
+		t.Errorf("unexpected cluster description: %q", cluster.Description)
+	}
+	if cluster.Features == nil || len(cluster.Features.Bits) != 2 || cluster.Features.Bits[1].Bit() != "2" {
+		t.Errorf("unexpected features: %v", cluster.Features)
+	}
+	if len(cluster.Bitmaps) != 1 || cluster.Bitmaps[0].Bits[1].Bit() != "1..2" {
+		t.Errorf("unexpected bitmaps: %v", cluster.Bitmaps)
+	}
+	if cluster.Enums[0].Values[1].Name != "Delayed Off Slow Fade" {
+		t.Errorf("expected spec name, got %s", cluster.Enums[0].Values[1].Name)
+	}
+
+	s := cluster.Structs[0]
+	if s.FabricScoping != matter.FabricScopingScoped {
+		t.Errorf("expected fabric scoped struct")
+	}
+	label := s.Fields[0]
+	if label.Name != "Label" || !label.Quality.Has(matter.QualityNullable) || label.Conformance.ASCIIDocString() != "O" {
+		t.Errorf("unexpected field %s: %v %v", label.Name, label.Quality, label.Conformance)
+	}
+	tags := s.Fields[1]
+	if !tags.Type.IsArray() || tags.Type.EntryType.Entity != idl.Enums[0] {
+		t.Errorf("expected list of shared enum, got %v", tags.Type)
+	}
+
+	onOff := cluster.Attributes[0]
+	if onOff.Access.Write != matter.PrivilegeUnknown || onOff.Type.BaseType != types.BaseDataTypeBoolean {
+		t.Errorf("unexpected attribute %s: %v", onOff.Name, onOff.Access)
+	}
+	if cluster.Attributes[1].Access.Write != matter.PrivilegeManage {
+		t.Errorf("expected manage write access, got %v", cluster.Attributes[1].Access.Write)
+	}
+	if !conformance.IsProvisional(cluster.Attributes[2].Conformance) || cluster.Attributes[2].Type.Entity != cluster.Bitmaps[0] {
+		t.Errorf("unexpected attribute %s: %v", cluster.Attributes[2].Name, cluster.Attributes[2].Conformance)
+	}
+
+	if len(cluster.Commands) != 3 {
+		t.Fatalf("expected 3 commands, got %d", len(cluster.Commands))
+	}
+	response := cluster.Commands[0]
+	if response.Direction != matter.InterfaceClient || response.ID.Value() != 5 || response.Fields[0].Type.BaseType != types.BaseDataTypeOctStr {
+		t.Errorf("unexpected response %s", response.Name)
+	}
+	off := cluster.Commands[1]
+	if off.Response.Name != "Y" || off.Description == "" {
+		t.Errorf("unexpected command %s: %s", off.Name, off.Response.Name)
+	}
+	offWithEffect := cluster.Commands[2]
+	if offWithEffect.Access.Invoke != matter.PrivilegeAdminister || offWithEffect.Access.Timing != matter.TimingTimed ||
+		offWithEffect.Access.FabricScoping != matter.FabricScopingScoped || len(offWithEffect.Fields) != 1 ||
+		offWithEffect.Response.Name != "ThingResponse" {
+		t.Errorf("unexpected command %s: %v", offWithEffect.Name, offWithEffect.Access)
+	}
+
+	event := cluster.Events[0]
+	if event.Priority != "Info" || event.Access.Read != matter.PrivilegeManage {
+		t.Errorf("unexpected event %s: %s %v", event.Name, event.Priority, event.Access)
+	}
+
+	endpoint := idl.Endpoints[0]
+	if endpoint.ID != 1 || endpoint.DeviceTypes[0].ID != 256 || endpoint.DeviceTypes[0].Version != 3 || endpoint.Bindings[0] != "OnOff" {
+		t.Errorf("unexpected endpoint: %v", endpoint)
+	}
+	server := endpoint.Servers[0]
+	if len(server.Attributes) != 2 || server.Attributes[1].Default != "a; b" || server.Commands[0] != "Off" || server.Events[0] != "StateChanged" {
+		t.Errorf("unexpected server cluster: %v", server)
+	}
+
+	problems := Lint(idl)
+	if len(problems) != 1 {
+		t.Errorf("expected one problem, got %v", problems)
+	}
+}
+
+func TestParseIDLErrors(t *testing.T) {
+	tests := []string{
+		"cluster OnOff = 6 { readonly attribute boolean onOff = 0 }",
+		"cluster OnOff = 6 { command Off(MissingRequest): DefaultSuccess = 0; }",
+		"cluster OnOff = 6 { bitmap Control : bitmap8 { kA = 0x5; } }",
+		"endpoint 1 { server cluster OnOff { ram attribute onOff default = 0 } }",
+	}
+	for _, test := range tests {
+		if _, err := ParseIDL("test.matter", []byte(test)); err == nil {
+			t.Errorf("expected error parsing %q", test)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package generate
 
 import (
-	"bytes"
 	"fmt"
 	"go/token"
 	"slices"
@@ -83,12 +82,7 @@ func optimizeParser(source string, customPatcher ParserPatcher) (out string, err
 			return
 		}
 	}
-	buf := bytes.NewBuffer(make([]byte, 0, len(source)))
-	err = decorator.Fprint(buf, file)
-	if err != nil {
-		return
-	}
-	out = buf.String()
+	out, err = Source(file)
 	return
 }
 
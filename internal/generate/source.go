@@ -0,0 +1,18 @@
+package generate
+
+import (
+	"bytes"
+
+	"github.com/dave/dst"
+	"github.com/dave/dst/decorator"
+)
+
+// Source prints a Go file, formatted as gofmt would
+func Source(file *dst.File) (string, error) {
+	var buf bytes.Buffer
+	err := decorator.Fprint(&buf, file)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -23,6 +23,15 @@ func (nar *ShallowRepositoryError) Error() string {
 	return fmt.Sprintf("%s is too shallow", nar.Path)
 }
 
+type UnknownRefError struct {
+	Path string
+	Ref  string
+}
+
+func (ure *UnknownRefError) Error() string {
+	return fmt.Sprintf("%s does not name a commit in the Git repository at %s", ure.Ref, ure.Path)
+}
+
 func GitDescribe(root string) (string, error) {
 	cmd := exec.Command("git", "describe", "--dirty", "--broken", "--tags")
 	cmd.Dir = root
@@ -0,0 +1,72 @@
+package vcs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// AddWorktree checks out ref from the repository containing root into a new temporary worktree, and returns
+// the path corresponding to root within it, along with a function which removes the worktree again
+func AddWorktree(root string, ref string) (path string, remove func() error, err error) {
+	var commit string
+	commit, err = ResolveRef(root, ref)
+	if err != nil {
+		return
+	}
+	var prefix string
+	prefix, err = runGit(root, "rev-parse", "--show-prefix")
+	if err != nil {
+		return
+	}
+	var worktree string
+	worktree, err = os.MkdirTemp("", "alchemy-worktree-")
+	if err != nil {
+		return
+	}
+	_, err = runGit(root, "worktree", "add", "--detach", worktree, commit)
+	if err != nil {
+		os.RemoveAll(worktree)
+		err = fmt.Errorf("error checking out %s: %w", ref, err)
+		return
+	}
+	path = filepath.Join(worktree, prefix)
+	remove = func() error {
+		_, err := runGit(root, "worktree", "remove", "--force", worktree)
+		if err != nil {
+			return err
+		}
+		return os.RemoveAll(worktree)
+	}
+	return
+}
+
+// ResolveRef returns the commit which ref names in the repository containing root
+func ResolveRef(root string, ref string) (string, error) {
+	commit, err := runGit(root, "rev-parse", "--verify", "--end-of-options", ref+"^{commit}")
+	if err != nil {
+		var notARepoError *NotARepositoryError
+		if errors.As(err, &notARepoError) {
+			return "", err
+		}
+		return "", &UnknownRefError{Path: root, Ref: ref}
+	}
+	return commit, nil
+}
+
+func runGit(root string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = root
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", parseGitError(root, stderr.String())
+	}
+	return strings.TrimSpace(string(out)), nil
+}
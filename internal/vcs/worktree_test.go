@@ -0,0 +1,72 @@
+package vcs
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+}
+
+func TestAddWorktree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo := t.TempDir()
+	root := filepath.Join(repo, "spec")
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(content string) {
+		if err := os.WriteFile(filepath.Join(root, "cluster.adoc"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git(t, repo, "init", "-q")
+	write("base\n")
+	git(t, repo, "add", "-A")
+	git(t, repo, "commit", "-q", "-m", "base")
+	git(t, repo, "tag", "base")
+	write("head\n")
+	git(t, repo, "commit", "-q", "-a", "-m", "head")
+
+	// The path returned is the spec root within the worktree, not the root of the repository
+	path, remove, err := AddWorktree(root, "base")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(filepath.Join(path, "cluster.adoc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "base\n" {
+		t.Errorf("expected the worktree to have the base revision, got %q", content)
+	}
+	if content, _ = os.ReadFile(filepath.Join(root, "cluster.adoc")); string(content) != "head\n" {
+		t.Errorf("expected the working copy to be left alone, got %q", content)
+	}
+	if err = remove(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the worktree to be removed, got %v", err)
+	}
+
+	var unknownRef *UnknownRefError
+	if _, _, err = AddWorktree(root, "no-such-ref"); !errors.As(err, &unknownRef) {
+		t.Errorf("expected an unknown ref error, got %v", err)
+	}
+	var notARepo *NotARepositoryError
+	if _, err = ResolveRef(t.TempDir(), "HEAD"); !errors.As(err, &notARepo) {
+		t.Errorf("expected a not a repository error, got %v", err)
+	}
+}
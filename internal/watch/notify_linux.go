@@ -0,0 +1,137 @@
+//go:build linux
+
+package watch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+const inotifyMask = syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_TO | syscall.IN_MOVED_FROM | syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_DELETE_SELF
+
+// notify uses inotify to send the path of any file under roots which is written, moved or removed;
+// if inotify can't be used, it falls back to polling
+func notify(cxt context.Context, roots []string, events chan<- string) error {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		slog.Warn("Unable to use inotify; polling for changes instead", slog.Any("error", err))
+		return poll(cxt, roots, events)
+	}
+	// The descriptor is non-blocking, so reads from the file go through the runtime's poller and honor deadlines
+	f := os.NewFile(uintptr(fd), "inotify")
+	defer f.Close()
+
+	in := &inotify{fd: fd, dirs: make(map[int]string)}
+	for _, root := range roots {
+		err = in.addTree(root)
+		if err != nil {
+			slog.Warn("Unable to watch directories with inotify; polling for changes instead", slog.Any("error", err))
+			return poll(cxt, roots, events)
+		}
+	}
+
+	buf := make([]byte, 64*1024)
+	for {
+		if cxt.Err() != nil {
+			return nil
+		}
+		// Wake up periodically to notice cancellation
+		err = f.SetReadDeadline(time.Now().Add(250 * time.Millisecond))
+		if err != nil {
+			return err
+		}
+		var n int
+		n, err = f.Read(buf)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameBytes := buf[offset+syscall.SizeofInotifyEvent : offset+syscall.SizeofInotifyEvent+int(event.Len)]
+			offset += syscall.SizeofInotifyEvent + int(event.Len)
+
+			dir, ok := in.dirs[int(event.Wd)]
+			if !ok {
+				continue
+			}
+			if event.Mask&syscall.IN_IGNORED != 0 {
+				delete(in.dirs, int(event.Wd))
+				continue
+			}
+			name := strings.TrimRight(string(nameBytes), "\x00")
+			if name == "" {
+				continue
+			}
+			path := filepath.Join(dir, name)
+			if event.Mask&syscall.IN_ISDIR != 0 {
+				// As in the initial walk, hidden directories such as .git aren't watched
+				if event.Mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0 && !strings.HasPrefix(name, ".") {
+					// New directories need watches of their own, and may already contain files
+					err = in.addTree(path)
+					if err != nil {
+						slog.Warn("Unable to watch new directory", slog.String("path", path), slog.Any("error", err))
+					}
+					for _, p := range in.files(path) {
+						if !send(cxt, events, p) {
+							return nil
+						}
+					}
+				}
+				continue
+			}
+			if event.Mask&syscall.IN_CREATE != 0 {
+				// Wait for the file to be closed before reading it
+				continue
+			}
+			if !send(cxt, events, path) {
+				return nil
+			}
+		}
+	}
+}
+
+type inotify struct {
+	fd   int
+	dirs map[int]string
+}
+
+func (in *inotify) addTree(root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		wd, err := syscall.InotifyAddWatch(in.fd, path, inotifyMask)
+		if err != nil {
+			return err
+		}
+		in.dirs[wd] = path
+		return nil
+	})
+}
+
+func (in *inotify) files(root string) (paths []string) {
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	return
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package watch
+
+import "context"
+
+func notify(cxt context.Context, roots []string, events chan<- string) error {
+	return poll(cxt, roots, events)
+}
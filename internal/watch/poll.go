@@ -0,0 +1,81 @@
+package watch
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const pollInterval = 500 * time.Millisecond
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// poll sends the path of any file under roots whose modification time or size changes, or which is
+// created or removed; it is used where filesystem notifications aren't available
+func poll(cxt context.Context, roots []string, events chan<- string) error {
+	states := scan(roots)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-cxt.Done():
+			return nil
+		case <-ticker.C:
+		}
+		current := scan(roots)
+		for path, state := range current {
+			if last, ok := states[path]; !ok || last != state {
+				if !send(cxt, events, path) {
+					return nil
+				}
+			}
+		}
+		for path := range states {
+			if _, ok := current[path]; !ok {
+				if !send(cxt, events, path) {
+					return nil
+				}
+			}
+		}
+		states = current
+	}
+}
+
+func scan(roots []string) map[string]fileState {
+	states := make(map[string]fileState)
+	for _, root := range roots {
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if path != root && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			states[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+	}
+	return states
+}
+
+// send delivers path to events, reporting false if cxt is cancelled first
+func send(cxt context.Context, events chan<- string, path string) bool {
+	select {
+	case events <- path:
+		return true
+	case <-cxt.Done():
+		return false
+	}
+}
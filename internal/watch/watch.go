@@ -0,0 +1,137 @@
+// Package watch re-runs work when files change on disk
+package watch
+
+import (
+	"context"
+	"crypto/sha256"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+type Options struct {
+	Watch         bool          `default:"false" help:"keep running, and regenerate output for any documents affected when files change" group:"Watch:"`
+	WatchDebounce time.Duration `default:"300ms" name:"watch-debounce" help:"how long to wait after the last change before regenerating" group:"Watch:"`
+}
+
+// Callback is called with the absolute paths of files which have changed
+type Callback func(cxt context.Context, changed []string) error
+
+// Run watches the directories in roots, calling callback with any files whose name ends in one of
+// extensions when their contents change, until cxt is cancelled.
+//
+// Changes are batched until no further changes arrive within the debounce interval. Files which are
+// written without their contents changing, including those rewritten by the callback itself, are ignored.
+func Run(cxt context.Context, roots []string, extensions []string, options Options, callback Callback) (err error) {
+	w := &watcher{
+		extensions: extensions,
+		hashes:     make(map[string][sha256.Size]byte),
+	}
+	roots = slices.Clone(roots)
+	for i, root := range roots {
+		roots[i], err = filepath.Abs(root)
+		if err != nil {
+			return
+		}
+		w.hashTree(roots[i])
+	}
+
+	events := make(chan string, 64)
+	errs := make(chan error, 1)
+	cxt, cancel := context.WithCancel(cxt)
+	defer cancel()
+	go func() {
+		errs <- notify(cxt, roots, events)
+	}()
+
+	slog.Info("Watching for changes", slog.Any("roots", roots))
+	pending := make(map[string]struct{})
+	timer := time.NewTimer(0)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	for {
+		select {
+		case <-cxt.Done():
+			return nil
+		case err = <-errs:
+			return
+		case path := <-events:
+			if !w.changed(path) {
+				continue
+			}
+			pending[path] = struct{}{}
+			timer.Reset(options.WatchDebounce)
+		case <-timer.C:
+			changed := make([]string, 0, len(pending))
+			for path := range pending {
+				changed = append(changed, path)
+			}
+			clear(pending)
+			slices.Sort(changed)
+			slog.Info("Files changed", slog.Any("paths", changed))
+			err = callback(cxt, changed)
+			if err != nil {
+				// Documents are often briefly broken while being edited, so keep watching
+				slog.Error("Error regenerating", slog.Any("error", err))
+			}
+			// Don't respond to the callback rewriting the files which triggered it
+			for _, path := range changed {
+				w.changed(path)
+			}
+		}
+	}
+}
+
+type watcher struct {
+	extensions []string
+	hashes     map[string][sha256.Size]byte
+}
+
+func (w *watcher) matches(path string) bool {
+	for _, ext := range w.extensions {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *watcher) hashTree(root string) {
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		w.changed(path)
+		return nil
+	})
+}
+
+// changed records the current contents of path, and reports whether they differ from the last time it was seen
+func (w *watcher) changed(path string) bool {
+	if !w.matches(path) {
+		return false
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if _, ok := w.hashes[path]; ok {
+			delete(w.hashes, path)
+			return true
+		}
+		return false
+	}
+	hash := sha256.Sum256(b)
+	last, ok := w.hashes[path]
+	w.hashes[path] = hash
+	return !ok || last != hash
+}
@@ -0,0 +1,187 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+type testWatcher struct {
+	t       *testing.T
+	root    string
+	changes chan []string
+	cancel  context.CancelFunc
+	done    chan error
+}
+
+func startWatcher(t *testing.T, debounce time.Duration) *testWatcher {
+	root := t.TempDir()
+	cxt, cancel := context.WithCancel(context.Background())
+	tw := &testWatcher{t: t, root: root, changes: make(chan []string, 16), cancel: cancel, done: make(chan error, 1)}
+	go func() {
+		tw.done <- Run(cxt, []string{root}, []string{".adoc"}, Options{Watch: true, WatchDebounce: debounce}, func(cxt context.Context, changed []string) error {
+			tw.changes <- changed
+			return nil
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-tw.done; err != nil {
+			t.Errorf("unexpected error from watcher: %v", err)
+		}
+	})
+	// The watcher starts in the background, so keep changing a file until it notices
+	ready := tw.path("ready.adoc")
+	for i := 0; ; i++ {
+		tw.write(ready, fmt.Sprintf("ready %d", i))
+		select {
+		case <-tw.changes:
+			tw.drain()
+			return tw
+		case <-time.After(debounce + 200*time.Millisecond):
+			if i > 50 {
+				t.Fatalf("watcher never started")
+			}
+		}
+	}
+}
+
+func (tw *testWatcher) path(name string) string {
+	return filepath.Join(tw.root, filepath.FromSlash(name))
+}
+
+func (tw *testWatcher) write(path string, content string) {
+	tw.t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		tw.t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		tw.t.Fatal(err)
+	}
+}
+
+// drain discards any changes already reported, e.g. from a previous write of the same file
+func (tw *testWatcher) drain() {
+	for {
+		select {
+		case <-tw.changes:
+		case <-time.After(500 * time.Millisecond):
+			return
+		}
+	}
+}
+
+func (tw *testWatcher) expect(paths ...string) {
+	tw.t.Helper()
+	select {
+	case changed := <-tw.changes:
+		if !slices.Equal(changed, paths) {
+			tw.t.Errorf("expected changes %v, got %v", paths, changed)
+		}
+	case <-time.After(5 * time.Second):
+		tw.t.Fatalf("timed out waiting for changes %v", paths)
+	}
+}
+
+func (tw *testWatcher) expectNone(wait time.Duration) {
+	tw.t.Helper()
+	select {
+	case changed := <-tw.changes:
+		tw.t.Errorf("expected no changes, got %v", changed)
+	case <-time.After(wait):
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	tw := startWatcher(t, 300*time.Millisecond)
+	a := tw.path("a.adoc")
+	b := tw.path("b.adoc")
+	// Changes within the debounce interval of each other are reported together, once
+	tw.write(a, "one")
+	tw.write(b, "one")
+	tw.write(a, "two")
+	tw.write(tw.path("ignored.txt"), "one")
+	tw.expect(a, b)
+	tw.expectNone(600 * time.Millisecond)
+
+	// Writing the same contents again is not a change
+	tw.write(a, "two")
+	tw.expectNone(600 * time.Millisecond)
+
+	tw.write(a, "three")
+	tw.expect(a)
+}
+
+func TestDirectories(t *testing.T) {
+	tw := startWatcher(t, 100*time.Millisecond)
+	// Files in new directories, however deep, are watched
+	nested := tw.path("new/nested/c.adoc")
+	tw.write(nested, "one")
+	tw.expect(nested)
+	tw.write(nested, "two")
+	tw.expect(nested)
+
+	// Removing a directory removes the files in it
+	if err := os.RemoveAll(tw.path("new")); err != nil {
+		t.Fatal(err)
+	}
+	tw.expect(nested)
+
+	// Hidden directories are not watched
+	tw.write(tw.path(".git/d.adoc"), "one")
+	tw.expectNone(400 * time.Millisecond)
+}
+
+func TestPoll(t *testing.T) {
+	root := t.TempDir()
+	existing := filepath.Join(root, "a.adoc")
+	if err := os.WriteFile(existing, []byte("one"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cxt, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan string, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- poll(cxt, []string{root}, events)
+	}()
+	// Let the first scan happen before changing anything
+	time.Sleep(pollInterval / 2)
+
+	expect := func(path string) {
+		t.Helper()
+		select {
+		case p := <-events:
+			if p != path {
+				t.Errorf("expected a change to %s, got %s", path, p)
+			}
+		case <-time.After(4 * pollInterval):
+			t.Fatalf("timed out waiting for a change to %s", path)
+		}
+	}
+	if err := os.WriteFile(existing, []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	expect(existing)
+	created := filepath.Join(root, "sub", "b.adoc")
+	if err := os.MkdirAll(filepath.Dir(created), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(created, []byte("one"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	expect(created)
+	if err := os.Remove(existing); err != nil {
+		t.Fatal(err)
+	}
+	expect(existing)
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("unexpected error from poll: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package jsonschema
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+type Options struct {
+	SchemaRoot string `default:"schema" help:"where to place the JSON Schema files" group:"JSON Schema:"`
+}
+
+type Renderer struct {
+	spec    *spec.Specification
+	options Options
+}
+
+func NewRenderer(spec *spec.Specification, options Options) *Renderer {
+	return &Renderer{spec: spec, options: options}
+}
+
+func (r *Renderer) Name() string {
+	return "Rendering JSON Schema"
+}
+
+func (r *Renderer) Process(cxt context.Context, input *pipeline.Data[*asciidoc.Document], index int32, total int32) (outputs []*pipeline.Data[string], extra []*pipeline.Data[*asciidoc.Document], err error) {
+	for _, e := range r.spec.EntitiesForDocument(input.Content) {
+		var clusters []*matter.Cluster
+		switch e := e.(type) {
+		case *matter.Cluster:
+			clusters = append(clusters, e)
+		case *matter.ClusterGroup:
+			clusters = append(clusters, e.Clusters...)
+		}
+		for _, c := range clusters {
+			var s string
+			s, err = RenderCluster(c)
+			if err != nil {
+				err = fmt.Errorf("failed rendering JSON Schema for %s: %w", input.Path, err)
+				return
+			}
+			outputs = append(outputs, pipeline.NewData(r.schemaPath(c), s))
+		}
+	}
+	return
+}
+
+func (r *Renderer) schemaPath(cluster *matter.Cluster) string {
+	return filepath.Join(r.options.SchemaRoot, matter.Case(cluster.Name)+".schema.json")
+}
@@ -0,0 +1,399 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/project-chip/alchemy/internal"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+const draft = "https://json-schema.org/draft/2020-12/schema"
+
+// RenderCluster generates a JSON Schema document for a cluster; its data types, command payloads and event payloads are
+// each given an entry in $defs, as are global data types which they refer to
+func RenderCluster(cluster *matter.Cluster) (string, error) {
+	g := &generator{cluster: cluster, defs: internal.NewJSONMap(), rendered: make(map[types.Entity]struct{})}
+
+	s := internal.NewJSONMap()
+	s.Set("$schema", draft)
+	s.Set("title", cluster.Name)
+	if cluster.Description != "" {
+		s.Set("description", cluster.Description)
+	}
+	s.Set("$comment", clusterComment(cluster))
+
+	for _, bm := range cluster.Bitmaps {
+		g.addEntity(bm)
+	}
+	for _, e := range cluster.Enums {
+		g.addEntity(e)
+	}
+	for _, st := range cluster.Structs {
+		g.addEntity(st)
+	}
+	for _, td := range cluster.TypeDefs {
+		g.addEntity(td)
+	}
+	for _, cmd := range cluster.Commands {
+		if !matter.IsIncluded(cmd.Conformance) {
+			continue
+		}
+		def := g.objectSchema(cmd.Name, cmd.Description, cmd.Fields, false)
+		def.Set("$comment", fmt.Sprintf("Command %s, %s", cmd.ID.HexString(), commandDirection(cmd)))
+		g.defs.Set(matter.WithSuffix(cmd.Name, "Command"), def)
+	}
+	for _, e := range cluster.Events {
+		if !matter.IsIncluded(e.Conformance) {
+			continue
+		}
+		def := g.objectSchema(e.Name, e.Description, e.Fields, false)
+		def.Set("$comment", fmt.Sprintf("Event %s, priority %s", e.ID.HexString(), e.Priority))
+		g.defs.Set(matter.WithSuffix(e.Name, "Event"), def)
+	}
+	// Rendering a global data type may queue further global data types
+	for len(g.pending) > 0 {
+		entity := g.pending[0]
+		g.pending = g.pending[1:]
+		g.addEntity(entity)
+	}
+	if g.defs.Len() > 0 {
+		s.Set("$defs", g.defs)
+	}
+
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error marshaling JSON Schema for %s: %w", cluster.Name, err)
+	}
+	return string(b) + "\n", nil
+}
+
+type generator struct {
+	cluster  *matter.Cluster
+	defs     *internal.JSONMap
+	rendered map[types.Entity]struct{}
+	pending  []types.Entity
+}
+
+func (g *generator) addEntity(entity types.Entity) {
+	if _, ok := g.rendered[entity]; ok {
+		return
+	}
+	g.rendered[entity] = struct{}{}
+	switch entity := entity.(type) {
+	case *matter.Bitmap:
+		g.defs.Set(entity.Name, g.bitmapSchema(entity))
+	case *matter.Enum:
+		g.defs.Set(entity.Name, g.enumSchema(entity))
+	case *matter.Struct:
+		g.defs.Set(entity.Name, g.objectSchema(entity.Name, entity.Description, entity.Fields, entity.FabricScoping == matter.FabricScopingScoped))
+	case *matter.TypeDef:
+		def := g.dataTypeSchema(entity.Type, nil, nil)
+		def.Set("title", entity.Name)
+		if entity.Description != "" {
+			def.Set("description", entity.Description)
+		}
+		g.defs.Set(entity.Name, def)
+	}
+}
+
+func (g *generator) bitmapSchema(bm *matter.Bitmap) *internal.JSONMap {
+	s := titled(bm.Name, bm.Description)
+	s.Set("type", "integer")
+	s.Set("minimum", 0)
+	if bm.Type != nil {
+		if max := types.Max(bm.Type.BaseType, types.NullabilityNonNull); max.IsNumeric() {
+			s.Set("maximum", max.Value())
+		}
+	}
+	return s
+}
+
+func (g *generator) enumSchema(e *matter.Enum) *internal.JSONMap {
+	s := titled(e.Name, e.Description)
+	s.Set("type", "integer")
+	var values []any
+	for _, ev := range e.Values {
+		if !ev.Value.Valid() || !matter.IsIncluded(ev.Conformance) {
+			continue
+		}
+		value := titled(ev.Name, ev.Summary)
+		value.Set("const", ev.Value.Value())
+		values = append(values, value)
+	}
+	if len(values) > 0 {
+		s.Set("oneOf", values)
+	}
+	return s
+}
+
+// objectSchema describes a struct, command or event payload; fields with mandatory conformance are required, and all
+// others may be omitted
+func (g *generator) objectSchema(name string, description string, fields matter.FieldSet, fabricScoped bool) *internal.JSONMap {
+	s := titled(name, description)
+	s.Set("type", "object")
+	properties := internal.NewJSONMap()
+	var required []string
+	var hasFabricIndex bool
+	for _, f := range fields {
+		if !matter.IsIncluded(f.Conformance) {
+			continue
+		}
+		if f.ID.Valid() && f.ID.Value() == matter.FabricIndexFieldID {
+			hasFabricIndex = true
+		}
+		properties.Set(f.Name, g.fieldSchema(f, fields))
+		if conformance.IsMandatory(f.Conformance) {
+			required = append(required, f.Name)
+		}
+	}
+	if fabricScoped && !hasFabricIndex {
+		fi := integerSchema(types.BaseDataTypeFabricIndex, types.NullabilityNonNull)
+		fi.Set("$comment", "implicit field of fabric-scoped structs")
+		properties.Set("FabricIndex", fi)
+	}
+	if properties.Len() > 0 {
+		s.Set("properties", properties)
+	}
+	if len(required) > 0 {
+		s.Set("required", required)
+	}
+	s.Set("additionalProperties", false)
+	return s
+}
+
+func (g *generator) fieldSchema(field *matter.Field, fields matter.FieldSet) *internal.JSONMap {
+	s := g.dataTypeSchema(field.Type, field, fields)
+	if field.Fallback != nil && !field.Type.IsArray() {
+		fallback := field.Fallback.Fallback(matter.NewConstraintContext(field, fields))
+		switch {
+		case fallback.IsNumeric():
+			s.Set("default", fallback.Value())
+		case fallback.IsNull() && field.Quality.Has(matter.QualityNullable):
+			s.Set("default", nil)
+		}
+	}
+	if field.Quality.Has(matter.QualityNullable) {
+		s = nullable(s)
+	}
+	return s
+}
+
+// dataTypeSchema describes a value of dataType; when field is set, its constraint narrows the bounds of the type
+func (g *generator) dataTypeSchema(dataType *types.DataType, field *matter.Field, fields matter.FieldSet) *internal.JSONMap {
+	if dataType == nil {
+		return unresolved("missing data type")
+	}
+
+	var c constraint.Constraint
+	var cc constraint.Context
+	nullability := types.NullabilityNonNull
+	if field != nil {
+		c = field.Constraint
+		cc = matter.NewConstraintContext(field, fields)
+		if field.Quality.Has(matter.QualityNullable) {
+			nullability = types.NullabilityNullable
+		}
+	}
+	if set, ok := c.(constraint.Set); ok && len(set) == 1 {
+		c = set[0]
+	}
+
+	if dataType.IsArray() {
+		var entryConstraint constraint.Constraint
+		if lc, ok := c.(*constraint.ListConstraint); ok {
+			c = lc.Constraint
+			entryConstraint = lc.EntryConstraint
+		}
+		s := internal.NewJSONMap()
+		s.Set("type", "array")
+		items := g.dataTypeSchema(dataType.EntryType, nil, nil)
+		if entryConstraint != nil {
+			applyBounds(items, entryConstraint, cc, dataType.EntryType)
+		}
+		s.Set("items", items)
+		applyBounds(s, c, cc, dataType)
+		return s
+	}
+
+	if dataType.Entity != nil {
+		switch entity := dataType.Entity.(type) {
+		case *matter.Bitmap, *matter.Enum, *matter.Struct, *matter.TypeDef:
+			if entity.Parent() != g.cluster {
+				g.queue(entity)
+			}
+			s := internal.NewJSONMap()
+			s.Set("$ref", "#/$defs/"+entityName(entity))
+			return s
+		}
+	}
+
+	var s *internal.JSONMap
+	switch dataType.BaseType {
+	case types.BaseDataTypeCustom:
+		return unresolved(fmt.Sprintf("unresolved data type %s", dataType.Name))
+	case types.BaseDataTypeBoolean:
+		s = internal.NewJSONMap()
+		s.Set("type", "boolean")
+		return s
+	case types.BaseDataTypeSingle, types.BaseDataTypeDouble:
+		s = internal.NewJSONMap()
+		s.Set("type", "number")
+	case types.BaseDataTypeString:
+		s = internal.NewJSONMap()
+		s.Set("type", "string")
+	case types.BaseDataTypeOctStr:
+		s = internal.NewJSONMap()
+		s.Set("type", "string")
+		s.Set("contentEncoding", "base16")
+		s.Set("pattern", "^([0-9A-Fa-f]{2})*$")
+	default:
+		s = integerSchema(dataType.BaseType, nullability)
+	}
+	applyBounds(s, c, cc, dataType)
+	return s
+}
+
+func (g *generator) queue(entity types.Entity) {
+	if _, ok := g.rendered[entity]; ok {
+		return
+	}
+	for _, p := range g.pending {
+		if p == entity {
+			return
+		}
+	}
+	g.pending = append(g.pending, entity)
+}
+
+func integerSchema(baseType types.BaseDataType, nullability types.Nullability) *internal.JSONMap {
+	s := internal.NewJSONMap()
+	s.Set("type", "integer")
+	if min := types.Min(baseType, nullability); min.IsNumeric() {
+		s.Set("minimum", min.Value())
+	}
+	if max := types.Max(baseType, nullability); max.IsNumeric() {
+		s.Set("maximum", max.Value())
+	}
+	return s
+}
+
+// applyBounds narrows a schema with the minimum and maximum of a constraint; the bounds of strings and lists limit
+// their length, and octet strings are twice as long in hex as they are in bytes
+func applyBounds(s *internal.JSONMap, c constraint.Constraint, cc constraint.Context, dataType *types.DataType) {
+	if c == nil || cc == nil {
+		return
+	}
+	if exact, ok := c.(*constraint.ExactConstraint); ok && !dataType.IsArray() && !dataType.HasLength() {
+		if v := exact.Min(cc); v.IsNumeric() {
+			s.Set("const", v.Value())
+			return
+		}
+	}
+	min := c.Min(cc)
+	max := c.Max(cc)
+	switch {
+	case dataType.IsArray():
+		setLength(s, "minItems", min, 1)
+		setLength(s, "maxItems", max, 1)
+	case dataType.BaseType == types.BaseDataTypeOctStr:
+		setLength(s, "minLength", min, 2)
+		setLength(s, "maxLength", max, 2)
+	case dataType.HasLength():
+		setLength(s, "minLength", min, 1)
+		setLength(s, "maxLength", max, 1)
+	case dataType.BaseType == types.BaseDataTypeBoolean:
+	default:
+		if min.IsNumeric() {
+			s.Set("minimum", min.Value())
+		}
+		if max.IsNumeric() {
+			s.Set("maximum", max.Value())
+		}
+	}
+}
+
+func setLength(s *internal.JSONMap, keyword string, limit types.DataTypeExtreme, scale uint64) {
+	if !limit.IsNumeric() || limit.IsNegative() {
+		return
+	}
+	length := limit.Big().Uint64() * scale
+	if keyword == "minItems" || keyword == "minLength" {
+		if length == 0 {
+			return
+		}
+	}
+	s.Set(keyword, length)
+}
+
+// unresolved describes a value whose data type is not known; the empty schema accepts any value, but as an empty
+// JSONMap marshals to null, the reason is left in a comment
+func unresolved(reason string) *internal.JSONMap {
+	s := internal.NewJSONMap()
+	s.Set("$comment", reason)
+	return s
+}
+
+// nullable allows null in addition to the values a schema describes
+func nullable(s *internal.JSONMap) *internal.JSONMap {
+	if t, ok := s.Get("type"); ok {
+		if t, ok := t.(string); ok {
+			s.Set("type", []string{t, "null"})
+			return s
+		}
+	}
+	n := internal.NewJSONMap()
+	null := internal.NewJSONMap()
+	null.Set("type", "null")
+	n.Set("anyOf", []any{s, null})
+	return n
+}
+
+func titled(title string, description string) *internal.JSONMap {
+	s := internal.NewJSONMap()
+	s.Set("title", title)
+	if description != "" {
+		s.Set("description", description)
+	}
+	return s
+}
+
+func entityName(entity types.Entity) string {
+	switch entity := entity.(type) {
+	case *matter.Bitmap:
+		return entity.Name
+	case *matter.Enum:
+		return entity.Name
+	case *matter.Struct:
+		return entity.Name
+	case *matter.TypeDef:
+		return entity.Name
+	}
+	return ""
+}
+
+func commandDirection(cmd *matter.Command) string {
+	switch cmd.Direction {
+	case matter.InterfaceServer:
+		return "client to server"
+	case matter.InterfaceClient:
+		return "server to client"
+	}
+	return "unknown direction"
+}
+
+func clusterComment(cluster *matter.Cluster) string {
+	var s strings.Builder
+	s.WriteString("Generated by Alchemy from the Matter specification")
+	if cluster.ID.Valid() {
+		fmt.Fprintf(&s, "; cluster %s", cluster.ID.HexString())
+	}
+	if r := cluster.Revisions.MostRecent(); r != nil && r.Number.Valid() {
+		fmt.Fprintf(&s, ", revision %s", r.Number.IntString())
+	}
+	return s.String()
+}
@@ -0,0 +1,167 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/project-chip/alchemy/internal"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestFieldSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		field    *matter.Field
+		expected string
+	}{
+		{
+			name:     "nullable integers lose their top value to null",
+			field:    &matter.Field{Type: types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankScalar), Quality: matter.QualityNullable},
+			expected: `{"type":["integer","null"],"minimum":0,"maximum":254}`,
+		},
+		{
+			name:     "null fallback",
+			field:    &matter.Field{Type: types.NewDataType(types.BaseDataTypeUInt16, types.DataTypeRankScalar), Quality: matter.QualityNullable, Fallback: constraint.ParseLimit("null")},
+			expected: `{"type":["integer","null"],"minimum":0,"maximum":65534,"default":null}`,
+		},
+		{
+			name:     "numeric fallback within a constraint",
+			field:    &matter.Field{Type: types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankScalar), Constraint: constraint.ParseString("max 10"), Fallback: constraint.ParseLimit("5")},
+			expected: `{"type":"integer","minimum":0,"maximum":10,"default":5}`,
+		},
+		{
+			name:     "exact constraint",
+			field:    &matter.Field{Type: types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankScalar), Constraint: constraint.ParseString("3")},
+			expected: `{"type":"integer","minimum":0,"maximum":255,"const":3}`,
+		},
+		{
+			name:     "octet strings are measured in hex digits",
+			field:    &matter.Field{Type: types.NewDataType(types.BaseDataTypeOctStr, types.DataTypeRankScalar), Constraint: constraint.ParseString("4 to 16")},
+			expected: `{"type":"string","contentEncoding":"base16","pattern":"^([0-9A-Fa-f]{2})*$","minLength":8,"maxLength":32}`,
+		},
+		{
+			name:     "list and entry constraints",
+			field:    &matter.Field{Type: types.NewDataType(types.BaseDataTypeString, types.DataTypeRankList), Constraint: constraint.ParseString("max 4 [max 8]")},
+			expected: `{"type":"array","items":{"type":"string","maxLength":8},"maxItems":4}`,
+		},
+		{
+			name:     "booleans ignore bounds",
+			field:    &matter.Field{Type: types.NewDataType(types.BaseDataTypeBoolean, types.DataTypeRankScalar), Constraint: constraint.ParseString("all")},
+			expected: `{"type":"boolean"}`,
+		},
+		{
+			name:     "unresolved data types accept anything",
+			field:    &matter.Field{Type: types.NewCustomDataType("MysteryType", types.DataTypeRankScalar)},
+			expected: `{"$comment":"unresolved data type MysteryType"}`,
+		},
+	}
+	for _, test := range tests {
+		g := &generator{cluster: matter.NewCluster(nil), defs: internal.NewJSONMap(), rendered: make(map[types.Entity]struct{})}
+		b, err := json.Marshal(g.fieldSchema(test.field, matter.FieldSet{test.field}))
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if string(b) != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, string(b))
+		}
+	}
+}
+
+// TestRenderClusterDefs checks which entries the schema of a cluster gets in $defs: nullable references become a
+// choice, global data types are pulled in once however often and however indirectly they're referred to, and a
+// fabric-scoped struct gets the FabricIndex it doesn't declare
+func TestRenderClusterDefs(t *testing.T) {
+	cluster := matter.NewCluster(nil)
+	cluster.Name = "Binding"
+	cluster.ID = matter.NewNumber(0x001E)
+
+	status := matter.NewEnum(nil, nil)
+	status.Name = "StatusCodeEnum"
+	status.Values = matter.EnumValueSet{&matter.EnumValue{Name: "Success", Value: matter.NewNumber(0), Conformance: conformance.ParseConformance("M")}}
+	result := matter.NewStruct(nil, nil)
+	result.Name = "ResultStruct"
+	result.Fields = matter.FieldSet{{ID: matter.NewNumber(0), Name: "Status", Type: &types.DataType{BaseType: types.BaseDataTypeCustom, Name: status.Name, Entity: status}, Conformance: conformance.ParseConformance("M")}}
+
+	target := matter.NewStruct(nil, cluster)
+	target.Name = "TargetStruct"
+	target.FabricScoping = matter.FabricScopingScoped
+	target.Fields = matter.FieldSet{
+		{ID: matter.NewNumber(1), Name: "Node", Type: types.NewDataType(types.BaseDataTypeNodeID, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")},
+		{ID: matter.NewNumber(2), Name: "Group", Type: types.NewDataType(types.BaseDataTypeGroupID, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("O")},
+	}
+	cluster.Structs = append(cluster.Structs, target)
+
+	bind := matter.NewCommand(nil, cluster)
+	bind.Name = "Bind"
+	bind.ID = matter.NewNumber(0x00)
+	bind.Direction = matter.InterfaceServer
+	bind.Fields = matter.FieldSet{
+		{ID: matter.NewNumber(0), Name: "Target", Type: &types.DataType{BaseType: types.BaseDataTypeCustom, Name: target.Name, Entity: target}, Quality: matter.QualityNullable, Conformance: conformance.ParseConformance("M")},
+		{ID: matter.NewNumber(1), Name: "Result", Type: &types.DataType{BaseType: types.BaseDataTypeCustom, Name: result.Name, Entity: result}, Conformance: conformance.ParseConformance("M")},
+		{ID: matter.NewNumber(2), Name: "Status", Type: &types.DataType{BaseType: types.BaseDataTypeCustom, Name: status.Name, Entity: status}, Conformance: conformance.ParseConformance("M")},
+		{ID: matter.NewNumber(3), Name: "Legacy", Type: types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("X")},
+	}
+	unbind := matter.NewCommand(nil, cluster)
+	unbind.Name = "Unbind"
+	unbind.ID = matter.NewNumber(0x01)
+	unbind.Conformance = conformance.ParseConformance("X")
+	cluster.Commands = append(cluster.Commands, bind, unbind)
+
+	out, err := RenderCluster(cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema struct {
+		Comment string                     `json:"$comment"`
+		Defs    map[string]json.RawMessage `json:"$defs"`
+	}
+	if err = json.Unmarshal([]byte(out), &schema); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if schema.Comment != "Generated by Alchemy from the Matter specification; cluster 0x001E" {
+		t.Errorf("unexpected comment %q", schema.Comment)
+	}
+	var names []string
+	for name := range schema.Defs {
+		names = append(names, name)
+	}
+	if len(names) != 4 || schema.Defs["StatusCodeEnum"] == nil || schema.Defs["ResultStruct"] == nil || schema.Defs["BindCommand"] == nil || schema.Defs["TargetStruct"] == nil {
+		t.Errorf("expected $defs of TargetStruct, BindCommand, ResultStruct and StatusCodeEnum, got %v", names)
+	}
+
+	var command struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Required   []string                   `json:"required"`
+	}
+	if err = json.Unmarshal(schema.Defs["BindCommand"], &command); err != nil {
+		t.Fatal(err)
+	}
+	var reference bytes.Buffer
+	if err = json.Compact(&reference, command.Properties["Target"]); err != nil {
+		t.Fatal(err)
+	}
+	if s := reference.String(); s != `{"anyOf":[{"$ref":"#/$defs/TargetStruct"},{"type":"null"}]}` {
+		t.Errorf("expected nullable reference to be a choice of the reference or null, got %s", s)
+	}
+	if _, ok := command.Properties["Legacy"]; ok || len(command.Required) != 3 {
+		t.Errorf("expected disallowed field to be left out, got %v required of %s", command.Required, schema.Defs["BindCommand"])
+	}
+
+	var targetStruct struct {
+		Properties map[string]map[string]any `json:"properties"`
+		Required   []string                  `json:"required"`
+	}
+	if err = json.Unmarshal(schema.Defs["TargetStruct"], &targetStruct); err != nil {
+		t.Fatal(err)
+	}
+	if len(targetStruct.Required) != 1 || targetStruct.Required[0] != "Node" {
+		t.Errorf("expected only the mandatory field to be required, got %v", targetStruct.Required)
+	}
+	if fi := targetStruct.Properties["FabricIndex"]; fi["$comment"] != "implicit field of fabric-scoped structs" || fi["type"] != "integer" {
+		t.Errorf("expected implicit FabricIndex, got %v", fi)
+	}
+}
@@ -0,0 +1,92 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// conn reads and writes JSON-RPC messages framed with Content-Length headers, as used by LSP over stdio
+type conn struct {
+	r *bufio.Reader
+
+	lock sync.Mutex
+	w    io.Writer
+}
+
+func newConn(r io.Reader, w io.Writer) *conn {
+	return &conn{r: bufio.NewReader(r), w: w}
+}
+
+func (c *conn) read() (req *request, err error) {
+	contentLength := -1
+	for {
+		var line string
+		line, err = c.r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header \"%s\"", line)
+		}
+		if strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			contentLength, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length \"%s\": %w", value, err)
+			}
+		}
+	}
+	if contentLength < 0 {
+		return nil, fmt.Errorf("missing Content-Length header")
+	}
+	body := make([]byte, contentLength)
+	_, err = io.ReadFull(c.r, body)
+	if err != nil {
+		return
+	}
+	req = &request{}
+	err = json.Unmarshal(body, req)
+	if err != nil {
+		err = &responseError{Code: codeParseError, Message: err.Error()}
+	}
+	return
+}
+
+func (c *conn) write(message any) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, err = fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n", len(body))
+	if err != nil {
+		return err
+	}
+	_, err = c.w.Write(body)
+	return err
+}
+
+func (c *conn) reply(id *json.RawMessage, result any, err error) error {
+	if err != nil {
+		re, ok := err.(*responseError)
+		if !ok {
+			re = &responseError{Code: codeRequestFailed, Message: err.Error()}
+		}
+		return c.write(errorResponse{JSONRPC: "2.0", ID: id, Error: re})
+	}
+	return c.write(response{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (c *conn) notify(method string, params any) error {
+	return c.write(notification{JSONRPC: "2.0", Method: method, Params: params})
+}
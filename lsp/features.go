@@ -0,0 +1,252 @@
+package lsp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+var (
+	crossReferencePattern = regexp.MustCompile(`<<([^>,\s]+)(?:,[^>]*)?>>|xref:([^\[\s]+)\[[^\]]*\]`)
+	anchorPattern         = regexp.MustCompile(`\[\[([^\],\s]+)(?:,[^\]]*)?\]\]|\[#([^\],.\s]+)[^\]]*\]|anchor:([^\[\s]+)\[[^\]]*\]`)
+)
+
+// referenceAt returns the ID of the cross reference or anchor under the cursor, if any
+func referenceAt(line string, character int) (id string, isAnchor bool) {
+	if id = matchAt(crossReferencePattern, line, character); id != "" {
+		return id, false
+	}
+	if id = matchAt(anchorPattern, line, character); id != "" {
+		return id, true
+	}
+	return "", false
+}
+
+func matchAt(pattern *regexp.Regexp, line string, character int) string {
+	offset, ok := byteOffset(line, character)
+	if !ok {
+		return ""
+	}
+	for _, match := range pattern.FindAllStringSubmatchIndex(line, -1) {
+		if offset < match[0] || offset >= match[1] {
+			continue
+		}
+		for i := 2; i+1 < len(match); i += 2 {
+			if match[i] >= 0 {
+				return line[match[i]:match[i+1]]
+			}
+		}
+	}
+	return ""
+}
+
+// wordAt returns the identifier under the cursor
+func wordAt(line string, character int) string {
+	offset, ok := byteOffset(line, character)
+	if !ok {
+		return ""
+	}
+	isWordRune := func(r rune) bool {
+		return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	start := offset
+	for start > 0 {
+		r, size := utf8.DecodeLastRuneInString(line[:start])
+		if !isWordRune(r) {
+			break
+		}
+		start -= size
+	}
+	end := offset
+	for end < len(line) {
+		r, size := utf8.DecodeRuneInString(line[end:])
+		if !isWordRune(r) {
+			break
+		}
+		end += size
+	}
+	return line[start:end]
+}
+
+// LSP positions count characters in UTF-16 code units, while the AsciiDoc parser counts columns in runes
+
+// byteOffset converts a character offset in UTF-16 code units to a byte offset in line; it returns false if the
+// offset is past the end of the line
+func byteOffset(line string, character int) (int, bool) {
+	units := 0
+	for i, r := range line {
+		if units >= character {
+			return i, true
+		}
+		units += utf16.RuneLen(r)
+	}
+	return len(line), units >= character
+}
+
+// utf16Column converts a zero-based column in runes to UTF-16 code units
+func utf16Column(line string, column int) (units int) {
+	for _, r := range line {
+		if column <= 0 {
+			break
+		}
+		units += utf16.RuneLen(r)
+		column--
+	}
+	return units + column
+}
+
+func (s *Server) definition(w *workspace, path string, position Position) (locations []Location) {
+	line := s.line(path, position.Line)
+	if id, isAnchor := referenceAt(line, position.Character); id != "" {
+		if isAnchor {
+			return nil
+		}
+		for _, library := range w.libraries(path) {
+			for _, anchor := range library.FindAnchors(id) {
+				if location, ok := s.elementLocation(anchor.Element); ok {
+					locations = append(locations, location)
+				}
+			}
+		}
+		return dedupeLocations(locations)
+	}
+	word := wordAt(line, position.Character)
+	if word == "" {
+		return nil
+	}
+	for _, entity := range w.entitiesByName[word] {
+		if location, ok := s.entityLocation(w, entity); ok {
+			locations = append(locations, location)
+		}
+	}
+	return dedupeLocations(locations)
+}
+
+func (s *Server) references(w *workspace, path string, params ReferenceParams) (locations []Location) {
+	line := s.line(path, params.Position.Line)
+	id, _ := referenceAt(line, params.Position.Character)
+	if id == "" {
+		return nil
+	}
+	for _, library := range w.libraries(path) {
+		if params.Context.IncludeDeclaration {
+			for _, anchor := range library.FindAnchors(id) {
+				if location, ok := s.elementLocation(anchor.Element); ok {
+					locations = append(locations, location)
+				}
+			}
+		}
+		for _, reference := range library.CrossReferences(id) {
+			if location, ok := s.elementLocation(reference.Reference); ok {
+				locations = append(locations, location)
+			}
+		}
+	}
+	return dedupeLocations(locations)
+}
+
+func (s *Server) hover(w *workspace, path string, position Position) *Hover {
+	var entities []types.Entity
+	if lines, ok := w.entitiesByLine[path]; ok {
+		entities = append(entities, lines[position.Line+1]...)
+	}
+	if len(entities) == 0 {
+		entities = w.entitiesByName[wordAt(s.line(path, position.Line), position.Character)]
+	}
+	if len(entities) == 0 {
+		return nil
+	}
+	var value strings.Builder
+	for _, entity := range entities {
+		if value.Len() > 0 {
+			value.WriteString("\n\n---\n\n")
+		}
+		writeEntityHover(&value, entity)
+	}
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: value.String()}}
+}
+
+func writeEntityHover(value *strings.Builder, entity types.Entity) {
+	fmt.Fprintf(value, "**%s** (%s)", entityName(entity), entity.EntityType().String())
+	if id := entityID(entity); id.Valid() {
+		fmt.Fprintf(value, " %s", id.HexString())
+	}
+	if hc, ok := entity.(conformance.HasConformance); ok {
+		if cs := hc.GetConformance(); len(cs) > 0 {
+			fmt.Fprintf(value, "\n\nConformance `%s`: %s", cs.ASCIIDocString(), cs.Description())
+		}
+	}
+}
+
+func entityName(entity types.Entity) string {
+	switch entity := entity.(type) {
+	case *matter.ClusterRequirement:
+		return entity.ClusterName
+	case *matter.ElementRequirement:
+		if entity.Field != "" {
+			return entity.ClusterName + "." + entity.Name + "." + entity.Field
+		}
+		return entity.ClusterName + "." + entity.Name
+	case *matter.DeviceTypeRequirement:
+		return entity.DeviceTypeName
+	}
+	return matter.EntityName(entity)
+}
+
+func entityID(entity types.Entity) *matter.Number {
+	switch entity.(type) {
+	case *matter.Cluster, *matter.Field, *matter.Event, *matter.Command, *matter.Namespace, *matter.SemanticTag, *matter.DeviceType:
+		return matter.EntityID(entity)
+	}
+	return nil
+}
+
+func (s *Server) entityLocation(w *workspace, entity types.Entity) (Location, bool) {
+	if location, ok := s.elementLocation(entity.Source()); ok {
+		return location, true
+	}
+	path, line := entity.Origin()
+	if path == "" || line <= 0 {
+		return Location{}, false
+	}
+	return Location{URI: pathToURI(w.absolutePath(path)), Range: lineRange(line - 1)}, true
+}
+
+func (s *Server) elementLocation(element any) (Location, bool) {
+	hp, ok := element.(asciidoc.HasPosition)
+	if !ok || hp.Document() == nil {
+		return Location{}, false
+	}
+	line, column, _ := hp.Position()
+	if line <= 0 {
+		return Location{}, false
+	}
+	path := hp.Document().Path.Absolute
+	start := Position{Line: line - 1, Character: utf16Column(s.line(path, line-1), max(column-1, 0))}
+	return Location{URI: pathToURI(path), Range: Range{Start: start, End: start}}, true
+}
+
+func lineRange(line int) Range {
+	return Range{Start: Position{Line: line}, End: Position{Line: line + 1}}
+}
+
+func dedupeLocations(locations []Location) []Location {
+	seen := make(map[Location]struct{}, len(locations))
+	deduped := make([]Location, 0, len(locations))
+	for _, l := range locations {
+		if _, ok := seen[l]; ok {
+			continue
+		}
+		seen[l] = struct{}{}
+		deduped = append(deduped, l)
+	}
+	return deduped
+}
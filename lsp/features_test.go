@@ -0,0 +1,69 @@
+package lsp
+
+import "testing"
+
+func TestReferenceAt(t *testing.T) {
+	tests := []struct {
+		line      string
+		character int
+		id        string
+		isAnchor  bool
+	}{
+		{line: "See <<ref_ModeEnum>> for details", character: 6, id: "ref_ModeEnum"},
+		{line: "See <<ref_ModeEnum, the enum>> for details", character: 22, id: "ref_ModeEnum"},
+		{line: "See <<ref_ModeEnum>> for details", character: 2},
+		{line: "See xref:ref_ModeEnum[the enum]", character: 10, id: "ref_ModeEnum"},
+		{line: "[[ref_ModeEnum, ModeEnum]]", character: 3, id: "ref_ModeEnum", isAnchor: true},
+		{line: "[#ref_ModeEnum]", character: 3, id: "ref_ModeEnum", isAnchor: true},
+		{line: "| 0x0000 | Mode | <<ref_ModeEnum>> | all", character: 20, id: "ref_ModeEnum"},
+		// Characters are counted in UTF-16 code units, so the emoji counts twice and the accented letter once
+		{line: "😀 é <<ref_ModeEnum>>", character: 5, id: "ref_ModeEnum"},
+		{line: "😀 é <<ref_ModeEnum>>", character: 4},
+		{line: "😀 é <<ref_ModeEnum>>", character: 21},
+	}
+	for _, test := range tests {
+		id, isAnchor := referenceAt(test.line, test.character)
+		if id != test.id || isAnchor != test.isAnchor {
+			t.Errorf("referenceAt(%q, %d) = %q, %v; expected %q, %v", test.line, test.character, id, isAnchor, test.id, test.isAnchor)
+		}
+	}
+}
+
+func TestWordAt(t *testing.T) {
+	tests := []struct {
+		line      string
+		character int
+		word      string
+	}{
+		{line: "| 0x0001 | Other | ModeEnum | all", character: 22, word: "ModeEnum"},
+		{line: "| 0x0001 | Other | ModeEnum | all", character: 19, word: "ModeEnum"},
+		{line: "| 0x0001 | Other | ModeEnum | all", character: 18, word: ""},
+		{line: "short", character: 10, word: ""},
+		{line: "short", character: 5, word: "short"},
+		{line: "| 😀 | Modé | ModeEnum", character: 16, word: "ModeEnum"},
+		{line: "| 😀 | Modé | ModeEnum", character: 7, word: "Modé"},
+	}
+	for _, test := range tests {
+		if word := wordAt(test.line, test.character); word != test.word {
+			t.Errorf("wordAt(%q, %d) = %q; expected %q", test.line, test.character, word, test.word)
+		}
+	}
+}
+
+func TestUTF16Column(t *testing.T) {
+	tests := []struct {
+		line   string
+		column int
+		units  int
+	}{
+		{line: "<<ref_ModeEnum>>", column: 2, units: 2},
+		{line: "😀 <<ref_ModeEnum>>", column: 2, units: 3},
+		{line: "é <<ref_ModeEnum>>", column: 2, units: 2},
+		{line: "😀", column: 3, units: 4},
+	}
+	for _, test := range tests {
+		if units := utf16Column(test.line, test.column); units != test.units {
+			t.Errorf("utf16Column(%q, %d) = %d; expected %d", test.line, test.column, units, test.units)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+type Options struct {
+	ParserOptions spec.ParserOptions
+	Attributes    []asciidoc.AttributeName
+
+	// How long to wait after the last edit before rebuilding the spec
+	Debounce time.Duration
+}
+
+// Server answers LSP requests against the most recent build of the spec, rebuilding it in the background
+// as documents are edited
+type Server struct {
+	options Options
+	conn    *conn
+
+	lock      sync.RWMutex
+	buffers   map[string]string
+	workspace *workspace
+	published map[string]struct{}
+
+	rebuild  chan struct{}
+	shutdown bool
+}
+
+// Run serves LSP over r and w until the client sends exit, or r is closed
+func Run(cxt context.Context, r io.Reader, w io.Writer, options Options) error {
+	s := &Server{
+		options:   options,
+		conn:      newConn(r, w),
+		buffers:   make(map[string]string),
+		published: make(map[string]struct{}),
+		rebuild:   make(chan struct{}, 1),
+	}
+	cxt, cancel := context.WithCancel(cxt)
+	defer cancel()
+	go s.build(cxt)
+	return s.serve(cxt)
+}
+
+func (s *Server) serve(cxt context.Context) error {
+	for {
+		req, err := s.conn.read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		var re *responseError
+		if errors.As(err, &re) {
+			if writeErr := s.conn.reply(nil, nil, re); writeErr != nil {
+				return writeErr
+			}
+			continue
+		} else if err != nil {
+			return err
+		}
+		if req.Method == "exit" {
+			s.lock.RLock()
+			shutdown := s.shutdown
+			s.lock.RUnlock()
+			if !shutdown {
+				return errors.New("exit received before shutdown")
+			}
+			return nil
+		}
+		result, err := s.handle(cxt, req)
+		if req.ID == nil {
+			if err != nil {
+				slog.WarnContext(cxt, "Error handling notification", "method", req.Method, "error", err)
+			}
+			continue
+		}
+		err = s.conn.reply(req.ID, result, err)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(cxt context.Context, req *request) (result any, err error) {
+	switch req.Method {
+	case "initialize":
+		return InitializeResult{
+			Capabilities: ServerCapabilities{
+				// Positions are always converted to and from UTF-16 code units, the encoding every client supports
+				PositionEncoding:   "utf-16",
+				TextDocumentSync:   TextDocumentSyncOptions{OpenClose: true, Change: TextDocumentSyncKindFull, Save: true},
+				DefinitionProvider: true,
+				ReferencesProvider: true,
+				HoverProvider:      true,
+			},
+			ServerInfo: ServerInfo{Name: "alchemy"},
+		}, nil
+	case "initialized":
+		s.requestRebuild()
+		return nil, nil
+	case "shutdown":
+		s.lock.Lock()
+		s.shutdown = true
+		s.lock.Unlock()
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err = unmarshalParams(req, &params); err != nil {
+			return
+		}
+		s.setBuffer(uriToPath(params.TextDocument.URI), params.TextDocument.Text)
+		return nil, nil
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err = unmarshalParams(req, &params); err != nil {
+			return
+		}
+		// We only offer full document sync, so the last change holds the whole document
+		if len(params.ContentChanges) > 0 {
+			s.setBuffer(uriToPath(params.TextDocument.URI), params.ContentChanges[len(params.ContentChanges)-1].Text)
+		}
+		return nil, nil
+	case "textDocument/didSave":
+		s.requestRebuild()
+		return nil, nil
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err = unmarshalParams(req, &params); err != nil {
+			return
+		}
+		s.lock.Lock()
+		delete(s.buffers, uriToPath(params.TextDocument.URI))
+		s.lock.Unlock()
+		return nil, nil
+	case "textDocument/definition":
+		var params TextDocumentPositionParams
+		if err = unmarshalParams(req, &params); err != nil {
+			return
+		}
+		w := s.currentWorkspace()
+		if w == nil {
+			return nil, nil
+		}
+		return s.definition(w, uriToPath(params.TextDocument.URI), params.Position), nil
+	case "textDocument/references":
+		var params ReferenceParams
+		if err = unmarshalParams(req, &params); err != nil {
+			return
+		}
+		w := s.currentWorkspace()
+		if w == nil {
+			return nil, nil
+		}
+		return s.references(w, uriToPath(params.TextDocument.URI), params), nil
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		if err = unmarshalParams(req, &params); err != nil {
+			return
+		}
+		w := s.currentWorkspace()
+		if w == nil {
+			return nil, nil
+		}
+		hover := s.hover(w, uriToPath(params.TextDocument.URI), params.Position)
+		if hover == nil {
+			return nil, nil
+		}
+		return hover, nil
+	default:
+		if strings.HasPrefix(req.Method, "$/") {
+			// Protocol notifications may be ignored
+			return nil, nil
+		}
+		return nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+func unmarshalParams(req *request, params any) error {
+	err := json.Unmarshal(req.Params, params)
+	if err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) setBuffer(path string, text string) {
+	s.lock.Lock()
+	s.buffers[path] = text
+	s.lock.Unlock()
+	s.requestRebuild()
+}
+
+func (s *Server) currentWorkspace() *workspace {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.workspace
+}
+
+// line returns a line of a document, from the editor's buffer if it is open, or else from disk
+func (s *Server) line(path string, line int) string {
+	s.lock.RLock()
+	text, ok := s.buffers[path]
+	s.lock.RUnlock()
+	if !ok {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return ""
+		}
+		text = string(b)
+	}
+	lines := strings.Split(text, "\n")
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+	return strings.TrimRight(lines[line], "\r")
+}
+
+func (s *Server) requestRebuild() {
+	select {
+	case s.rebuild <- struct{}{}:
+	default:
+	}
+}
+
+// build rebuilds the spec whenever a rebuild is requested and no further edits arrive within the debounce interval
+func (s *Server) build(cxt context.Context) {
+	for {
+		select {
+		case <-cxt.Done():
+			return
+		case <-s.rebuild:
+		}
+		timer := time.NewTimer(s.options.Debounce)
+	debounce:
+		for {
+			select {
+			case <-cxt.Done():
+				timer.Stop()
+				return
+			case <-s.rebuild:
+				timer.Reset(s.options.Debounce)
+			case <-timer.C:
+				break debounce
+			}
+		}
+
+		s.lock.RLock()
+		buffers := make(map[string]string, len(s.buffers))
+		for path, text := range s.buffers {
+			buffers[path] = text
+		}
+		s.lock.RUnlock()
+
+		slog.InfoContext(cxt, "Building spec", "root", s.options.ParserOptions.Root)
+		w, err := buildWorkspace(cxt, s.options, buffers)
+		if err != nil {
+			slog.ErrorContext(cxt, "Error building spec", "error", err)
+			continue
+		}
+		s.lock.Lock()
+		s.workspace = w
+		s.lock.Unlock()
+		s.publishDiagnostics(w)
+	}
+}
+
+func (s *Server) publishDiagnostics(w *workspace) {
+	published := make(map[string]struct{}, len(w.diagnostics))
+	for path, diagnostics := range w.diagnostics {
+		s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: pathToURI(path), Diagnostics: diagnostics})
+		published[path] = struct{}{}
+	}
+	// Clear the diagnostics of any document which no longer has errors
+	for path := range s.published {
+		if _, ok := published[path]; !ok {
+			s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: pathToURI(path), Diagnostics: []Diagnostic{}})
+		}
+	}
+	s.published = published
+}
+
+func (s *Server) notify(method string, params any) {
+	err := s.conn.notify(method, params)
+	if err != nil {
+		slog.Error("Error sending notification", "method", method, "error", err)
+	}
+}
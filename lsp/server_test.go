@@ -0,0 +1,181 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+// testClient talks to a server over the same framing as an editor would
+type testClient struct {
+	t         *testing.T
+	conn      *conn
+	nextID    int
+	responses chan json.RawMessage
+}
+
+func newTestClient(t *testing.T, options Options) (*testClient, chan error) {
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- Run(context.Background(), serverReader, serverWriter, options)
+		serverWriter.Close()
+	}()
+	c := &testClient{t: t, conn: newConn(clientReader, clientWriter), responses: make(chan json.RawMessage, 1)}
+	go c.readResponses(bufio.NewReader(clientReader))
+	return c, done
+}
+
+// readResponses passes on responses, and drops notifications such as published diagnostics
+func (c *testClient) readResponses(r *bufio.Reader) {
+	defer close(c.responses)
+	for {
+		var contentLength int
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimSpace(line)
+			if line == "" {
+				break
+			}
+			fmt.Sscanf(line, "Content-Length: %d", &contentLength)
+		}
+		body := make([]byte, contentLength)
+		if _, err := io.ReadFull(r, body); err != nil {
+			return
+		}
+		var message struct {
+			ID *json.RawMessage `json:"id"`
+		}
+		if json.Unmarshal(body, &message) == nil && message.ID != nil {
+			c.responses <- body
+		}
+	}
+}
+
+func (c *testClient) notify(method string, params any) {
+	c.t.Helper()
+	if err := c.conn.notify(method, params); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *testClient) request(method string, params any, result any) {
+	c.t.Helper()
+	c.nextID++
+	err := c.conn.write(map[string]any{"jsonrpc": "2.0", "id": c.nextID, "method": method, "params": params})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	var body json.RawMessage
+	select {
+	case body = <-c.responses:
+	case <-time.After(30 * time.Second):
+		c.t.Fatalf("timed out waiting for %s response", method)
+	}
+	var response struct {
+		ID     int             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *responseError  `json:"error"`
+	}
+	if err = json.Unmarshal(body, &response); err != nil {
+		c.t.Fatal(err)
+	}
+	if response.ID != c.nextID {
+		c.t.Fatalf("expected response to request %d, got %d", c.nextID, response.ID)
+	}
+	if response.Error != nil {
+		c.t.Fatalf("%s failed: %s", method, response.Error.Message)
+	}
+	if result != nil {
+		if err = json.Unmarshal(response.Result, result); err != nil {
+			c.t.Fatal(err)
+		}
+	}
+}
+
+func TestServer(t *testing.T) {
+	root, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(root, "src", "app_clusters", "SampleCluster.adoc")
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(b), "\n")
+	var referenceLine, anchorLine, attributeLine int
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "[[ref_ModeEnum]]"):
+			anchorLine = i
+		case strings.Contains(line, "<<ref_ModeEnum>>"):
+			referenceLine = i
+		case strings.HasPrefix(line, "| 0x0000 | CurrentMode"):
+			attributeLine = i
+		}
+	}
+	// The line starts with characters outside the Basic Multilingual Plane, which take two UTF-16 code units each
+	before, _, _ := strings.Cut(lines[referenceLine], "<<")
+	character := len(utf16.Encode([]rune(before))) + 2
+
+	client, done := newTestClient(t, Options{ParserOptions: spec.ParserOptions{Root: root}})
+
+	var initialize InitializeResult
+	client.request("initialize", InitializeParams{RootURI: pathToURI(root)}, &initialize)
+	if !initialize.Capabilities.DefinitionProvider || initialize.Capabilities.PositionEncoding != "utf-16" {
+		t.Errorf("unexpected capabilities: %+v", initialize.Capabilities)
+	}
+	client.notify("initialized", struct{}{})
+
+	params := TextDocumentPositionParams{TextDocument: TextDocumentIdentifier{URI: pathToURI(path)}, Position: Position{Line: referenceLine, Character: character}}
+	var locations []Location
+	// The spec is built in the background, so there are no results until it has been built
+	for deadline := time.Now().Add(30 * time.Second); len(locations) == 0 && time.Now().Before(deadline); {
+		client.request("textDocument/definition", params, &locations)
+		if len(locations) == 0 {
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+	if len(locations) != 1 {
+		t.Fatalf("expected one definition of ref_ModeEnum, got %v", locations)
+	}
+	if locations[0].URI != pathToURI(path) || locations[0].Range.Start.Line != anchorLine {
+		t.Errorf("expected ref_ModeEnum to be defined at line %d of %s, got %+v", anchorLine, pathToURI(path), locations[0])
+	}
+
+	// Just before the reference, in UTF-16 code units, is outside of it
+	params.Position.Character = character - 3
+	locations = nil
+	client.request("textDocument/definition", params, &locations)
+	if len(locations) != 0 {
+		t.Errorf("expected no definition before the reference, got %v", locations)
+	}
+
+	var hover *Hover
+	params.Position = Position{Line: attributeLine, Character: 12}
+	client.request("textDocument/hover", params, &hover)
+	if hover == nil || !strings.Contains(hover.Contents.Value, "**CurrentMode** (attribute) 0x0000") {
+		t.Errorf("expected hover for CurrentMode, got %+v", hover)
+	}
+
+	client.request("shutdown", nil, nil)
+	client.notify("exit", nil)
+	if err = <-done; err != nil {
+		t.Errorf("expected the server to exit cleanly, got %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package lsp
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/asciidoc/parse"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+	"github.com/project-chip/alchemy/validate"
+)
+
+// workspace is a snapshot of the spec, built from the files on disk overlaid with the editor's open buffers
+type workspace struct {
+	root string
+	spec *spec.Specification
+
+	// Entities by the absolute path and line of their source
+	entitiesByLine map[string]map[int][]types.Entity
+	// Data types, clusters and device types by name
+	entitiesByName map[string][]types.Entity
+
+	diagnostics map[string][]Diagnostic
+}
+
+func buildWorkspace(cxt context.Context, options Options, buffers map[string]string) (w *workspace, err error) {
+	processingOptions := pipeline.ProcessingOptions{NoProgress: true}
+
+	var specDocs spec.DocSet
+	specDocs, err = spec.LoadSpecDocs(cxt, options.ParserOptions, processingOptions)
+	if err != nil {
+		return
+	}
+	parseDiagnostics := make(map[string][]Diagnostic)
+	for path, text := range buffers {
+		if _, ok := specDocs.Load(path); !ok {
+			continue
+		}
+		var p asciidoc.Path
+		p, err = spec.NewSpecPath(path, options.ParserOptions.Root)
+		if err != nil {
+			return
+		}
+		var doc *asciidoc.Document
+		doc, err = parse.Raw(p, []byte(text))
+		if err != nil {
+			// Leave the last saved version of the document in place until the buffer parses again
+			parseDiagnostics[path] = []Diagnostic{parseDiagnostic(text, err)}
+			err = nil
+			continue
+		}
+		specDocs.Store(path, pipeline.NewData(path, doc))
+	}
+
+	var specification *spec.Specification
+	specification, _, err = spec.Build(cxt, options.ParserOptions, processingOptions, nil, specDocs, options.Attributes)
+	if err != nil {
+		return
+	}
+	spec.Validate(specification)
+
+	w = &workspace{
+		root:           options.ParserOptions.Root,
+		spec:           specification,
+		entitiesByLine: make(map[string]map[int][]types.Entity),
+		entitiesByName: make(map[string][]types.Entity),
+		diagnostics:    make(map[string][]Diagnostic),
+	}
+	w.indexEntities()
+	w.indexDiagnostics()
+	for path, diagnostics := range parseDiagnostics {
+		w.diagnostics[path] = append(diagnostics, w.diagnostics[path]...)
+	}
+	return
+}
+
+// parseDiagnostic reports an error parsing an open buffer at the position the parser stopped, or at the top of the
+// document if the parser did not say where
+func parseDiagnostic(text string, err error) Diagnostic {
+	var start Position
+	if line, column, ok := parse.ErrorPosition(err); ok && line > 0 {
+		lines := strings.Split(text, "\n")
+		if line <= len(lines) {
+			start = Position{Line: line - 1, Character: utf16Column(lines[line-1], max(column-1, 0))}
+		}
+	}
+	return Diagnostic{
+		Range:    Range{Start: start, End: Position{Line: start.Line + 1}},
+		Severity: DiagnosticSeverityError,
+		Source:   "alchemy",
+		Message:  err.Error(),
+	}
+}
+
+func (w *workspace) indexEntities() {
+	spec.TraverseEntities(w.spec, func(parentCluster *matter.Cluster, parent types.Entity, entity types.Entity) parse.SearchShould {
+		w.addEntity(entity)
+		return parse.SearchShouldContinue
+	})
+	for _, dt := range w.spec.DeviceTypes {
+		w.addEntity(dt)
+		for _, cr := range dt.ClusterRequirements {
+			w.addEntity(cr)
+		}
+		for _, er := range dt.ElementRequirements {
+			w.addEntity(er)
+		}
+	}
+	for _, ns := range w.spec.Namespaces {
+		w.addEntity(ns)
+	}
+}
+
+func (w *workspace) addEntity(entity types.Entity) {
+	path, line := entity.Origin()
+	if path != "" && line > 0 {
+		path = w.absolutePath(path)
+		lines, ok := w.entitiesByLine[path]
+		if !ok {
+			lines = make(map[int][]types.Entity)
+			w.entitiesByLine[path] = lines
+		}
+		lines[line] = append(lines[line], entity)
+	}
+	switch entity := entity.(type) {
+	case *matter.Cluster:
+		w.entitiesByName[entity.Name] = append(w.entitiesByName[entity.Name], entity)
+	case *matter.DeviceType:
+		w.entitiesByName[entity.Name] = append(w.entitiesByName[entity.Name], entity)
+	case *matter.Bitmap:
+		w.entitiesByName[entity.Name] = append(w.entitiesByName[entity.Name], entity)
+	case *matter.Enum:
+		w.entitiesByName[entity.Name] = append(w.entitiesByName[entity.Name], entity)
+	case *matter.Struct:
+		w.entitiesByName[entity.Name] = append(w.entitiesByName[entity.Name], entity)
+	case *matter.TypeDef:
+		w.entitiesByName[entity.Name] = append(w.entitiesByName[entity.Name], entity)
+	}
+}
+
+func (w *workspace) indexDiagnostics() {
+	report := validate.NewReport(w.spec)
+	for _, f := range report.Findings {
+		if f.Path == "" {
+			continue
+		}
+		path := w.absolutePath(filepath.FromSlash(f.Path))
+		line := max(f.Line-1, 0)
+		w.diagnostics[path] = append(w.diagnostics[path], Diagnostic{
+			Range:    lineRange(line),
+			Severity: DiagnosticSeverityError,
+			Code:     f.RuleID,
+			Source:   "alchemy",
+			Message:  f.Message,
+		})
+	}
+}
+
+func (w *workspace) absolutePath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(w.root, path)
+}
+
+// libraries returns the libraries which include the document at path, or every library if none do
+func (w *workspace) libraries(path string) (libraries []*spec.Library) {
+	for _, library := range w.spec.Libraries {
+		for _, doc := range library.Docs {
+			if doc.Path.Absolute == path {
+				libraries = append(libraries, library)
+				break
+			}
+		}
+	}
+	if len(libraries) == 0 {
+		libraries = w.spec.Libraries
+	}
+	return
+}
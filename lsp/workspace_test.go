@@ -0,0 +1,54 @@
+package lsp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+// TestBufferParseError checks that a buffer which doesn't parse is reported where the parser stopped, and that the
+// saved copy of the document is built in its place
+func TestBufferParseError(t *testing.T) {
+	root, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(root, "src", "app_clusters", "SampleCluster.adoc")
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(string(b), "\n")
+	var line int
+	for i, l := range lines {
+		if strings.Contains(l, "<<ref_ModeEnum>>") {
+			line = i
+			break
+		}
+	}
+	// The reference line starts with characters outside the Basic Multilingual Plane, so the column of the invalid
+	// byte is further along in UTF-16 code units than in runes
+	before, after, _ := strings.Cut(lines[line], "<<")
+	lines[line] = before + "\xff<<" + after
+	buffers := map[string]string{path: strings.Join(lines, "\n")}
+
+	w, err := buildWorkspace(context.Background(), Options{ParserOptions: spec.ParserOptions{Root: root}}, buffers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diagnostics := w.diagnostics[path]
+	if len(diagnostics) == 0 {
+		t.Fatalf("expected a diagnostic for the buffer which failed to parse")
+	}
+	start := Position{Line: line, Character: utf16Column(before, len([]rune(before)))}
+	if diagnostics[0].Range.Start != start || diagnostics[0].Severity != DiagnosticSeverityError {
+		t.Errorf("expected the parse error to be reported at %+v, got %+v", start, diagnostics[0])
+	}
+	if len(w.entitiesByLine[path]) == 0 {
+		t.Errorf("expected the saved copy of %s to be built in place of the buffer", path)
+	}
+}
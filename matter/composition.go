@@ -1,6 +1,7 @@
 package matter
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/project-chip/alchemy/asciidoc"
@@ -48,6 +49,10 @@ func (s RequirementOrigin) Compare(oro RequirementOrigin) int {
 	return 0
 }
 
+func (s RequirementOrigin) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
 func (s RequirementOrigin) String() string {
 	str, ok := requirementOrigins[s]
 	if ok {
@@ -151,16 +156,21 @@ type DeviceTypeTagRequirement struct {
 	DeviceTypeID   *Number `json:"deviceTypeId,omitempty"`
 	DeviceTypeName string  `json:"deviceTypeName,omitempty"`
 
+	// RequirementOrigin is named to avoid shadowing entity.Origin, which tag requirements are logged with
+	RequirementOrigin RequirementOrigin
+
 	DeviceType            *DeviceType `json:"deviceType,omitempty"`
 	DeviceTypeRequirement *DeviceTypeRequirement
 }
 
 func (dtcr *DeviceTypeTagRequirement) Clone() *DeviceTypeTagRequirement {
 	return &DeviceTypeTagRequirement{
-		DeviceTypeID:   dtcr.DeviceTypeID,
-		DeviceTypeName: dtcr.DeviceTypeName,
-		DeviceType:     dtcr.DeviceType,
-		TagRequirement: dtcr.TagRequirement,
+		DeviceTypeID:          dtcr.DeviceTypeID,
+		DeviceTypeName:        dtcr.DeviceTypeName,
+		DeviceType:            dtcr.DeviceType,
+		TagRequirement:        dtcr.TagRequirement,
+		RequirementOrigin:     dtcr.RequirementOrigin,
+		DeviceTypeRequirement: dtcr.DeviceTypeRequirement,
 	}
 }
 
@@ -45,6 +45,9 @@ func (ie *IdentifierExpression) Eval(context Context) (ExpressionResult, error)
 			return &expressionResult{value: true, confidence: v}, nil
 		case ExpressionResult:
 			return v, nil
+		case int64, uint64, float64:
+			// An identifier with a value, e.g. an attribute, is present
+			return &expressionResult{value: !ie.Not, confidence: ConfidenceDefinite}, nil
 		default:
 			return nil, fmt.Errorf("unexpected context value type: %T", v)
 		}
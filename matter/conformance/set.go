@@ -111,7 +111,7 @@ func (cs Set) Equal(c Conformance) bool {
 		return false
 	}
 	for i, c := range cs {
-		oc := cs[i]
+		oc := ocs[i]
 		if !oc.Equal(c) {
 			return false
 		}
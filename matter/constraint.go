@@ -11,6 +11,9 @@ import (
 type ConstraintContext struct {
 	Field  *Field
 	Fields FieldSet
+	// Values holds the values of fields when a concrete value is being validated; references to a field with a value
+	// resolve to that value rather than to the bounds of the field's constraint
+	Values map[*Field]any
 
 	visitedMinReferences map[types.Entity]struct{}
 	visitedMaxReferences map[types.Entity]struct{}
@@ -31,6 +34,7 @@ func (cc *ConstraintContext) Child(field *Field, fields FieldSet) *ConstraintCon
 	return &ConstraintContext{
 		Field:                field,
 		Fields:               cc.Fields,
+		Values:               cc.Values,
 		parent:               cc,
 		visitedMinReferences: make(map[types.Entity]struct{}),
 		visitedMaxReferences: make(map[types.Entity]struct{}),
@@ -55,6 +59,10 @@ func (cc *ConstraintContext) MinEntityValue(entity types.Entity, field constrain
 
 	switch entity := entity.(type) {
 	case *Field:
+		if v, ok := cc.value(entity); ok {
+			min = v
+			return
+		}
 		child := cc.Child(entity, cc.Fields)
 		if child.recordMinReference(entity) {
 			slog.Error("Circular reference detected", LogEntity("entity", entity))
@@ -84,6 +92,10 @@ func (cc *ConstraintContext) MaxEntityValue(entity types.Entity, field constrain
 
 	switch entity := entity.(type) {
 	case *Field:
+		if v, ok := cc.value(entity); ok {
+			max = v
+			return
+		}
 		child := cc.Child(entity, cc.Fields)
 		if child.recordMaxReference(entity) {
 			slog.Error("Circular reference detected", LogEntity("entity", entity))
@@ -110,6 +122,14 @@ func (cc *ConstraintContext) MaxEntityValue(entity types.Entity, field constrain
 	return
 }
 
+func (cc *ConstraintContext) value(field *Field) (types.DataTypeExtreme, bool) {
+	value, ok := cc.Values[field]
+	if !ok || value == nil {
+		return types.DataTypeExtreme{}, false
+	}
+	return constraint.ValueExtreme(value)
+}
+
 func (cc *ConstraintContext) recordMinReference(entity types.Entity) (previouslyVisited bool) {
 	c := cc
 	for {
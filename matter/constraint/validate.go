@@ -0,0 +1,266 @@
+package constraint
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type ViolationType uint8
+
+const (
+	ViolationTypeUnknown ViolationType = iota
+	ViolationTypeNull
+	ViolationTypeDataType
+	ViolationTypeBelowMinimum
+	ViolationTypeAboveMaximum
+	ViolationTypeNotAllowed
+	ViolationTypeTooShort
+	ViolationTypeTooLong
+	ViolationTypeUnknownField
+	ViolationTypeMissingField
+)
+
+var violationTypeNames = map[ViolationType]string{
+	ViolationTypeUnknown:      "unknown",
+	ViolationTypeNull:         "null",
+	ViolationTypeDataType:     "data-type",
+	ViolationTypeBelowMinimum: "below-minimum",
+	ViolationTypeAboveMaximum: "above-maximum",
+	ViolationTypeNotAllowed:   "not-allowed",
+	ViolationTypeTooShort:     "too-short",
+	ViolationTypeTooLong:      "too-long",
+	ViolationTypeUnknownField: "unknown-field",
+	ViolationTypeMissingField: "missing-field",
+}
+
+func (vt ViolationType) String() string {
+	if name, ok := violationTypeNames[vt]; ok {
+		return name
+	}
+	return fmt.Sprintf("ViolationType(%d)", vt)
+}
+
+func (vt ViolationType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(vt.String())
+}
+
+// Violation is a single way in which a value fails to meet its data type or constraint; Path locates the offending
+// value within the one validated, e.g. "[2]" for the third entry of a list, and is empty for the value itself
+type Violation struct {
+	Type    ViolationType         `json:"type"`
+	Path    string                `json:"path,omitempty"`
+	Value   any                   `json:"value,omitempty"`
+	Limit   types.DataTypeExtreme `json:"-"`
+	Message string                `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Path != "" {
+		return fmt.Sprintf("%s: %s", v.Path, v.Message)
+	}
+	return v.Message
+}
+
+// Validate checks value against the data type, nullability and constraint of the field described by cc; references
+// in the constraint are resolved through cc, so a context which knows the values of sibling fields will check against
+// those values. Lists are checked for length, and each entry against the entry constraint. Structs are not descended
+// into; the caller is expected to validate their fields individually
+//
+// Validate takes a Context rather than a field and its field set because package matter, which defines fields,
+// imports this package; matter.ValidateValue builds the context for a field and descends into structs
+func Validate(cc Context, c Constraint, value any) []Violation {
+	return validate(cc, c, value, "")
+}
+
+func validate(cc Context, c Constraint, value any, path string) (violations []Violation) {
+	if value == nil {
+		if cc.Nullability() != types.NullabilityNullable {
+			violations = append(violations, Violation{Type: ViolationTypeNull, Path: path, Message: "is null, but is not nullable"})
+		}
+		return
+	}
+	if _, ok := value.(map[string]any); ok {
+		return
+	}
+	if set, ok := c.(Set); ok && len(set) == 1 {
+		c = set[0]
+	}
+	dt := cc.DataType()
+	switch {
+	case dt.IsArray():
+		list, ok := value.([]any)
+		if !ok {
+			violations = append(violations, dataTypeViolation(value, path, "a list"))
+			return
+		}
+		var entryConstraint Constraint
+		if lc, ok := c.(*ListConstraint); ok {
+			c = lc.Constraint
+			entryConstraint = lc.EntryConstraint
+		}
+		violations = append(violations, validateLength(cc, c, value, len(list), "entries", path)...)
+		ec := &entryContext{Context: cc}
+		for i, entry := range list {
+			violations = append(violations, validate(ec, entryConstraint, entry, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case dt.HasLength():
+		var length int
+		var unit string
+		switch value := value.(type) {
+		case string:
+			length, unit = len(value), "bytes"
+		case []byte:
+			length, unit = len(value), "bytes"
+		default:
+			violations = append(violations, dataTypeViolation(value, path, "a string"))
+			return
+		}
+		violations = append(violations, validateLength(cc, c, value, length, unit, path)...)
+	case dt != nil && dt.BaseType == types.BaseDataTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			violations = append(violations, dataTypeViolation(value, path, "a boolean"))
+		}
+	default:
+		switch value.(type) {
+		case bool, string, []byte, []any:
+			violations = append(violations, dataTypeViolation(value, path, "a number"))
+			return
+		}
+		v, ok := ValueExtreme(value)
+		if !ok {
+			// Only integral values can be compared against the constraint
+			return
+		}
+		var min, max types.DataTypeExtreme
+		if dt != nil {
+			min = types.Min(dt.BaseType, cc.Nullability())
+			max = types.Max(dt.BaseType, cc.Nullability())
+		}
+		violations = append(violations, validateRange(cc, c, value, v, min, max, path)...)
+	}
+	return
+}
+
+// validateRange checks a numeric value against the bounds of c, falling back to the bounds of the data type where c
+// does not define one; a value which falls between the members of a set is reported as not allowed
+func validateRange(cc Context, c Constraint, value any, v types.DataTypeExtreme, typeMin types.DataTypeExtreme, typeMax types.DataTypeExtreme, path string) (violations []Violation) {
+	min, max := bounds(cc, c, typeMin, typeMax)
+	if below(v, min) {
+		return append(violations, Violation{Type: ViolationTypeBelowMinimum, Path: path, Value: value, Limit: min, Message: fmt.Sprintf("has value %v, which is less than the minimum of %v", value, min.Value())})
+	}
+	if above(v, max) {
+		return append(violations, Violation{Type: ViolationTypeAboveMaximum, Path: path, Value: value, Limit: max, Message: fmt.Sprintf("has value %v, which is greater than the maximum of %v", value, max.Value())})
+	}
+	if set, ok := c.(Set); ok {
+		for _, member := range set {
+			min, max := bounds(cc, member, typeMin, typeMax)
+			if !below(v, min) && !above(v, max) {
+				return
+			}
+		}
+		violations = append(violations, Violation{Type: ViolationTypeNotAllowed, Path: path, Value: value, Message: fmt.Sprintf("has value %v, which is not allowed by the constraint %s", value, c.ASCIIDocString(cc.DataType()))})
+	}
+	return
+}
+
+func validateLength(cc Context, c Constraint, value any, length int, unit string, path string) (violations []Violation) {
+	if c == nil {
+		return
+	}
+	v := types.NewUintDataTypeExtreme(uint64(length), types.NumberFormatInt)
+	min, max := bounds(cc, c, types.DataTypeExtreme{}, types.DataTypeExtreme{})
+	if below(v, min) {
+		violations = append(violations, Violation{Type: ViolationTypeTooShort, Path: path, Value: value, Limit: min, Message: fmt.Sprintf("has %d %s, which is fewer than the minimum of %v", length, unit, min.Value())})
+	} else if above(v, max) {
+		violations = append(violations, Violation{Type: ViolationTypeTooLong, Path: path, Value: value, Limit: max, Message: fmt.Sprintf("has %d %s, which is more than the maximum of %v", length, unit, max.Value())})
+	}
+	return
+}
+
+func bounds(cc Context, c Constraint, typeMin types.DataTypeExtreme, typeMax types.DataTypeExtreme) (min types.DataTypeExtreme, max types.DataTypeExtreme) {
+	if c != nil {
+		min = c.Min(cc)
+		max = c.Max(cc)
+	}
+	if !min.IsNumeric() {
+		min = typeMin
+	}
+	if !max.IsNumeric() {
+		max = typeMax
+	}
+	return
+}
+
+func below(v types.DataTypeExtreme, min types.DataTypeExtreme) bool {
+	c, ok := v.Compare(min)
+	return ok && c < 0
+}
+
+func above(v types.DataTypeExtreme, max types.DataTypeExtreme) bool {
+	c, ok := v.Compare(max)
+	return ok && c > 0
+}
+
+func dataTypeViolation(value any, path string, expected string) Violation {
+	return Violation{Type: ViolationTypeDataType, Path: path, Value: value, Message: fmt.Sprintf("has value %v; expected %s", value, expected)}
+}
+
+// ValueExtreme converts a decoded value into an extreme which can be compared against the bounds of a constraint;
+// integers keep their value, while strings, octet strings and lists are measured by their length
+func ValueExtreme(value any) (types.DataTypeExtreme, bool) {
+	switch value := value.(type) {
+	case int64:
+		return types.NewIntDataTypeExtreme(value, types.NumberFormatInt), true
+	case uint64:
+		return types.NewUintDataTypeExtreme(value, types.NumberFormatInt), true
+	case int:
+		return types.NewIntDataTypeExtreme(int64(value), types.NumberFormatInt), true
+	case float64:
+		if value != math.Trunc(value) {
+			return types.DataTypeExtreme{}, false
+		}
+		if value < 0 {
+			return types.NewIntDataTypeExtreme(int64(value), types.NumberFormatInt), true
+		}
+		return types.NewUintDataTypeExtreme(uint64(value), types.NumberFormatInt), true
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return ValueExtreme(i)
+		}
+		if u, err := strconv.ParseUint(value.String(), 10, 64); err == nil {
+			return ValueExtreme(u)
+		}
+		f, err := value.Float64()
+		if err != nil {
+			return types.DataTypeExtreme{}, false
+		}
+		return ValueExtreme(f)
+	case string:
+		return types.NewUintDataTypeExtreme(uint64(len(value)), types.NumberFormatInt), true
+	case []byte:
+		return types.NewUintDataTypeExtreme(uint64(len(value)), types.NumberFormatInt), true
+	case []any:
+		return types.NewUintDataTypeExtreme(uint64(len(value)), types.NumberFormatInt), true
+	}
+	return types.DataTypeExtreme{}, false
+}
+
+// entryContext describes the entries of the list described by its parent
+type entryContext struct {
+	Context
+}
+
+func (ec *entryContext) DataType() *types.DataType {
+	if dt := ec.Context.DataType(); dt != nil {
+		return dt.EntryType
+	}
+	return nil
+}
+
+func (ec *entryContext) Nullability() types.Nullability {
+	return types.NullabilityNonNull
+}
@@ -0,0 +1,167 @@
+package constraint
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type validateTest struct {
+	constraint string
+	dataType   *types.DataType
+	nullable   bool
+	fields     fieldSet
+	value      any
+	violations []ViolationType
+	paths      []string
+}
+
+var uint8Type = types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankScalar)
+var stringType = types.NewDataType(types.BaseDataTypeString, types.DataTypeRankScalar)
+var booleanType = types.NewDataType(types.BaseDataTypeBoolean, types.DataTypeRankScalar)
+var uint8ListType = types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankList)
+
+var validateTests = []validateTest{
+	{constraint: "1 to 254", dataType: uint8Type, value: uint64(1)},
+	{constraint: "1 to 254", dataType: uint8Type, value: uint64(254)},
+	{constraint: "1 to 254", dataType: uint8Type, value: uint64(0), violations: []ViolationType{ViolationTypeBelowMinimum}},
+	{constraint: "1 to 254", dataType: uint8Type, value: uint64(255), violations: []ViolationType{ViolationTypeAboveMaximum}},
+	{constraint: "1 to 254", dataType: uint8Type, value: int64(-1), violations: []ViolationType{ViolationTypeBelowMinimum}},
+	{constraint: "1 to 254", dataType: uint8Type, value: json.Number("300"), violations: []ViolationType{ViolationTypeAboveMaximum}},
+	{constraint: "1 to 254", dataType: uint8Type, value: 1.5},
+	{constraint: "1 to 254", dataType: uint8Type, value: "high", violations: []ViolationType{ViolationTypeDataType}},
+	{constraint: "1 to 254", dataType: uint8Type, value: true, violations: []ViolationType{ViolationTypeDataType}},
+	{constraint: "1 to 254", dataType: uint8Type, value: nil, violations: []ViolationType{ViolationTypeNull}},
+	{constraint: "1 to 254", dataType: uint8Type, nullable: true, value: nil},
+	{constraint: "desc", dataType: uint8Type, value: uint64(256), violations: []ViolationType{ViolationTypeAboveMaximum}},
+	{constraint: "0, 5 to 10", dataType: uint8Type, value: uint64(3), violations: []ViolationType{ViolationTypeNotAllowed}},
+	{constraint: "0, 5 to 10", dataType: uint8Type, value: uint64(7)},
+	{constraint: "0 to Limit", dataType: uint8Type, fields: fieldSet{{Name: "Limit", Constraint: mustParseConstraint("max 4"), Type: uint8Type}}, value: uint64(5), violations: []ViolationType{ViolationTypeAboveMaximum}},
+	{constraint: "max 4", dataType: stringType, value: "abcd"},
+	{constraint: "max 4", dataType: stringType, value: "abcde", violations: []ViolationType{ViolationTypeTooLong}},
+	{constraint: "max 4", dataType: stringType, value: []byte("abcde"), violations: []ViolationType{ViolationTypeTooLong}},
+	{constraint: "2 to 4", dataType: stringType, value: "a", violations: []ViolationType{ViolationTypeTooShort}},
+	{constraint: "max 4", dataType: stringType, value: uint64(4), violations: []ViolationType{ViolationTypeDataType}},
+	{constraint: "desc", dataType: booleanType, value: false},
+	{constraint: "desc", dataType: booleanType, value: "false", violations: []ViolationType{ViolationTypeDataType}},
+	{constraint: "max 2 [1 to 10]", dataType: uint8ListType, value: []any{uint64(1), uint64(10)}},
+	{constraint: "max 2 [1 to 10]", dataType: uint8ListType, value: []any{uint64(1), uint64(2), uint64(3)}, violations: []ViolationType{ViolationTypeTooLong}},
+	{constraint: "max 2 [1 to 10]", dataType: uint8ListType, value: "1, 2", violations: []ViolationType{ViolationTypeDataType}},
+	{
+		constraint: "max 2 [1 to 10]",
+		dataType:   uint8ListType,
+		nullable:   true,
+		value:      []any{uint64(0), nil},
+		violations: []ViolationType{ViolationTypeBelowMinimum, ViolationTypeNull},
+		paths:      []string{"[0]", "[1]"},
+	},
+	{constraint: "1 to 254", dataType: uint8Type, value: map[string]any{"Level": uint64(0)}},
+}
+
+func TestValidate(t *testing.T) {
+	for _, vt := range validateTests {
+		c := mustParseConstraint(vt.constraint)
+		stitchFieldConstraint(vt.fields, c)
+		f := &field{Type: vt.dataType, Nullable: vt.nullable}
+		violations := Validate(&constraintTestContext{field: f, fields: vt.fields}, c, vt.value)
+		var violationTypes []ViolationType
+		var paths []string
+		for _, v := range violations {
+			violationTypes = append(violationTypes, v.Type)
+			paths = append(paths, v.Path)
+		}
+		if !slices.Equal(violationTypes, vt.violations) {
+			t.Errorf("%v against \"%s\": expected violations %v, got %v", vt.value, vt.constraint, vt.violations, violations)
+			continue
+		}
+		if vt.paths != nil && !slices.Equal(paths, vt.paths) {
+			t.Errorf("%v against \"%s\": expected paths %v, got %v", vt.value, vt.constraint, vt.paths, paths)
+		}
+	}
+}
+
+func TestValueExtreme(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected types.DataTypeExtreme
+		ok       bool
+	}{
+		{int64(-3), types.NewIntDataTypeExtreme(-3, types.NumberFormatInt), true},
+		{uint64(3), types.NewUintDataTypeExtreme(3, types.NumberFormatInt), true},
+		{3, types.NewIntDataTypeExtreme(3, types.NumberFormatInt), true},
+		{float64(-3), types.NewIntDataTypeExtreme(-3, types.NumberFormatInt), true},
+		{float64(3), types.NewUintDataTypeExtreme(3, types.NumberFormatInt), true},
+		{3.5, types.DataTypeExtreme{}, false},
+		{json.Number("-3"), types.NewIntDataTypeExtreme(-3, types.NumberFormatInt), true},
+		{json.Number("18446744073709551615"), types.NewUintDataTypeExtreme(18446744073709551615, types.NumberFormatInt), true},
+		{json.Number("3.5"), types.DataTypeExtreme{}, false},
+		{"abc", types.NewUintDataTypeExtreme(3, types.NumberFormatInt), true},
+		{[]byte{1, 2}, types.NewUintDataTypeExtreme(2, types.NumberFormatInt), true},
+		{[]any{1, 2, 3, 4}, types.NewUintDataTypeExtreme(4, types.NumberFormatInt), true},
+		{true, types.DataTypeExtreme{}, false},
+		{nil, types.DataTypeExtreme{}, false},
+	}
+	for _, test := range tests {
+		v, ok := ValueExtreme(test.value)
+		if ok != test.ok {
+			t.Errorf("%v: expected ok %v, got %v", test.value, test.ok, ok)
+			continue
+		}
+		if ok && !v.Equals(test.expected) {
+			t.Errorf("%v: expected %v, got %v", test.value, test.expected, v)
+		}
+	}
+}
+
+func TestEntryContext(t *testing.T) {
+	cc := &constraintTestContext{field: &field{Type: uint8ListType, Nullable: true}}
+	ec := &entryContext{Context: cc}
+	if ec.DataType() != uint8ListType.EntryType {
+		t.Errorf("expected entry data type %v, got %v", uint8ListType.EntryType, ec.DataType())
+	}
+	if ec.Nullability() != types.NullabilityNonNull {
+		t.Errorf("expected entries to be non-nullable")
+	}
+
+	ec = &entryContext{Context: &constraintTestContext{field: &field{}}}
+	if ec.DataType() != nil {
+		t.Errorf("expected no entry data type without a list data type, got %v", ec.DataType())
+	}
+}
+
+func TestViolationType(t *testing.T) {
+	names := map[ViolationType]string{
+		ViolationTypeUnknown:      "unknown",
+		ViolationTypeNull:         "null",
+		ViolationTypeDataType:     "data-type",
+		ViolationTypeBelowMinimum: "below-minimum",
+		ViolationTypeAboveMaximum: "above-maximum",
+		ViolationTypeNotAllowed:   "not-allowed",
+		ViolationTypeTooShort:     "too-short",
+		ViolationTypeTooLong:      "too-long",
+		ViolationTypeUnknownField: "unknown-field",
+		ViolationTypeMissingField: "missing-field",
+	}
+	for vt := ViolationTypeUnknown; vt <= ViolationTypeMissingField; vt++ {
+		name, ok := names[vt]
+		if !ok {
+			t.Errorf("no expected name for violation type %d", vt)
+			continue
+		}
+		if vt.String() != name {
+			t.Errorf("violation type %d: expected %s, got %s", vt, name, vt.String())
+		}
+		b, err := json.Marshal(Violation{Type: vt, Message: "is wrong"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := `{"type":"` + name + `","message":"is wrong"}`; string(b) != expected {
+			t.Errorf("violation type %d: expected JSON %s, got %s", vt, expected, string(b))
+		}
+	}
+	if s := ViolationType(200).String(); s != "ViolationType(200)" {
+		t.Errorf("unexpected name for undefined violation type: %s", s)
+	}
+}
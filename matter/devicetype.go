@@ -1,6 +1,7 @@
 package matter
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/project-chip/alchemy/asciidoc"
@@ -240,6 +241,10 @@ var (
 	}
 )
 
+func (s DeviceTypeRequirementLocation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
 func (s DeviceTypeRequirementLocation) String() string {
 	str, ok := deviceTypeRequirementRelations[s]
 	if ok {
@@ -81,6 +81,17 @@ func EntityName(e types.Entity) string {
 	}
 }
 
+// EntityCluster returns the closest ancestor of an entity which is a cluster, or the entity itself if it is one
+func EntityCluster(e types.Entity) *Cluster {
+	for e != nil {
+		if cluster, ok := e.(*Cluster); ok {
+			return cluster
+		}
+		e = e.Parent()
+	}
+	return nil
+}
+
 func EntityID(e types.Entity) *Number {
 	switch entity := e.(type) {
 	case *Cluster:
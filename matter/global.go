@@ -2,3 +2,6 @@ package matter
 
 var AtomicRequestCommandID = NewNumber(0xFE)
 var AtomicResponseCommandID = NewNumber(0xFD)
+
+// FabricIndexFieldID is the field ID of the FabricIndex field implied by fabric-scoped structs
+const FabricIndexFieldID = 0xFE
@@ -0,0 +1,175 @@
+package matter
+
+import (
+	"fmt"
+
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// ClusterImplementation describes a concrete implementation of a cluster, against which the conformance of
+// the cluster's elements can be evaluated
+type ClusterImplementation struct {
+	// Features holds the codes of the supported features; all other features of the cluster are unsupported
+	Features []string
+	// FeatureMap holds the value of the cluster's FeatureMap attribute; its features are supported along with those in
+	// Features, and any bit it sets which the cluster does not define is an error
+	FeatureMap uint64
+	// Attributes holds the names of the implemented attributes; if nil, attributes are evaluated by their own conformance
+	Attributes []string
+	// Commands holds the names of the implemented commands; if nil, commands are evaluated by their own conformance
+	Commands []string
+	// Revision is the implemented revision of the cluster; if zero, revision expressions are treated as possible
+	Revision conformance.Revision
+	// Values holds values for identifiers used in comparisons, e.g. the value of an attribute
+	Values map[string]any
+}
+
+// ElementConformance is the resolved conformance of a single element of a cluster
+type ElementConformance struct {
+	Entity      types.Entity
+	Parent      types.Entity
+	Conformance conformance.Set
+	State       conformance.ConformanceState
+}
+
+// Context returns a conformance context for cluster populated from this implementation
+func (ci *ClusterImplementation) Context(cluster *Cluster) (*conformance.BasicContext, error) {
+	cxt := &conformance.BasicContext{Values: make(map[string]any)}
+	if cluster.Features != nil {
+		for f := range cluster.Features.FeatureBits() {
+			cxt.Values[f.Code] = false
+		}
+	}
+	for _, code := range ci.Features {
+		if _, ok := cxt.Values[code]; !ok {
+			return nil, fmt.Errorf("unknown feature on cluster %s: %s", cluster.Name, code)
+		}
+		cxt.Values[code] = true
+	}
+	if ci.FeatureMap != 0 {
+		var defined uint64
+		for f := range cluster.Features.FeatureBits() {
+			mask, err := f.Mask()
+			if err != nil {
+				return nil, err
+			}
+			defined |= mask
+			if ci.FeatureMap&mask != 0 {
+				cxt.Values[f.Code] = true
+			}
+		}
+		if undefined := ci.FeatureMap &^ defined; undefined != 0 {
+			return nil, fmt.Errorf("undefined bits in FeatureMap of cluster %s: 0x%X", cluster.Name, undefined)
+		}
+	}
+	if ci.Attributes != nil {
+		err := implementedElements(cxt, cluster, "attribute", cluster.Attributes, func(a *Field) string { return a.Name }, ci.Attributes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ci.Commands != nil {
+		err := implementedElements(cxt, cluster, "command", cluster.Commands, func(c *Command) string { return c.Name }, ci.Commands)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if ci.Revision > 0 {
+		cxt.Values["Revision"] = ci.Revision
+	}
+	for name, value := range ci.Values {
+		cxt.Values[name] = value
+	}
+	return cxt, nil
+}
+
+func implementedElements[T any](cxt *conformance.BasicContext, cluster *Cluster, kind string, elements []T, name func(T) string, implemented []string) error {
+	known := make(map[string]struct{}, len(elements))
+	for _, e := range elements {
+		n := name(e)
+		known[n] = struct{}{}
+		cxt.Values[n] = false
+	}
+	for _, n := range implemented {
+		if _, ok := known[n]; !ok {
+			return fmt.Errorf("unknown %s on cluster %s: %s", kind, cluster.Name, n)
+		}
+		cxt.Values[n] = true
+	}
+	return nil
+}
+
+// EvaluateClusterConformance resolves the conformance of every element of cluster which has conformance,
+// given an implementation of it; features come first, followed by attributes, commands, events and data types
+func EvaluateClusterConformance(cluster *Cluster, implementation ClusterImplementation) (elements []ElementConformance, err error) {
+	var cxt *conformance.BasicContext
+	cxt, err = implementation.Context(cluster)
+	if err != nil {
+		return
+	}
+	evaluate := func(entity types.Entity, parent types.Entity) error {
+		set := EntityConformance(entity)
+		if conformance.IsBlank(set) {
+			return nil
+		}
+		state, err := set.Eval(cxt)
+		if err != nil {
+			return err
+		}
+		elements = append(elements, ElementConformance{Entity: entity, Parent: parent, Conformance: set, State: state})
+		return nil
+	}
+	evaluateFields := func(fields FieldSet, parent types.Entity) error {
+		for _, f := range fields {
+			if err := evaluate(f, parent); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for f := range cluster.Features.FeatureBits() {
+		if err = evaluate(f, cluster.Features); err != nil {
+			return
+		}
+	}
+	if err = evaluateFields(cluster.Attributes, cluster); err != nil {
+		return
+	}
+	for _, c := range cluster.Commands {
+		if err = evaluate(c, cluster); err != nil {
+			return
+		}
+		if err = evaluateFields(c.Fields, c); err != nil {
+			return
+		}
+	}
+	for _, e := range cluster.Events {
+		if err = evaluate(e, cluster); err != nil {
+			return
+		}
+		if err = evaluateFields(e.Fields, e); err != nil {
+			return
+		}
+	}
+	for _, bm := range cluster.Bitmaps {
+		for _, b := range bm.Bits {
+			if err = evaluate(b, bm); err != nil {
+				return
+			}
+		}
+	}
+	for _, en := range cluster.Enums {
+		for _, ev := range en.Values {
+			if err = evaluate(ev, en); err != nil {
+				return
+			}
+		}
+	}
+	for _, s := range cluster.Structs {
+		if err = evaluateFields(s.Fields, s); err != nil {
+			return
+		}
+	}
+	return
+}
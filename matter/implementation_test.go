@@ -0,0 +1,102 @@
+package matter
+
+import (
+	"testing"
+
+	"github.com/project-chip/alchemy/matter/conformance"
+)
+
+func TestEvaluateClusterConformance(t *testing.T) {
+	cluster := NewCluster(nil)
+	cluster.Name = "Test"
+	cluster.Features = NewFeatures(nil, cluster)
+	cluster.Features.AddFeatureBit(NewFeature(nil, "0", "Lighting", "LT", "", conformance.ParseConformance("O")))
+	cluster.Features.AddFeatureBit(NewFeature(nil, "1", "DeadFront", "DF", "", conformance.ParseConformance("O")))
+	attributes := map[string]string{
+		"Always":    "M",
+		"LightOnly": "LT",
+		"Both":      "LT & DF",
+		"Newer":     "Rev >= v4",
+		"Dependent": "Always",
+		"Compared":  "Always > 5",
+	}
+	for _, name := range []string{"Always", "LightOnly", "Both", "Newer", "Dependent", "Compared"} {
+		a := NewAttribute(nil, cluster)
+		a.Name = name
+		a.Conformance = conformance.ParseConformance(attributes[name])
+		cluster.Attributes = append(cluster.Attributes, a)
+	}
+
+	tests := []struct {
+		name           string
+		implementation ClusterImplementation
+		expected       map[string]conformance.State
+	}{
+		{
+			name:           "none",
+			implementation: ClusterImplementation{Revision: 3},
+			expected: map[string]conformance.State{
+				"LightOnly": conformance.StateDisallowed,
+				"Always":    conformance.StateMandatory,
+				"Both":      conformance.StateDisallowed,
+				"Newer":     conformance.StateDisallowed,
+				"Dependent": conformance.StateMandatory,
+			},
+		},
+		{
+			name:           "features",
+			implementation: ClusterImplementation{Features: []string{"LT", "DF"}, Revision: 4, Attributes: []string{"Always"}, Values: map[string]any{"Always": int64(7)}},
+			expected: map[string]conformance.State{
+				"LightOnly": conformance.StateMandatory,
+				"Both":      conformance.StateMandatory,
+				"Newer":     conformance.StateMandatory,
+				"Dependent": conformance.StateMandatory,
+				"Compared":  conformance.StateMandatory,
+			},
+		},
+		{
+			name:           "feature map",
+			implementation: ClusterImplementation{FeatureMap: 0x1, Revision: 3},
+			expected: map[string]conformance.State{
+				"LightOnly": conformance.StateMandatory,
+				"Both":      conformance.StateDisallowed,
+			},
+		},
+		{
+			name:           "feature map and features",
+			implementation: ClusterImplementation{Features: []string{"DF"}, FeatureMap: 0x1, Revision: 3},
+			expected: map[string]conformance.State{
+				"LightOnly": conformance.StateMandatory,
+				"Both":      conformance.StateMandatory,
+			},
+		},
+	}
+	for _, test := range tests {
+		elements, err := EvaluateClusterConformance(cluster, test.implementation)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		for _, e := range elements {
+			name := EntityName(e.Entity)
+			if _, ok := e.Entity.(*Feature); ok {
+				continue
+			}
+			expected, ok := test.expected[name]
+			if !ok {
+				continue
+			}
+			if e.State.State != expected {
+				t.Errorf("%s: expected %s to be %s, got %s", test.name, name, expected, e.State.State)
+			}
+		}
+	}
+
+	_, err := EvaluateClusterConformance(cluster, ClusterImplementation{Features: []string{"XX"}})
+	if err == nil {
+		t.Errorf("expected error for unknown feature")
+	}
+	_, err = EvaluateClusterConformance(cluster, ClusterImplementation{FeatureMap: 0x5})
+	if err == nil {
+		t.Errorf("expected error for a FeatureMap bit the cluster does not define")
+	}
+}
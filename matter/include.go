@@ -0,0 +1,32 @@
+package matter
+
+import (
+	"strings"
+
+	"github.com/project-chip/alchemy/matter/conformance"
+)
+
+// IsIncluded reports whether an element with conformance c belongs in definitions generated for Matter; Zigbee-only
+// and disallowed elements are left out
+func IsIncluded(c conformance.Conformance) bool {
+	return !conformance.IsZigbee(c) && !conformance.IsDisallowed(c)
+}
+
+// IncludedBits returns the bits of a bitmap or feature map which are included in definitions generated for Matter
+func IncludedBits(bits BitSet) (included BitSet) {
+	for _, b := range bits {
+		if IsIncluded(b.Conformance()) {
+			included = append(included, b)
+		}
+	}
+	return
+}
+
+// WithSuffix appends suffix to name, unless name already ends with it
+// e.g. "ToggleResponse" stays "ToggleResponse" with the suffix "Response", while "Toggle" becomes "ToggleResponse"
+func WithSuffix(name string, suffix string) string {
+	if strings.HasSuffix(name, suffix) {
+		return name
+	}
+	return name + suffix
+}
@@ -0,0 +1,39 @@
+package matter
+
+import (
+	"testing"
+
+	"github.com/project-chip/alchemy/matter/conformance"
+)
+
+func TestIncludedBits(t *testing.T) {
+	bits := BitSet{
+		NewBitmapBit(nil, nil, "0", "Mandatory", "", conformance.ParseConformance("M")),
+		NewBitmapBit(nil, nil, "1", "Disallowed", "", conformance.ParseConformance("X")),
+		NewBitmapBit(nil, nil, "2", "Optional", "", conformance.ParseConformance("O")),
+		NewBitmapBit(nil, nil, "3", "Zigbee", "", conformance.ParseConformance("[Zigbee]")),
+	}
+	included := IncludedBits(bits)
+	if len(included) != 2 || included[0].Name() != "Mandatory" || included[1].Name() != "Optional" {
+		names := make([]string, 0, len(included))
+		for _, b := range included {
+			names = append(names, b.Name())
+		}
+		t.Errorf("expected Mandatory and Optional bits, got %v", names)
+	}
+}
+
+func TestWithSuffix(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		expected string
+	}{
+		{"Toggle", "ToggleResponse"},
+		{"ToggleResponse", "ToggleResponse"},
+		{"Response", "Response"},
+	} {
+		if s := WithSuffix(test.name, "Response"); s != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, s)
+		}
+	}
+}
@@ -149,6 +149,14 @@ func (n *Number) HexString() string {
 	return fmt.Sprintf("0x%04X", n.value)
 }
 
+// ValidHexString returns the number in hex, or an empty string if the number is not valid
+func (n *Number) ValidHexString() string {
+	if !n.Valid() {
+		return ""
+	}
+	return n.HexString()
+}
+
 func (n *Number) ShortHexString() string {
 	if !n.Valid() {
 		return n.text
@@ -0,0 +1,327 @@
+package matter
+
+import (
+	"cmp"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// ResolvedEndpoint is the effective set of requirements on an endpoint of a device type, after the requirements
+// of its base, subset and composed device types have been merged by origin priority
+type ResolvedEndpoint struct {
+	DeviceTypeID   *Number                       `json:"deviceTypeId,omitempty"`
+	DeviceTypeName string                        `json:"deviceTypeName,omitempty"`
+	Location       DeviceTypeRequirementLocation `json:"location"`
+	Conformance    conformance.Set               `json:"conformance,omitempty"`
+
+	Clusters  []*ResolvedClusterRequirement `json:"clusters,omitempty"`
+	Tags      []*ResolvedTagRequirement     `json:"tags,omitempty"`
+	Endpoints []*ResolvedEndpoint           `json:"endpoints,omitempty"`
+
+	DeviceType *DeviceType `json:"-"`
+}
+
+type ResolvedClusterRequirement struct {
+	ClusterID   *Number           `json:"clusterId,omitempty"`
+	ClusterName string            `json:"clusterName,omitempty"`
+	Interface   Interface         `json:"interface"`
+	Conformance conformance.Set   `json:"conformance,omitempty"`
+	Origin      RequirementOrigin `json:"origin"`
+	DeclaredBy  string            `json:"declaredBy,omitempty"`
+
+	Features   []*ResolvedElementRequirement `json:"features,omitempty"`
+	Attributes []*ResolvedElementRequirement `json:"attributes,omitempty"`
+	Commands   []*ResolvedElementRequirement `json:"commands,omitempty"`
+	Events     []*ResolvedElementRequirement `json:"events,omitempty"`
+
+	Overrides []*ResolvedOverride `json:"overrides,omitempty"`
+
+	Cluster     *Cluster                      `json:"-"`
+	Requirement *DeviceTypeClusterRequirement `json:"-"`
+}
+
+type ResolvedElementRequirement struct {
+	Element     types.EntityType      `json:"element"`
+	Name        string                `json:"name"`
+	Field       string                `json:"field,omitempty"`
+	Constraint  constraint.Constraint `json:"constraint,omitempty"`
+	Conformance conformance.Set       `json:"conformance,omitempty"`
+	Origin      RequirementOrigin     `json:"origin"`
+	DeclaredBy  string                `json:"declaredBy,omitempty"`
+
+	Overrides []*ResolvedOverride `json:"overrides,omitempty"`
+
+	Requirement *DeviceTypeElementRequirement `json:"-"`
+}
+
+type ResolvedTagRequirement struct {
+	NamespaceID     *Number               `json:"namespaceId,omitempty"`
+	NamespaceName   string                `json:"namespaceName,omitempty"`
+	SemanticTagID   *Number               `json:"semanticTagId,omitempty"`
+	SemanticTagName string                `json:"semanticTagName,omitempty"`
+	Constraint      constraint.Constraint `json:"constraint,omitempty"`
+	Conformance     conformance.Set       `json:"conformance,omitempty"`
+	Origin          RequirementOrigin     `json:"origin"`
+	DeclaredBy      string                `json:"declaredBy,omitempty"`
+
+	Requirement *DeviceTypeTagRequirement `json:"-"`
+}
+
+// ResolvedOverride is a lower-priority requirement which was replaced during resolution
+type ResolvedOverride struct {
+	Conformance conformance.Set   `json:"conformance,omitempty"`
+	Origin      RequirementOrigin `json:"origin"`
+	DeclaredBy  string            `json:"declaredBy,omitempty"`
+}
+
+// ResolveDeviceTypeComposition flattens a device type composition into the effective requirements on each endpoint;
+// where more than one layer places a requirement on the same cluster, element or tag, the one with the highest
+// origin priority wins
+func ResolveDeviceTypeComposition(dc *DeviceTypeComposition) *ResolvedEndpoint {
+	re := resolveEndpoint(dc)
+	re.Location = DeviceTypeRequirementLocationDeviceEndpoint
+	return re
+}
+
+func resolveEndpoint(dc *DeviceTypeComposition) *ResolvedEndpoint {
+	re := &ResolvedEndpoint{DeviceType: dc.DeviceType}
+	if dc.DeviceType != nil {
+		re.DeviceTypeID = dc.DeviceType.ID
+		re.DeviceTypeName = dc.DeviceType.Name
+	}
+	re.Clusters = resolveClusterRequirements(dc)
+	re.Tags = resolveTagRequirements(dc)
+
+	requirements := make(map[*DeviceType]*DeviceTypeRequirement, len(dc.DeviceTypeRequirements))
+	for _, dtr := range dc.DeviceTypeRequirements {
+		requirements[dtr.DeviceType] = dtr
+	}
+	for location, compositions := range dc.ComposedDeviceTypes {
+		if location == DeviceTypeRequirementLocationDeviceEndpoint {
+			// Requirements of device types on the same endpoint have already been merged into this composition
+			continue
+		}
+		for _, cdt := range compositions {
+			child := resolveEndpoint(cdt)
+			child.Location = location
+			if dtr, ok := requirements[cdt.DeviceType]; ok {
+				child.Conformance = dtr.Conformance
+			}
+			re.Endpoints = append(re.Endpoints, child)
+		}
+	}
+	slices.SortStableFunc(re.Endpoints, func(a, b *ResolvedEndpoint) int {
+		if c := cmp.Compare(a.Location, b.Location); c != 0 {
+			return c
+		}
+		return compareIDs(a.DeviceTypeID, b.DeviceTypeID)
+	})
+	return re
+}
+
+type clusterRequirementKey struct {
+	cluster *Cluster
+	iface   Interface
+}
+
+func resolveClusterRequirements(dc *DeviceTypeComposition) (clusters []*ResolvedClusterRequirement) {
+	byKey := make(map[clusterRequirementKey][]*DeviceTypeClusterRequirement)
+	var keys []clusterRequirementKey
+	for _, cr := range dc.ClusterRequirements {
+		if cr.ClusterRequirement == nil || cr.ClusterRequirement.Cluster == nil {
+			continue
+		}
+		key := clusterRequirementKey{cluster: cr.ClusterRequirement.Cluster, iface: cr.ClusterRequirement.Interface}
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], cr)
+	}
+	servers := make(map[*Cluster]*ResolvedClusterRequirement)
+	for _, key := range keys {
+		crs := byKey[key]
+		slices.SortStableFunc(crs, func(a, b *DeviceTypeClusterRequirement) int {
+			return a.Origin.Compare(b.Origin)
+		})
+		winner := crs[len(crs)-1]
+		rcr := &ResolvedClusterRequirement{
+			ClusterID:   key.cluster.ID,
+			ClusterName: key.cluster.Name,
+			Interface:   key.iface,
+			Conformance: winner.ClusterRequirement.Conformance,
+			Origin:      winner.Origin,
+			DeclaredBy:  requirementDeclaredBy(winner.ClusterRequirement, winner.DeviceTypeName),
+			Cluster:     key.cluster,
+			Requirement: winner,
+		}
+		for _, cr := range crs[:len(crs)-1] {
+			rcr.Overrides = append(rcr.Overrides, &ResolvedOverride{
+				Conformance: cr.ClusterRequirement.Conformance,
+				Origin:      cr.Origin,
+				DeclaredBy:  requirementDeclaredBy(cr.ClusterRequirement, cr.DeviceTypeName),
+			})
+		}
+		if key.iface == InterfaceServer {
+			servers[key.cluster] = rcr
+		}
+		clusters = append(clusters, rcr)
+	}
+	slices.SortStableFunc(clusters, func(a, b *ResolvedClusterRequirement) int {
+		if c := compareIDs(a.ClusterID, b.ClusterID); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.ClusterName, b.ClusterName); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Interface, b.Interface)
+	})
+
+	resolveElementRequirements(dc, servers)
+	return
+}
+
+type elementRequirementKey struct {
+	cluster *Cluster
+	element types.EntityType
+	entity  types.Entity
+	name    string
+	field   string
+}
+
+func resolveElementRequirements(dc *DeviceTypeComposition, servers map[*Cluster]*ResolvedClusterRequirement) {
+	byKey := make(map[elementRequirementKey][]*DeviceTypeElementRequirement)
+	var keys []elementRequirementKey
+	for _, er := range dc.ElementRequirements {
+		if er.ElementRequirement == nil || er.ElementRequirement.Cluster == nil {
+			continue
+		}
+		key := elementRequirementKey{cluster: er.ElementRequirement.Cluster, element: er.ElementRequirement.Element, entity: er.ElementRequirement.Entity}
+		if key.entity == nil {
+			key.name = strings.ToLower(er.ElementRequirement.Name)
+			key.field = strings.ToLower(er.ElementRequirement.Field)
+		}
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], er)
+	}
+	for _, key := range keys {
+		ers := byKey[key]
+		rcr, ok := servers[key.cluster]
+		if !ok {
+			slog.Warn("Element requirement on cluster without server requirement", slog.String("deviceType", dc.DeviceType.Name), slog.String("clusterName", key.cluster.Name), slog.String("element", ers[0].ElementRequirement.Name))
+			continue
+		}
+		slices.SortStableFunc(ers, func(a, b *DeviceTypeElementRequirement) int {
+			return a.Origin.Compare(b.Origin)
+		})
+		winner := ers[len(ers)-1]
+		rer := &ResolvedElementRequirement{
+			Element:     winner.ElementRequirement.Element,
+			Name:        winner.ElementRequirement.Name,
+			Field:       winner.ElementRequirement.Field,
+			Constraint:  resolvedConstraint(winner.ElementRequirement.Constraint),
+			Conformance: winner.ElementRequirement.Conformance,
+			Origin:      winner.Origin,
+			DeclaredBy:  requirementDeclaredBy(winner.ElementRequirement, winner.DeviceTypeName),
+			Requirement: winner,
+		}
+		for _, er := range ers[:len(ers)-1] {
+			rer.Overrides = append(rer.Overrides, &ResolvedOverride{
+				Conformance: er.ElementRequirement.Conformance,
+				Origin:      er.Origin,
+				DeclaredBy:  requirementDeclaredBy(er.ElementRequirement, er.DeviceTypeName),
+			})
+		}
+		switch key.element {
+		case types.EntityTypeFeature:
+			rcr.Features = append(rcr.Features, rer)
+		case types.EntityTypeAttribute:
+			rcr.Attributes = append(rcr.Attributes, rer)
+		case types.EntityTypeCommand, types.EntityTypeCommandField:
+			rcr.Commands = append(rcr.Commands, rer)
+		case types.EntityTypeEvent:
+			rcr.Events = append(rcr.Events, rer)
+		default:
+			slog.Warn("Unexpected element requirement type", slog.String("deviceType", dc.DeviceType.Name), slog.String("element", key.element.String()))
+		}
+	}
+}
+
+type tagRequirementKey struct {
+	namespace   string
+	semanticTag string
+}
+
+func resolveTagRequirements(dc *DeviceTypeComposition) (tags []*ResolvedTagRequirement) {
+	byKey := make(map[tagRequirementKey][]*DeviceTypeTagRequirement)
+	var keys []tagRequirementKey
+	for _, tr := range dc.TagRequirements {
+		if tr.TagRequirement == nil {
+			continue
+		}
+		key := tagRequirementKey{namespace: strings.ToLower(tr.TagRequirement.NamespaceName), semanticTag: strings.ToLower(tr.TagRequirement.SemanticTagName)}
+		if tr.TagRequirement.NamespaceID.Valid() {
+			key.namespace = tr.TagRequirement.NamespaceID.HexString()
+		}
+		if tr.TagRequirement.SemanticTagID.Valid() {
+			key.semanticTag = tr.TagRequirement.SemanticTagID.HexString()
+		}
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], tr)
+	}
+	for _, key := range keys {
+		trs := byKey[key]
+		slices.SortStableFunc(trs, func(a, b *DeviceTypeTagRequirement) int {
+			return a.RequirementOrigin.Compare(b.RequirementOrigin)
+		})
+		winner := trs[len(trs)-1]
+		tags = append(tags, &ResolvedTagRequirement{
+			NamespaceID:     winner.TagRequirement.NamespaceID,
+			NamespaceName:   winner.TagRequirement.NamespaceName,
+			SemanticTagID:   winner.TagRequirement.SemanticTagID,
+			SemanticTagName: winner.TagRequirement.SemanticTagName,
+			Constraint:      resolvedConstraint(winner.TagRequirement.Constraint),
+			Conformance:     winner.TagRequirement.Conformance,
+			Origin:          winner.RequirementOrigin,
+			DeclaredBy:      requirementDeclaredBy(winner.TagRequirement, winner.DeviceTypeName),
+			Requirement:     winner,
+		})
+	}
+	return
+}
+
+// requirementDeclaredBy returns the name of the device type whose tables contain the requirement
+func requirementDeclaredBy(requirement interface{ Parent() types.Entity }, fallback string) string {
+	if dt, ok := requirement.Parent().(*DeviceType); ok {
+		return dt.Name
+	}
+	return fallback
+}
+
+func resolvedConstraint(c constraint.Constraint) constraint.Constraint {
+	if c == nil || constraint.IsBlank(c) {
+		return nil
+	}
+	return c
+}
+
+// compareIDs orders valid IDs before invalid ones
+func compareIDs(a, b *Number) int {
+	switch {
+	case a.Valid() && b.Valid():
+		return a.Compare(b)
+	case a.Valid():
+		return -1
+	case b.Valid():
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,70 @@
+package matter
+
+import (
+	"testing"
+
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestResolveDeviceTypeComposition(t *testing.T) {
+	base := &DeviceType{Name: "Base Device Type", ID: NewNumber(0xFFFE)}
+	light := &DeviceType{Name: "Light", ID: NewNumber(0x0100)}
+	cluster := NewCluster(nil)
+	cluster.Name = "On/Off"
+	cluster.ID = NewNumber(0x0006)
+
+	clusterRequirement := func(deviceType *DeviceType, conf string, origin RequirementOrigin) *DeviceTypeClusterRequirement {
+		cr := NewClusterRequirement(deviceType, nil)
+		cr.Cluster = cluster
+		cr.Interface = InterfaceServer
+		cr.Conformance = conformance.ParseConformance(conf)
+		dtcr := NewDeviceTypeClusterRequirement(light, cr, nil)
+		dtcr.Origin = origin
+		return dtcr
+	}
+	elementRequirement := func(deviceType *DeviceType, name string, conf string, origin RequirementOrigin) *DeviceTypeElementRequirement {
+		er := NewElementRequirement(deviceType, nil)
+		er.Cluster = cluster
+		er.Element = types.EntityTypeAttribute
+		er.Name = name
+		er.Conformance = conformance.ParseConformance(conf)
+		dter := NewDeviceTypeElementRequirement(light, &er, nil)
+		dter.Origin = origin
+		return dter
+	}
+
+	dc := &DeviceTypeComposition{
+		DeviceType: light,
+		ClusterRequirements: []*DeviceTypeClusterRequirement{
+			clusterRequirement(light, "M", RequirementOriginDeviceType),
+			clusterRequirement(base, "O", RequirementOriginBaseDeviceType),
+		},
+		ElementRequirements: []*DeviceTypeElementRequirement{
+			elementRequirement(light, "OnTime", "M", RequirementOriginDeviceType),
+			elementRequirement(base, "ontime", "O", RequirementOriginBaseDeviceType),
+			elementRequirement(base, "OffWaitTime", "O", RequirementOriginBaseDeviceType),
+		},
+	}
+
+	resolved := ResolveDeviceTypeComposition(dc)
+	if len(resolved.Clusters) != 1 {
+		t.Fatalf("expected 1 resolved cluster requirement, got %d", len(resolved.Clusters))
+	}
+	rcr := resolved.Clusters[0]
+	if rcr.Origin != RequirementOriginDeviceType || !conformance.IsMandatory(rcr.Conformance) {
+		t.Errorf("expected device type requirement to win, got %s from %s", rcr.Conformance.ASCIIDocString(), rcr.Origin)
+	}
+	if len(rcr.Overrides) != 1 || rcr.Overrides[0].DeclaredBy != base.Name {
+		t.Errorf("expected base device type requirement to be overridden")
+	}
+	if len(rcr.Attributes) != 2 {
+		t.Fatalf("expected 2 resolved attribute requirements, got %d", len(rcr.Attributes))
+	}
+	if rcr.Attributes[0].Name != "OnTime" || rcr.Attributes[0].Origin != RequirementOriginDeviceType {
+		t.Errorf("expected OnTime requirement from device type, got %s from %s", rcr.Attributes[0].Name, rcr.Attributes[0].Origin)
+	}
+	if rcr.Attributes[1].Name != "OffWaitTime" || rcr.Attributes[1].Origin != RequirementOriginBaseDeviceType {
+		t.Errorf("expected OffWaitTime requirement from base device type, got %s from %s", rcr.Attributes[1].Name, rcr.Attributes[1].Origin)
+	}
+}
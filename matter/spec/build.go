@@ -26,6 +26,7 @@ type Builder struct {
 
 	ignoreHierarchy bool
 	patchForSdk     bool
+	imported        bool
 
 	conformanceFailures map[any]referenceFailure
 	constraintFailures  map[any]referenceFailure
@@ -70,6 +71,7 @@ func (sp *Builder) buildSpec(cxt context.Context, libraries []*Library) (referen
 	slices.SortStableFunc(libraries, func(a *Library, b *Library) int {
 		return strings.Compare(a.Root.Path.Relative, b.Root.Path.Relative)
 	})
+	spec.Libraries = libraries
 
 	docs := make(map[string][]*asciidoc.Document)
 
@@ -186,71 +188,15 @@ func (sp *Builder) readEntities(spec *Specification, libraries []*Library) (basi
 				}
 				continue
 			}
-			switch entity := result.(type) {
-			case *matter.ClusterGroup:
-				for _, c := range entity.Clusters {
-					sp.addCluster(doc, c)
-				}
-			case *matter.Cluster:
-				switch entity.Name {
+			if cluster, ok := result.(*matter.Cluster); ok {
+				switch cluster.Name {
 				case "Basic Information":
-					basicInformationCluster = entity
+					basicInformationCluster = cluster
 				case "Bridged Device Basic Information":
-					bridgedDeviceBasicInformationCluster = entity
-				}
-				sp.addCluster(doc, entity)
-			case *matter.DeviceType:
-				spec.DeviceTypes = append(spec.DeviceTypes, entity)
-				if entity.ID.Valid() {
-					if existing, ok := spec.DeviceTypesByID[entity.ID.Value()]; ok {
-						slog.Error("duplicate device type ID", slog.String("deviceTypeId", entity.ID.HexString()), log.Path("previousSource", existing), log.Path("newSource", entity))
-						spec.addError(&DuplicateEntityIDError{Entity: entity, Previous: existing})
-					} else {
-						spec.DeviceTypesByID[entity.ID.Value()] = entity
-					}
-
-				}
-				existing, ok := spec.DeviceTypesByName[entity.Name]
-				if ok {
-					slog.Warn("Duplicate Device Type Name", slog.String("deviceTypeId", entity.ID.HexString()), slog.String("deviceTypeName", entity.Name), slog.String("existingDeviceTypeId", existing.ID.HexString()))
-					spec.addError(&DuplicateEntityNameError{Entity: entity, Previous: existing})
-				}
-				spec.DeviceTypesByName[entity.Name] = entity
-				switch entity.Name {
-				case "Root Node":
-					spec.RootNodeDeviceType = entity
-				case "Base Device Type":
-					spec.BaseDeviceType = entity
+					bridgedDeviceBasicInformationCluster = cluster
 				}
-			case *matter.Namespace:
-				spec.Namespaces = append(spec.Namespaces, entity)
-			case *matter.Bitmap:
-				slog.Debug("Found global bitmap", "name", entity.Name, "path", doc.Path)
-				spec.addEntityByName(entity.Name, entity, nil)
-				spec.GlobalObjects[entity] = doc
-			case *matter.Enum:
-				slog.Debug("Found global enum", "name", entity.Name, "path", doc.Path)
-				spec.addEntityByName(entity.Name, entity, nil)
-				spec.GlobalObjects[entity] = doc
-			case *matter.Struct:
-				slog.Debug("Found global struct", "name", entity.Name, "path", doc.Path)
-				spec.addEntityByName(entity.Name, entity, nil)
-				spec.GlobalObjects[entity] = doc
-			case *matter.TypeDef:
-				slog.Debug("Found global typedef", "name", entity.Name, "path", doc.Path)
-				spec.addEntityByName(entity.Name, entity, nil)
-				spec.GlobalObjects[entity] = doc
-			case *matter.Command:
-				spec.addEntityByName(entity.Name, entity, nil)
-				spec.GlobalObjects[entity] = doc
-			case *matter.Event:
-				spec.addEntityByName(entity.Name, entity, nil)
-				spec.GlobalObjects[entity] = doc
-
-			default:
-				slog.Warn("unknown entity type", "path", doc.Path, "type", fmt.Sprintf("%T", entity))
-
 			}
+			sp.addEntity(doc, result)
 			switch entity := result.(type) {
 			case *matter.ClusterGroup:
 				spec.entityRefs[doc] = append(spec.entityRefs[doc], entity)
@@ -268,6 +214,69 @@ func (sp *Builder) readEntities(spec *Specification, libraries []*Library) (basi
 	return
 }
 
+func (sp *Builder) addEntity(doc *asciidoc.Document, entity any) {
+	spec := sp.Spec
+	switch entity := entity.(type) {
+	case *matter.ClusterGroup:
+		for _, c := range entity.Clusters {
+			sp.addCluster(doc, c)
+		}
+	case *matter.Cluster:
+		sp.addCluster(doc, entity)
+	case *matter.DeviceType:
+		spec.DeviceTypes = append(spec.DeviceTypes, entity)
+		if entity.ID.Valid() {
+			if existing, ok := spec.DeviceTypesByID[entity.ID.Value()]; ok {
+				slog.Error("duplicate device type ID", slog.String("deviceTypeId", entity.ID.HexString()), log.Path("previousSource", existing), log.Path("newSource", entity))
+				spec.addError(&DuplicateEntityIDError{Entity: entity, Previous: existing})
+			} else {
+				spec.DeviceTypesByID[entity.ID.Value()] = entity
+			}
+
+		}
+		existing, ok := spec.DeviceTypesByName[entity.Name]
+		if ok {
+			slog.Warn("Duplicate Device Type Name", slog.String("deviceTypeId", entity.ID.HexString()), slog.String("deviceTypeName", entity.Name), slog.String("existingDeviceTypeId", existing.ID.HexString()))
+			spec.addError(&DuplicateEntityNameError{Entity: entity, Previous: existing})
+		}
+		spec.DeviceTypesByName[entity.Name] = entity
+		switch entity.Name {
+		case "Root Node":
+			spec.RootNodeDeviceType = entity
+		case "Base Device Type":
+			spec.BaseDeviceType = entity
+		}
+	case *matter.Namespace:
+		spec.Namespaces = append(spec.Namespaces, entity)
+	case *matter.Bitmap:
+		slog.Debug("Found global bitmap", "name", entity.Name, "path", doc.Path)
+		spec.addEntityByName(entity.Name, entity, nil)
+		spec.GlobalObjects[entity] = doc
+	case *matter.Enum:
+		slog.Debug("Found global enum", "name", entity.Name, "path", doc.Path)
+		spec.addEntityByName(entity.Name, entity, nil)
+		spec.GlobalObjects[entity] = doc
+	case *matter.Struct:
+		slog.Debug("Found global struct", "name", entity.Name, "path", doc.Path)
+		spec.addEntityByName(entity.Name, entity, nil)
+		spec.GlobalObjects[entity] = doc
+	case *matter.TypeDef:
+		slog.Debug("Found global typedef", "name", entity.Name, "path", doc.Path)
+		spec.addEntityByName(entity.Name, entity, nil)
+		spec.GlobalObjects[entity] = doc
+	case *matter.Command:
+		spec.addEntityByName(entity.Name, entity, nil)
+		spec.GlobalObjects[entity] = doc
+	case *matter.Event:
+		spec.addEntityByName(entity.Name, entity, nil)
+		spec.GlobalObjects[entity] = doc
+
+	default:
+		slog.Warn("unknown entity type", "path", doc.Path, "type", fmt.Sprintf("%T", entity))
+
+	}
+}
+
 func (spec *Specification) BuildClusterReferences() {
 	TraverseEntities(spec, func(parentCluster *matter.Cluster, parent, entity types.Entity) parse.SearchShould {
 		if parentCluster != nil {
@@ -51,6 +51,14 @@ func (spec *Specification) ComposeDeviceType(deviceType *matter.DeviceType) (com
 			dter.Origin = subsetOrigin
 			composition.ElementRequirements = append(composition.ElementRequirements, dter)
 		}
+
+		for _, tr := range subsetDeviceTypeComposition.TagRequirements {
+			tr = tr.Clone()
+			if tr.RequirementOrigin != matter.RequirementOriginBaseDeviceType {
+				tr.RequirementOrigin = matter.RequirementOriginSubsetDeviceType
+			}
+			composition.TagRequirements = append(composition.TagRequirements, tr)
+		}
 	}
 
 	for _, dtr := range deviceType.DeviceTypeRequirements {
@@ -101,6 +109,14 @@ func (spec *Specification) ComposeDeviceType(deviceType *matter.DeviceType) (com
 					dter.Origin = origin
 					composition.ElementRequirements = append(composition.ElementRequirements, dter)
 				}
+				for _, tr := range dr.TagRequirements {
+					tr = tr.Clone()
+					if tr.RequirementOrigin != matter.RequirementOriginBaseDeviceType {
+						tr.RequirementOrigin = matter.RequirementOriginComposedDeviceType
+					}
+					tr.DeviceTypeRequirement = req
+					composition.TagRequirements = append(composition.TagRequirements, tr)
+				}
 			}
 		}
 	}
@@ -157,6 +173,31 @@ func (spec *Specification) ComposeDeviceType(deviceType *matter.DeviceType) (com
 		comp.ElementRequirements = append(comp.ElementRequirements, er)
 	}
 
+	for _, tr := range deviceType.ComposedDeviceTagRequirements {
+		if tr.TagRequirement == nil {
+			continue
+		}
+		if tr.DeviceType == nil || tr.DeviceTypeRequirement == nil {
+			slog.Warn("Tag requirement on composed device type missing device type", log.Path("source", tr))
+			continue
+		}
+		var comp *matter.DeviceTypeComposition
+		switch tr.DeviceTypeRequirement.Location {
+		case matter.DeviceTypeRequirementLocationDeviceEndpoint:
+			comp = composition
+		default:
+			var ok bool
+			comp, ok = composedDevices[tr.DeviceType]
+			if !ok {
+				slog.Warn("Tag requirement on composed device type not found", log.Path("source", tr))
+				continue
+			}
+		}
+		tr = tr.Clone()
+		tr.RequirementOrigin = matter.RequirementOriginComposedDeviceType
+		comp.TagRequirements = append(comp.TagRequirements, tr)
+	}
+
 	var origin = matter.RequirementOriginDeviceType
 	if deviceType == spec.BaseDeviceType {
 		origin = matter.RequirementOriginBaseDeviceType
@@ -183,6 +224,24 @@ func (spec *Specification) ComposeDeviceType(deviceType *matter.DeviceType) (com
 		composition.ElementRequirements = append(composition.ElementRequirements, dter)
 	}
 
+	for _, tr := range deviceType.TagRequirements {
+		dttr := matter.NewDeviceTypeTagRequirement(deviceType, tr.Source())
+		dttr.TagRequirement = tr
+		dttr.DeviceType = deviceType
+		dttr.RequirementOrigin = origin
+		composition.TagRequirements = append(composition.TagRequirements, dttr)
+	}
+
 	spec.deviceTypeCompositionCache[deviceType] = composition
 	return
 }
+
+// ResolveDeviceType returns the effective requirements on each endpoint of a device type, with the requirements
+// of its base, subset and composed device types merged by origin priority
+func (spec *Specification) ResolveDeviceType(deviceType *matter.DeviceType) (*matter.ResolvedEndpoint, error) {
+	composition, err := spec.ComposeDeviceType(deviceType)
+	if err != nil {
+		return nil, err
+	}
+	return matter.ResolveDeviceTypeComposition(composition), nil
+}
@@ -90,7 +90,7 @@ func (sp *Builder) resolveClusterDataTypeReferences(onlyBaseClusters bool) {
 		clusterFinder := newClusterEntityFinder(cluster, specEntityFinder)
 
 		library, ok := sp.Spec.LibraryRefs[cluster]
-		if !ok {
+		if !ok && !sp.imported {
 			continue
 		}
 
@@ -125,7 +125,7 @@ func (sp *Builder) resolveGlobalDataTypeReferences() {
 	specEntityFinder := newSpecEntityFinder(sp.Spec, nil, nil)
 	for o := range sp.Spec.GlobalObjects {
 		library, ok := sp.Spec.LibraryRefs[o]
-		if !ok {
+		if !ok && !sp.imported {
 			continue
 		}
 		switch s := o.(type) {
@@ -267,6 +267,9 @@ func (sp *Builder) getCustomDataType(library *Library, dataType *types.DataType,
 }
 
 func (sp *Builder) getCustomDataTypeFromFieldReference(library *Library, cluster *matter.Cluster, reference *asciidoc.CrossReference, finder entityFinder) (e types.Entity) {
+	if library == nil {
+		return
+	}
 
 	referenceID := library.elementIdentifier(library, reference, reference, reference.ID)
 	var label string
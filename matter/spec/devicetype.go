@@ -510,6 +510,9 @@ func findDeviceTypeRequirementDeviceType(spec *Specification, id *matter.Number,
 }
 
 func buildReferencedClusters(deviceType *matter.DeviceType, referencedClusters map[*matter.Cluster]struct{}) {
+	if deviceType == nil {
+		return
+	}
 	parent := deviceType.SubsetDeviceType
 	if parent != nil {
 		buildReferencedClusters(parent, referencedClusters)
@@ -671,34 +674,34 @@ func validateElementRequirement(spec *Specification, dt *matter.DeviceType, er *
 	_, ok := referencedClusters[er.Cluster]
 	if !ok {
 		slog.Error("Element Requirement references non-required cluster", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName), log.Path("source", er))
-		spec.addError(ElementRequirementUnreferencedClusterError{Requirement: er})
+		spec.addError(&ElementRequirementUnreferencedClusterError{Requirement: er})
 		return
 	}
 	switch er.Element {
 	case types.EntityTypeAttribute:
 		if er.Entity == nil {
 			slog.Error("Element Requirement references unknown attribute", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName), slog.String("attributeName", er.Name), log.Path("source", er))
-			spec.addError(ElementRequirementUnknownElementError{Requirement: er})
+			spec.addError(&ElementRequirementUnknownElementError{Requirement: er})
 		}
 	case types.EntityTypeFeature:
 		if er.Entity == nil {
 			slog.Error("Element Requirement references unknown feature", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName), slog.String("featureName", er.Name), log.Path("source", er))
-			spec.addError(ElementRequirementUnknownElementError{Requirement: er})
+			spec.addError(&ElementRequirementUnknownElementError{Requirement: er})
 		}
 	case types.EntityTypeCommand:
 		if er.Entity == nil {
 			slog.Error("Element Requirement references unknown command", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName), slog.String("commandName", er.Name), log.Path("source", er))
-			spec.addError(ElementRequirementUnknownElementError{Requirement: er})
+			spec.addError(&ElementRequirementUnknownElementError{Requirement: er})
 		}
 	case types.EntityTypeCommandField:
 		if er.Entity == nil {
 			slog.Error("Element Requirement references unknown command field", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName), slog.String("commandName", er.Name), slog.String("commandField", er.Field), log.Path("source", er))
-			spec.addError(ElementRequirementUnknownElementError{Requirement: er})
+			spec.addError(&ElementRequirementUnknownElementError{Requirement: er})
 		}
 	case types.EntityTypeEvent:
 		if er.Entity == nil {
 			slog.Error("Element Requirement references unknown event", slog.String("deviceType", dt.Name), slog.String("clusterId", er.ClusterID.HexString()), slog.String("clusterName", er.ClusterName), slog.String("commandName", er.Name), log.Path("source", er))
-			spec.addError(ElementRequirementUnknownElementError{Requirement: er})
+			spec.addError(&ElementRequirementUnknownElementError{Requirement: er})
 		}
 	default:
 		slog.Error("Unknown entity type", slog.String("entityType", er.Element.String()))
@@ -1,7 +1,10 @@
 package spec
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/project-chip/alchemy/internal/log"
@@ -57,6 +60,78 @@ const (
 	ErrorTypeConformanceChoiceMismatch
 )
 
+// String returns the stable rule identifier for the error type
+func (et ErrorType) String() string {
+	if name, ok := errorTypeNames[et]; ok {
+		return name
+	}
+	return errorTypeNames[ErrorTypeUnknown]
+}
+
+func (et ErrorType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(et.String())
+}
+
+func ParseErrorType(s string) (ErrorType, bool) {
+	for et, name := range errorTypeNames {
+		if strings.EqualFold(name, s) {
+			return et, true
+		}
+	}
+	return ErrorTypeUnknown, false
+}
+
+// ErrorTypes returns every named error type, in order
+func ErrorTypes() []ErrorType {
+	ets := slices.Collect(maps.Keys(errorTypeNames))
+	slices.Sort(ets)
+	return ets
+}
+
+var errorTypeNames = map[ErrorType]string{
+	ErrorTypeUnknown:                                                     "unknown",
+	ErrorTypeGenericParse:                                                "generic-parse",
+	ErrorTypeDuplicateEntityID:                                           "duplicate-entity-id",
+	ErrorTypeDuplicateEntityName:                                         "duplicate-entity-name",
+	ErrorTypeUnknownConstraintIdentifier:                                 "unknown-constraint-identifier",
+	ErrorTypeUnknownConstraintReference:                                  "unknown-constraint-reference",
+	ErrorTypeUnknownCustomDataType:                                       "unknown-custom-data-type",
+	ErrorTypeUnknownSuperset:                                             "unknown-superset",
+	ErrorTypeUnknownClusterRequirement:                                   "unknown-cluster-requirement",
+	ErrorTypeUnknownElementRequirementCluster:                            "unknown-element-requirement-cluster",
+	ErrorTypeElementRequirementUnreferencedCluster:                       "element-requirement-unreferenced-cluster",
+	ErrorTypeElementRequirementUnknownElement:                            "element-requirement-unknown-element",
+	ErrorTypeComposingDeviceTypeRequirementUnknownDeviceType:             "composing-device-type-requirement-unknown-device-type",
+	ErrorTypeComposingDeviceTypeClusterRequirementUnknownCluster:         "composing-device-type-cluster-requirement-unknown-cluster",
+	ErrorTypeComposingDeviceTypeClusterRequirementUnknownDeviceType:      "composing-device-type-cluster-requirement-unknown-device-type",
+	ErrorTypeComposingDeviceTypeClusterRequirementUnreferencedDeviceType: "composing-device-type-cluster-requirement-unreferenced-device-type",
+	ErrorTypeComposingDeviceTypeElementRequirementUnknownCluster:         "composing-device-type-element-requirement-unknown-cluster",
+	ErrorTypeComposingDeviceTypeElementRequirementUnknownDeviceType:      "composing-device-type-element-requirement-unknown-device-type",
+	ErrorTypeComposingDeviceTypeElementRequirementUnreferencedDeviceType: "composing-device-type-element-requirement-unreferenced-device-type",
+	ErrorTypeConditionRequirementUnknownDeviceType:                       "condition-requirement-unknown-device-type",
+	ErrorTypeConditionRequirementUnreferencedDeviceType:                  "condition-requirement-unreferenced-device-type",
+	ErrorTypeConditionRequirementUnknownCondition:                        "condition-requirement-unknown-condition",
+	ErrorTypeTagRequirementUnreferencedDeviceType:                        "tag-requirement-unreferenced-device-type",
+	ErrorTypeTagRequirementUnknownNamespace:                              "tag-requirement-unknown-namespace",
+	ErrorTypeTagRequirementNamespaceNameMismatch:                         "tag-requirement-namespace-name-mismatch",
+	ErrorTypeTagRequirementUnknownTag:                                    "tag-requirement-unknown-tag",
+	ErrorTypeTagRequirementTagNameMismatch:                               "tag-requirement-tag-name-mismatch",
+	ErrorTypeClusterReferenceNameMismatch:                                "cluster-reference-name-mismatch",
+	ErrorTypeDeviceTypeReferenceNameMismatch:                             "device-type-reference-name-mismatch",
+	ErrorTypeNamespaceNameMismatch:                                       "namespace-name-mismatch",
+	ErrorTypeUnknownBaseCluster:                                          "unknown-base-cluster",
+	ErrorTypeUnknownConformanceIdentifier:                                "unknown-conformance-identifier",
+	ErrorTypeUnknownConformanceReference:                                 "unknown-conformance-reference",
+	ErrorTypeFabricScopingNotAllowed:                                     "fabric-scoping-not-allowed",
+	ErrorTypeFabricSensitivityNotAllowed:                                 "fabric-sensitivity-not-allowed",
+	ErrorTypeFabricScopedStructNotAllowed:                                "fabric-scoped-struct-not-allowed",
+	ErrorTypeInvalidConformance:                                          "invalid-conformance",
+	ErrorTypeInvalidConstraint:                                           "invalid-constraint",
+	ErrorTypeInvalidFallback:                                             "invalid-fallback",
+	ErrorTypeConformanceChoiceOrphan:                                     "conformance-choice-orphan",
+	ErrorTypeConformanceChoiceMismatch:                                   "conformance-choice-mismatch",
+}
+
 type Error interface {
 	Type() ErrorType
 	Error() string
@@ -719,7 +794,7 @@ type InvalidFallbackError struct {
 }
 
 func (ifbe InvalidFallbackError) Type() ErrorType {
-	return ErrorTypeInvalidConstraint
+	return ErrorTypeInvalidFallback
 }
 
 func (ifbe InvalidFallbackError) Origin() (path string, line int) {
@@ -794,3 +869,125 @@ func (mcee *MissingClonedEntityError) Origin() (path string, line int) {
 func (mcee *MissingClonedEntityError) Error() string {
 	return fmt.Sprintf("failed to find local clone of entity %s for field %s", matter.EntityName(mcee.Entity), mcee.Field.Name)
 }
+
+// ErrorEntity returns the entity responsible for an error, if one can be determined
+func ErrorEntity(e Error) types.Entity {
+	switch subject := errorSubject(e).(type) {
+	case types.Entity:
+		return subject
+	case *matter.DeviceTypeClusterRequirement:
+		if subject != nil && subject.ClusterRequirement != nil {
+			return subject.ClusterRequirement
+		}
+	case *matter.DeviceTypeElementRequirement:
+		if subject != nil && subject.ElementRequirement != nil {
+			return subject.ElementRequirement
+		}
+	case *matter.DeviceTypeTagRequirement:
+		if subject != nil {
+			return subject.Parent()
+		}
+	case *matter.TagRequirement:
+		if subject != nil {
+			return subject.Parent()
+		}
+	}
+	if entity, ok := errorSource(e).(types.Entity); ok {
+		return entity
+	}
+	return nil
+}
+
+func errorSubject(e Error) any {
+	switch e := e.(type) {
+	case *DuplicateEntityIDError:
+		return e.Entity
+	case *DuplicateEntityNameError:
+		return e.Entity
+	case *UnknownCustomDataTypeError:
+		return e.Field
+	case *UnknownSupersetError:
+		return e.DeviceType
+	case *UnknownClusterRequirementError:
+		return e.Requirement
+	case *UnknownElementRequirementClusterError:
+		return e.Requirement
+	case *ElementRequirementUnreferencedClusterError:
+		return e.Requirement
+	case *ElementRequirementUnknownElementError:
+		return e.Requirement
+	case *UnknownConditionRequirementDeviceTypeError:
+		return e.Requirement
+	case *UnreferencedConditionRequirementDeviceTypeError:
+		return e.Requirement
+	case *UnknownConditionRequirementConditionError:
+		return e.Requirement
+	case *UnknownComposingDeviceTypeRequirementDeviceTypeError:
+		return e.Requirement
+	case *UnknownComposingDeviceTypeRequirementClusterError:
+		return e.Requirement
+	case *UnknownComposingDeviceTypeClusterRequirementDeviceTypeError:
+		return e.Requirement
+	case *UnreferencedComposingDeviceTypeClusterRequirementDeviceTypeError:
+		return e.Requirement
+	case *UnknownComposingElementRequirementClusterError:
+		return e.Requirement
+	case *UnknownComposingDeviceTypeElementRequirementDeviceTypeError:
+		return e.Requirement
+	case *UnreferencedComposingDeviceTypeElementRequirementDeviceTypeError:
+		return e.Requirement
+	case *UnknownComposingDeviceTypeTagRequirementDeviceTypeError:
+		return e.Requirement
+	case *UnreferencedTagRequirementDeviceTypeError:
+		return e.Requirement
+	case *UnknownNamespaceTagRequirementError:
+		return e.Requirement
+	case *NamespaceNameMismatchTagRequirementError:
+		return e.Requirement
+	case *UnknownTagRequirementError:
+		return e.Requirement
+	case *TagNameMismatchTagRequirementError:
+		return e.Requirement
+	case *UnknownBaseClusterError:
+		return e.Cluster
+	case *ClusterReferenceNameMismatch:
+		return e.Cluster
+	case *DeviceTypeReferenceNameMismatch:
+		return e.DeviceType
+	case *UnknownConformanceIdentifierError:
+		return e.Entity
+	case *UnknownConformanceReferenceError:
+		return e.Entity
+	case *FabricScopingNotAllowedError:
+		return e.Entity
+	case *FabricSensitivityNotAllowedError:
+		return e.Entity
+	case *FabricScopedStructNotAllowedError:
+		return e.Entity
+	case *ConformanceChoiceMismatchError:
+		return e.Entity
+	case *MissingClonedEntityError:
+		return e.Field
+	}
+	return nil
+}
+
+func errorSource(e Error) log.Source {
+	switch e := e.(type) {
+	case *GenericParseError:
+		return e.Source
+	case *UnknownConstraintIdentifierError:
+		return e.Source
+	case *UnknownConstraintReferenceError:
+		return e.Source
+	case *InvalidConformanceError:
+		return e.Source
+	case *InvalidConstraintError:
+		return e.Source
+	case *InvalidFallbackError:
+		return e.Source
+	case *ConformanceChoiceOrphanError:
+		return e.Source
+	}
+	return nil
+}
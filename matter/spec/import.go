@@ -0,0 +1,71 @@
+package spec
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/config"
+	"github.com/project-chip/alchemy/errata"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// Import builds a specification from entities that were read from a source other than the AsciiDoc spec,
+// such as the SDK's ZAP templates. Each document stands in for the file its entities were read from;
+// the resulting specification has no libraries, but otherwise resolves references, conformance,
+// constraints and device type requirements the same way a parsed spec does.
+func Import(cxt context.Context, root string, cfg *config.Config, ec *errata.Collection, entities map[*asciidoc.Document][]types.Entity, options ...BuilderOption) (spec *Specification, err error) {
+	sp := NewBuilder(root, cfg, ec, options...)
+	sp.imported = true
+	sp.Spec = newSpec(root, cfg, ec)
+	spec = sp.Spec
+
+	docs := make([]*asciidoc.Document, 0, len(entities))
+	for doc := range entities {
+		docs = append(docs, doc)
+	}
+	slices.SortStableFunc(docs, func(a *asciidoc.Document, b *asciidoc.Document) int {
+		return strings.Compare(a.Path.Relative, b.Path.Relative)
+	})
+
+	for _, doc := range docs {
+		spec.Docs[doc.Path.Relative] = doc
+		for _, entity := range entities[doc] {
+			sp.addEntity(doc, entity)
+			switch entity := entity.(type) {
+			case *matter.ClusterGroup:
+				spec.entityRefs[doc] = append(spec.entityRefs[doc], entity)
+				for _, c := range entity.Clusters {
+					sp.noteDocRefs(doc, c)
+				}
+			default:
+				spec.entityRefs[doc] = append(spec.entityRefs[doc], entity)
+				sp.noteDocRefs(doc, entity)
+			}
+		}
+	}
+
+	sp.resolveClusterDataTypeReferences(true)
+	sp.resolveGlobalDataTypeReferences()
+
+	err = spec.associateDeviceTypeRequirements()
+	if err != nil {
+		return
+	}
+
+	sp.resolveClusterDataTypeReferences(false)
+
+	sp.ResolveConformances()
+	sp.resolveConstraints()
+
+	spec.BuildClusterReferences()
+	spec.BuildDataTypeReferences()
+
+	sp.noteConformanceResolutionFailures(spec)
+	sp.noteConstraintResolutionFailures(spec)
+
+	Validate(spec)
+	return
+}
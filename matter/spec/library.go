@@ -237,3 +237,37 @@ func dumpLibrarySection(library *Library, section *asciidoc.Section, indent int)
 	}
 
 }
+
+// Dependents returns the absolute paths of the documents at paths, along with every document which
+// includes any of them, directly or indirectly
+func (spec *Specification) Dependents(paths ...string) (dependents []string) {
+	seen := make(map[string]struct{})
+	for _, path := range paths {
+		if _, ok := seen[path]; ok {
+			continue
+		}
+		seen[path] = struct{}{}
+		dependents = append(dependents, path)
+	}
+	for _, library := range spec.Libraries {
+		for _, doc := range library.Docs {
+			if _, ok := seen[doc.Path.Absolute]; !ok {
+				continue
+			}
+			queue := []*asciidoc.Document{doc}
+			for len(queue) > 0 {
+				d := queue[0]
+				queue = queue[1:]
+				for _, parent := range library.Parents(d) {
+					if _, ok := seen[parent.Path.Absolute]; ok {
+						continue
+					}
+					seen[parent.Path.Absolute] = struct{}{}
+					dependents = append(dependents, parent.Path.Absolute)
+					queue = append(queue, parent)
+				}
+			}
+		}
+	}
+	return
+}
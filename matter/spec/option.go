@@ -2,8 +2,10 @@ package spec
 
 import (
 	"fmt"
+	"log/slog"
 	"path/filepath"
 
+	"github.com/project-chip/alchemy/asciidoc/parse"
 	"github.com/project-chip/alchemy/internal/files"
 )
 
@@ -34,6 +36,10 @@ type ParserOptions struct {
 	Root              string `name:"spec-root" default:"connectedhomeip-spec" aliases:"specRoot" help:"the src root of your clone of CHIP-Specifications/connectedhomeip-spec" group:"Spec:"`
 	ErrataPath        string `name:"errata-path" help:"the path to the errata file" group:"Spec:"`
 	ErrataOverlayPath string `name:"errata-overlay" help:"the path to the errata overlay file" group:"Spec:"`
+	ParseCache        string `name:"parse-cache" help:"cache parsed documents in this directory, so that later runs only parse documents which have changed" group:"Spec:"`
+
+	// Ref, if set, is the git ref of the repository containing Root to read the spec at, rather than its working tree
+	Ref string `kong:"-"`
 }
 
 func (po *ParserOptions) AfterApply() error {
@@ -81,9 +87,28 @@ func (po *ParserOptions) AfterApply() error {
 			return fmt.Errorf("errata overlay path \"%s\" does not exist", po.ErrataOverlayPath)
 		}
 	}
+	if po.ParseCache != "" && !filepath.IsAbs(po.ParseCache) {
+		po.ParseCache, err = filepath.Abs(po.ParseCache)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// Cache returns the parse cache selected by the options, or nil if no cache directory was given
+func (po ParserOptions) Cache() *parse.Cache {
+	if po.ParseCache == "" {
+		return nil
+	}
+	cache, err := parse.NewCache(po.ParseCache)
+	if err != nil {
+		slog.Warn("Unable to open parse cache; parsing every document", slog.String("path", po.ParseCache), slog.Any("error", err))
+		return nil
+	}
+	return cache
+}
+
 type FilterOptions struct {
 	Paths         []string `arg:"" optional:"" help:"Paths of AsciiDoc files to use for generation" group:"Spec:"`
 	Exclude       []string `short:"e"  help:"exclude files matching this file pattern" group:"Spec:"`
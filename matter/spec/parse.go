@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/asciidoc/parse"
 	"github.com/project-chip/alchemy/internal/pipeline"
 )
 
@@ -31,10 +32,11 @@ import (
 */
 type Reader struct {
 	options ParserOptions
+	cache   *parse.Cache
 }
 
 func NewReader(parserOptions ParserOptions) (Reader, error) {
-	return Reader{options: parserOptions}, nil
+	return Reader{options: parserOptions, cache: parserOptions.Cache()}, nil
 }
 
 func (p Reader) Name() string {
@@ -53,7 +55,7 @@ func (p Reader) Process(cxt context.Context, input *pipeline.Data[struct{}], ind
 		return
 	}
 	var doc *asciidoc.Document
-	doc, err = readFile(path.Absolute, p.options.Root)
+	doc, err = readFile(path.Absolute, p.options.Root, p.cache)
 	if err != nil {
 		return
 	}
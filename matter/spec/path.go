@@ -38,6 +38,20 @@ func NewSpecPath(path string, rootPath string) (asciidoc.Path, error) {
 	return p, err
 }
 
+// RelativePath returns an absolute path relative to the spec root, with forward slashes; paths which are already
+// relative or outside of the root are left as they are
+func RelativePath(root string, path string) string {
+	if path == "" {
+		return path
+	}
+	if root != "" && filepath.IsAbs(path) {
+		if rel, err := filepath.Rel(root, path); err == nil {
+			path = rel
+		}
+	}
+	return filepath.ToSlash(path)
+}
+
 func deriveSpecPath(path string) string {
 	if !filepath.IsAbs(path) {
 		var err error
@@ -9,8 +9,19 @@ import (
 	"github.com/project-chip/alchemy/internal/pipeline"
 )
 
+// Parse loads and builds the spec described by parserOptions. If parserOptions.Ref is set, the spec is read from a
+// temporary worktree which is removed before Parse returns, so the paths of the returned documents no longer exist;
+// callers which need the files at a ref, such as to patch them, should use LoadSpecPullRequest, which keeps its
+// worktrees until they're cleaned up
 func Parse(cxt context.Context, parserOptions ParserOptions, processingOptions pipeline.ProcessingOptions, builderOptions []BuilderOption, attributes []asciidoc.AttributeName) (specification *Specification, specDocs DocSet, err error) {
 
+	var remove func()
+	parserOptions, remove, err = checkoutRef(cxt, parserOptions)
+	if err != nil {
+		return
+	}
+	defer remove()
+
 	specDocs, err = LoadSpecDocs(cxt, parserOptions, processingOptions)
 	if err != nil {
 		return
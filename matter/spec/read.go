@@ -31,7 +31,7 @@ func ReadFile(path string, rootPath string) (*asciidoc.Document, error) {
 	return parse.Bytes(p, b)
 }
 
-func readFile(path string, rootPath string) (*asciidoc.Document, error) {
+func readFile(path string, rootPath string, cache *parse.Cache) (*asciidoc.Document, error) {
 
 	var p asciidoc.Path
 	p, err := asciidoc.NewPath(path, rootPath)
@@ -48,7 +48,7 @@ func readFile(path string, rootPath string) (*asciidoc.Document, error) {
 	if err != nil {
 		return nil, err
 	}
-	return parse.Raw(p, b)
+	return cache.Raw(p, b)
 }
 
 func readString(contents string, path string, rootPath string) (doc *asciidoc.Document, err error) {
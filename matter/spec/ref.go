@@ -0,0 +1,30 @@
+package spec
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/project-chip/alchemy/internal/vcs"
+)
+
+// checkoutRef checks the spec out at parserOptions.Ref into a temporary worktree, if a ref is given, and returns
+// parser options rooted in the worktree, along with a function which removes it again; the paths of documents read
+// from the worktree are only valid until it is removed, so the caller decides how long it lives
+func checkoutRef(cxt context.Context, parserOptions ParserOptions) (ParserOptions, func(), error) {
+	if parserOptions.Ref == "" {
+		return parserOptions, func() {}, nil
+	}
+	root, remove, err := vcs.AddWorktree(parserOptions.Root, parserOptions.Ref)
+	if err != nil {
+		return parserOptions, nil, fmt.Errorf("error reading spec at %s: %w", parserOptions.Ref, err)
+	}
+	slog.InfoContext(cxt, "Reading spec from Git", "ref", parserOptions.Ref, "root", root)
+	parserOptions.Root = root
+	parserOptions.Ref = ""
+	return parserOptions, func() {
+		if err := remove(); err != nil {
+			slog.WarnContext(cxt, "Unable to remove worktree", "root", root, "error", err)
+		}
+	}, nil
+}
@@ -12,11 +12,31 @@ type SpecPullRequest struct {
 	BaseInProgress *Specification
 	Head           *Specification
 	HeadInProgress *Specification
+
+	// BaseRoot and HeadRoot are the roots each version was read from; for a version read at a ref, this is the
+	// temporary worktree it was checked out into
+	BaseRoot string
+	HeadRoot string
+}
+
+// PullRequestOptions locate the two versions of the spec a comparison is made between; each may be a clone's
+// working tree, or a ref within it, so that both versions can be read from a single clone
+type PullRequestOptions struct {
+	HeadRoot string `name:"head-root" default:"connectedhomeip-spec" help:"the src root of your clone of CHIP-Specifications/connectedhomeip-spec with the changes"`
+	BaseRoot string `name:"base-root" default:"connectedhomeip-spec" help:"the src root of your clone of CHIP-Specifications/connectedhomeip-spec without the changes"`
+	HeadRef  string `name:"head-ref" help:"the git ref of head-root to read the spec at; defaults to the working tree of head-root"`
+	BaseRef  string `name:"base-ref" help:"the git ref of base-root to read the spec at; defaults to the working tree of base-root"`
 }
 
-func loadSpecs(cxt context.Context, pipelineOptions pipeline.ProcessingOptions, specRoot string) (baseSpec *Specification, inProgressSpec *Specification, err error) {
-	parserOptions := ParserOptions{Root: specRoot}
+func (pro PullRequestOptions) Head() ParserOptions {
+	return ParserOptions{Root: pro.HeadRoot, Ref: pro.HeadRef}
+}
+
+func (pro PullRequestOptions) Base() ParserOptions {
+	return ParserOptions{Root: pro.BaseRoot, Ref: pro.BaseRef}
+}
 
+func loadSpecs(cxt context.Context, pipelineOptions pipeline.ProcessingOptions, parserOptions ParserOptions) (baseSpec *Specification, inProgressSpec *Specification, err error) {
 	var specDocs DocSet
 	specDocs, err = LoadSpecDocs(cxt, parserOptions, pipelineOptions)
 	if err != nil {
@@ -32,15 +52,43 @@ func loadSpecs(cxt context.Context, pipelineOptions pipeline.ProcessingOptions,
 	return
 }
 
-func LoadSpecPullRequest(cxt context.Context, baseRoot string, headRoot string, pipelineOptions pipeline.ProcessingOptions) (ss SpecPullRequest, err error) {
-	ss.Head, ss.HeadInProgress, err = loadSpecs(cxt, pipelineOptions, headRoot)
+// LoadSpecPullRequest loads the head and base versions of the spec, each with and without in-progress content.
+// Versions read at a ref are checked out into temporary worktrees, which are kept until cleanup is called, so that
+// the files of the specs can still be read once they are loaded; if an error is returned, they have already been
+// removed
+func LoadSpecPullRequest(cxt context.Context, options PullRequestOptions, pipelineOptions pipeline.ProcessingOptions) (ss SpecPullRequest, cleanup func(), err error) {
+	var removeHead, removeBase func()
+	cleanup = func() {
+		if removeBase != nil {
+			removeBase()
+		}
+		if removeHead != nil {
+			removeHead()
+		}
+	}
+	defer func() {
+		if err != nil {
+			cleanup()
+			cleanup = func() {}
+		}
+	}()
+
+	var headOptions, baseOptions ParserOptions
+	headOptions, removeHead, err = checkoutRef(cxt, options.Head())
+	if err != nil {
+		return
+	}
+	ss.HeadRoot = headOptions.Root
+	ss.Head, ss.HeadInProgress, err = loadSpecs(cxt, pipelineOptions, headOptions)
 	if err != nil {
 		return
 	}
 
-	ss.Base, ss.BaseInProgress, err = loadSpecs(cxt, pipelineOptions, baseRoot)
+	baseOptions, removeBase, err = checkoutRef(cxt, options.Base())
 	if err != nil {
 		return
 	}
+	ss.BaseRoot = baseOptions.Root
+	ss.Base, ss.BaseInProgress, err = loadSpecs(cxt, pipelineOptions, baseOptions)
 	return
 }
@@ -17,6 +17,8 @@ const (
 	ViolationNewParseError
 
 	ViolationMasterList
+
+	ViolationRevision
 )
 
 func (vt ViolationType) String() string {
@@ -42,6 +44,12 @@ func (vt ViolationType) String() string {
 		}
 		sb.WriteString("master-list-incompatible")
 	}
+	if (vt & ViolationRevision) != ViolationTypeNone {
+		if sb.Len() > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("revision")
+	}
 	return sb.String()
 }
 
@@ -0,0 +1,175 @@
+package matter
+
+import (
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// ValidateValue checks a value against the data type, nullability and constraint of field, which belongs to fields;
+// struct values, and lists of them, have each of their fields checked in turn. Enum values may be given by name, and
+// octet strings as hex
+//
+// It is the field-aware half of constraint.Validate; see there for why the two are split
+func ValidateValue(field *Field, value any, fields FieldSet) []constraint.Violation {
+	return validateValue(field, value, fields, nil, field.Name)
+}
+
+// ValidateValues checks an object, keyed by field name or ID, against fields; references from the constraint of one
+// field to another are resolved against the value supplied for the other, if there is one
+func ValidateValues(fields FieldSet, values map[string]any) []constraint.Violation {
+	return validateObject(fields, values, false, "")
+}
+
+// ValidateStruct checks an object against the fields of s; fabric-scoped structs may also carry a FabricIndex
+func ValidateStruct(s *Struct, values map[string]any) []constraint.Violation {
+	return validateObject(s.Fields, values, s.FabricScoping == FabricScopingScoped, "")
+}
+
+func validateObject(fields FieldSet, values map[string]any, fabricScoped bool, path string) (violations []constraint.Violation) {
+	fieldValues := make(map[*Field]any, len(values))
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		field := fieldByKey(fields, key)
+		if field == nil {
+			if fabricScoped && isFabricIndexKey(key) {
+				continue
+			}
+			violations = append(violations, constraint.Violation{Type: constraint.ViolationTypeUnknownField, Path: joinPath(path, key), Message: fmt.Sprintf("unknown field %s", key)})
+			continue
+		}
+		fieldValues[field] = normalizeValue(field, values[key])
+	}
+	for _, field := range fields {
+		value, ok := fieldValues[field]
+		if !ok {
+			if conformance.IsMandatory(field.Conformance) {
+				violations = append(violations, constraint.Violation{Type: constraint.ViolationTypeMissingField, Path: joinPath(path, field.Name), Message: "is mandatory, but is missing"})
+			}
+			continue
+		}
+		violations = append(violations, validateValue(field, value, fields, fieldValues, joinPath(path, field.Name))...)
+	}
+	return
+}
+
+func validateValue(field *Field, value any, fields FieldSet, values map[*Field]any, path string) (violations []constraint.Violation) {
+	if field.Type == nil {
+		return
+	}
+	value = normalizeValue(field, value)
+	cc := NewConstraintContext(field, fields)
+	cc.Values = values
+	for _, v := range constraint.Validate(cc, field.Constraint, value) {
+		v.Path = path + v.Path
+		violations = append(violations, v)
+	}
+
+	if field.Type.IsArray() {
+		if field.Type.EntryType == nil {
+			return
+		}
+		s, ok := field.Type.EntryType.Entity.(*Struct)
+		list, isList := value.([]any)
+		if !ok || !isList {
+			return
+		}
+		for i, entry := range list {
+			if o, ok := entry.(map[string]any); ok {
+				violations = append(violations, validateObject(s.Fields, o, s.FabricScoping == FabricScopingScoped, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+		return
+	}
+	if s, ok := field.Type.Entity.(*Struct); ok {
+		switch o := value.(type) {
+		case map[string]any:
+			violations = append(violations, validateObject(s.Fields, o, s.FabricScoping == FabricScopingScoped, path)...)
+		case nil:
+		default:
+			violations = append(violations, constraint.Violation{Type: constraint.ViolationTypeDataType, Path: path, Value: value, Message: fmt.Sprintf("has value %v; expected an object", value)})
+		}
+	}
+	return
+}
+
+// normalizeValue converts enum names into their values and hex octet strings into bytes, so they can be compared
+// against constraints; values which can not be converted are left alone to be reported by validation
+func normalizeValue(field *Field, value any) any {
+	if field.Type == nil {
+		return value
+	}
+	if field.Type.IsArray() {
+		list, ok := value.([]any)
+		if !ok || field.Type.EntryType == nil {
+			return value
+		}
+		entryField := &Field{Type: field.Type.EntryType}
+		normalized := make([]any, len(list))
+		for i, entry := range list {
+			normalized[i] = normalizeValue(entryField, entry)
+		}
+		return normalized
+	}
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+	if e, ok := field.Type.Entity.(*Enum); ok {
+		for _, ev := range e.Values {
+			if strings.EqualFold(ev.Name, s) && ev.Value.Valid() {
+				return ev.Value.Value()
+			}
+		}
+		return value
+	}
+	if field.Type.BaseType == types.BaseDataTypeOctStr {
+		b, err := hex.DecodeString(s)
+		if err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+func fieldByKey(fields FieldSet, key string) *Field {
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, key) {
+			return f
+		}
+	}
+	id := ParseNumber(key)
+	if !id.Valid() {
+		return nil
+	}
+	for _, f := range fields {
+		if f.ID.Valid() && f.ID.Value() == id.Value() {
+			return f
+		}
+	}
+	return nil
+}
+
+func isFabricIndexKey(key string) bool {
+	if strings.EqualFold(key, "FabricIndex") {
+		return true
+	}
+	id := ParseNumber(key)
+	return id.Valid() && id.Value() == FabricIndexFieldID
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
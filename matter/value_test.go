@@ -0,0 +1,98 @@
+package matter
+
+import (
+	"testing"
+
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/constraint"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestValidateValue(t *testing.T) {
+	field := NewAttribute(nil, nil)
+	field.Name = "Level"
+	field.Type = types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankScalar)
+	field.Constraint = constraint.ParseString("1 to 254")
+	fields := FieldSet{field}
+
+	tests := []struct {
+		value      any
+		violations []constraint.ViolationType
+	}{
+		{uint64(1), nil},
+		{uint64(254), nil},
+		{uint64(0), []constraint.ViolationType{constraint.ViolationTypeBelowMinimum}},
+		{uint64(255), []constraint.ViolationType{constraint.ViolationTypeAboveMaximum}},
+		{int64(-1), []constraint.ViolationType{constraint.ViolationTypeBelowMinimum}},
+		{"high", []constraint.ViolationType{constraint.ViolationTypeDataType}},
+		{nil, []constraint.ViolationType{constraint.ViolationTypeNull}},
+	}
+	for _, test := range tests {
+		checkViolations(t, test.value, ValidateValue(field, test.value, fields), test.violations)
+	}
+
+	field.Quality = QualityNullable
+	checkViolations(t, nil, ValidateValue(field, nil, fields), nil)
+
+	field.Quality = QualityNone
+	field.Constraint = constraint.ParseString("0, 5 to 10")
+	checkViolations(t, uint64(3), ValidateValue(field, uint64(3), fields), []constraint.ViolationType{constraint.ViolationTypeNotAllowed})
+	checkViolations(t, uint64(7), ValidateValue(field, uint64(7), fields), nil)
+
+	list := NewAttribute(nil, nil)
+	list.Name = "Names"
+	list.Type = types.NewDataType(types.BaseDataTypeString, types.DataTypeRankList)
+	list.Constraint = constraint.ParseString("max 2[max 3]")
+	value := []any{"abc", "abcd", "ab"}
+	violations := ValidateValue(list, value, FieldSet{list})
+	checkViolations(t, value, violations, []constraint.ViolationType{constraint.ViolationTypeTooLong, constraint.ViolationTypeTooLong})
+	if len(violations) == 2 && violations[1].Path != "Names[1]" {
+		t.Errorf("expected path Names[1], got %s", violations[1].Path)
+	}
+}
+
+func TestValidateValues(t *testing.T) {
+	min := newValueField(0, "Min", types.BaseDataTypeUInt8)
+	max := newValueField(1, "Max", types.BaseDataTypeUInt8)
+	max.Constraint = &constraint.MinConstraint{Minimum: &constraint.IdentifierLimit{ID: "Min", Entity: min}}
+	label := newValueField(2, "Label", types.BaseDataTypeString)
+	label.Constraint = constraint.ParseString("max 4")
+	label.Conformance = conformance.Set{&conformance.Optional{}}
+	fields := FieldSet{min, max, label}
+
+	tests := []struct {
+		values     map[string]any
+		violations []constraint.ViolationType
+	}{
+		{map[string]any{"Min": 2, "Max": 3}, nil},
+		{map[string]any{"0": 2, "1": 2, "Label": "four"}, nil},
+		{map[string]any{"Min": 4, "Max": 3}, []constraint.ViolationType{constraint.ViolationTypeBelowMinimum}},
+		{map[string]any{"Min": 4}, []constraint.ViolationType{constraint.ViolationTypeMissingField}},
+		{map[string]any{"Min": 1, "Max": 1, "Label": "fives", "Bogus": 1}, []constraint.ViolationType{constraint.ViolationTypeUnknownField, constraint.ViolationTypeTooLong}},
+	}
+	for _, test := range tests {
+		checkViolations(t, test.values, ValidateValues(fields, test.values), test.violations)
+	}
+}
+
+func newValueField(id uint64, name string, baseType types.BaseDataType) *Field {
+	field := NewField(nil, nil, types.EntityTypeStructField)
+	field.ID = NewNumber(id)
+	field.Name = name
+	field.Type = types.NewDataType(baseType, types.DataTypeRankScalar)
+	field.Conformance = conformance.Set{&conformance.Mandatory{}}
+	return field
+}
+
+func checkViolations(t *testing.T, value any, violations []constraint.Violation, expected []constraint.ViolationType) {
+	t.Helper()
+	if len(violations) != len(expected) {
+		t.Errorf("value %v: expected %d violations, got %v", value, len(expected), violations)
+		return
+	}
+	for i, v := range violations {
+		if v.Type != expected[i] {
+			t.Errorf("value %v: expected %s violation, got %s", value, expected[i], v.Type)
+		}
+	}
+}
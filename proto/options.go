@@ -0,0 +1,30 @@
+package proto
+
+// OptionsPath is the path, relative to the root of the generated files, of the file declaring the custom options
+// which carry Matter IDs
+const OptionsPath = "matter/options.proto"
+
+// OptionsFile declares the custom options used by generated files; extension numbers are taken from the range
+// reserved for use within an organization
+const OptionsFile = `// Generated by Alchemy; do not edit
+
+syntax = "proto3";
+
+package matter;
+
+import "google/protobuf/descriptor.proto";
+
+extend google.protobuf.FileOptions {
+  uint32 cluster_id = 50000;
+  uint32 cluster_revision = 50001;
+}
+
+extend google.protobuf.MessageOptions {
+  uint32 command_id = 50010;
+  uint32 event_id = 50011;
+}
+
+extend google.protobuf.FieldOptions {
+  uint32 attribute_id = 50020;
+}
+`
@@ -0,0 +1,282 @@
+package proto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/project-chip/alchemy/internal/text"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// Protocol Buffers reserves field numbers 19000 through 19999 for its own use
+const (
+	firstReservedFieldNumber = 19000
+	lastReservedFieldNumber  = 19999
+	maxFieldNumber           = 1<<29 - 1
+)
+
+// RenderCluster generates a proto3 file for a cluster, in the package pkg; its structs, enums, command payloads, event
+// payloads and attributes are each given a message or enum, as are global data types which they refer to. Field
+// numbers are the Matter field IDs plus one, as Protocol Buffers does not allow field number 0
+func RenderCluster(cluster *matter.Cluster, pkg string) string {
+	g := &generator{cluster: cluster, rendered: make(map[types.Entity]struct{})}
+
+	var s strings.Builder
+	s.WriteString("// Generated by Alchemy from the Matter specification; do not edit\n\n")
+	s.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&s, "package %s;\n\n", packageName(pkg, cluster))
+	fmt.Fprintf(&s, "import \"%s\";\n\n", OptionsPath)
+	if cluster.ID.Valid() {
+		fmt.Fprintf(&s, "option (matter.cluster_id) = %s;\n", cluster.ID.HexString())
+	}
+	if r := cluster.Revisions.MostRecent(); r != nil && r.Number.Valid() {
+		fmt.Fprintf(&s, "option (matter.cluster_revision) = %s;\n", r.Number.IntString())
+	}
+
+	for _, e := range cluster.Enums {
+		g.addEntity(e)
+	}
+	for _, st := range cluster.Structs {
+		g.addEntity(st)
+	}
+	for _, cmd := range cluster.Commands {
+		if !matter.IsIncluded(cmd.Conformance) {
+			continue
+		}
+		g.message(messageName(cmd), cmd.Description, cmd.Fields, false, fmt.Sprintf("option (matter.command_id) = %s;", cmd.ID.HexString()))
+	}
+	for _, e := range cluster.Events {
+		if !matter.IsIncluded(e.Conformance) {
+			continue
+		}
+		g.message(matter.WithSuffix(matter.Case(e.Name), "Event"), e.Description, e.Fields, false, fmt.Sprintf("option (matter.event_id) = %s;", e.ID.HexString()))
+	}
+	g.attributes()
+	// Rendering a global data type may queue further global data types
+	for len(g.pending) > 0 {
+		entity := g.pending[0]
+		g.pending = g.pending[1:]
+		g.addEntity(entity)
+	}
+	s.WriteString(g.out.String())
+	return s.String()
+}
+
+type generator struct {
+	cluster  *matter.Cluster
+	rendered map[types.Entity]struct{}
+	pending  []types.Entity
+	out      strings.Builder
+}
+
+func (g *generator) addEntity(entity types.Entity) {
+	if _, ok := g.rendered[entity]; ok {
+		return
+	}
+	g.rendered[entity] = struct{}{}
+	switch entity := entity.(type) {
+	case *matter.Enum:
+		g.enum(entity)
+	case *matter.Struct:
+		g.message(matter.Case(entity.Name), entity.Description, entity.Fields, entity.FabricScoping == matter.FabricScopingScoped, "")
+	}
+}
+
+func (g *generator) enum(e *matter.Enum) {
+	name := matter.Case(e.Name)
+	prefix := strings.ToUpper(text.ToIDLSnakeCase(name))
+	g.out.WriteString("\n")
+	writeComment(&g.out, e.Description)
+	fmt.Fprintf(&g.out, "enum %s {\n", name)
+	var hasZero bool
+	for _, ev := range e.Values {
+		if ev.Value.Valid() && ev.Value.Value() == 0 && matter.IsIncluded(ev.Conformance) {
+			hasZero = true
+		}
+	}
+	// proto3 requires the first value of an enum to be zero
+	if !hasZero {
+		fmt.Fprintf(&g.out, "  %s_UNSPECIFIED = 0;\n", prefix)
+	}
+	for _, ev := range e.Values {
+		if !ev.Value.Valid() || !matter.IsIncluded(ev.Conformance) {
+			continue
+		}
+		fmt.Fprintf(&g.out, "  %s_%s = %s;\n", prefix, strings.ToUpper(text.ToIDLSnakeCase(matter.Case(ev.Name))), ev.Value.HexString())
+	}
+	g.out.WriteString("}\n")
+}
+
+// message describes a struct, command or event payload; fields which are not mandatory, or which are nullable, are
+// marked optional, so that their presence can be told apart from their default value
+func (g *generator) message(name string, description string, fields matter.FieldSet, fabricScoped bool, option string) {
+	g.out.WriteString("\n")
+	writeComment(&g.out, description)
+	fmt.Fprintf(&g.out, "message %s {\n", name)
+	if option != "" {
+		fmt.Fprintf(&g.out, "  %s\n", option)
+	}
+	var hasFabricIndex bool
+	for _, f := range fields {
+		if !matter.IsIncluded(f.Conformance) {
+			continue
+		}
+		if f.ID.Valid() && f.ID.Value() == matter.FabricIndexFieldID {
+			hasFabricIndex = true
+		}
+		g.field(f, "")
+	}
+	if fabricScoped && !hasFabricIndex {
+		fmt.Fprintf(&g.out, "  uint32 fabric_index = %d;\n", matter.FabricIndexFieldID+1)
+	}
+	g.out.WriteString("}\n")
+}
+
+// attributes describes the attributes of the cluster as a single message, each field of which carries the ID of its
+// attribute
+func (g *generator) attributes() {
+	var attributes matter.FieldSet
+	for _, a := range g.cluster.Attributes {
+		if matter.IsIncluded(a.Conformance) {
+			attributes = append(attributes, a)
+		}
+	}
+	if len(attributes) == 0 {
+		return
+	}
+	g.out.WriteString("\n")
+	fmt.Fprintf(&g.out, "message %sAttributes {\n", matter.Case(g.cluster.Name))
+	for _, a := range attributes {
+		g.field(a, fmt.Sprintf(" [(matter.attribute_id) = %s]", a.ID.HexString()))
+	}
+	g.out.WriteString("}\n")
+}
+
+func (g *generator) field(f *matter.Field, option string) {
+	name := text.ToIDLSnakeCase(matter.Case(f.Name))
+	if !f.ID.Valid() {
+		fmt.Fprintf(&g.out, "  // %s omitted: no field ID\n", name)
+		return
+	}
+	number := f.ID.Value() + 1
+	if number > maxFieldNumber || (number >= firstReservedFieldNumber && number <= lastReservedFieldNumber) {
+		fmt.Fprintf(&g.out, "  // %s omitted: ID %s can not be used as a field number\n", name, f.ID.HexString())
+		return
+	}
+	typeName, isMessage := g.typeName(f.Type)
+	if typeName == "" {
+		fmt.Fprintf(&g.out, "  // %s omitted: unresolved data type\n", name)
+		return
+	}
+	var label string
+	switch {
+	case f.Type.IsArray():
+		label = "repeated "
+	case isMessage:
+	case f.Quality.Has(matter.QualityNullable), !conformance.IsMandatory(f.Conformance):
+		label = "optional "
+	}
+	fmt.Fprintf(&g.out, "  %s%s %s = %d%s;\n", label, typeName, name, number, option)
+}
+
+// typeName returns the Protocol Buffers type of a Matter data type, and whether that type is a message
+func (g *generator) typeName(dataType *types.DataType) (string, bool) {
+	if dataType == nil {
+		return "", false
+	}
+	if dataType.IsArray() {
+		return g.typeName(dataType.EntryType)
+	}
+	switch entity := dataType.Entity.(type) {
+	case *matter.Enum:
+		g.reference(entity)
+		return matter.Case(entity.Name), false
+	case *matter.Struct:
+		g.reference(entity)
+		return matter.Case(entity.Name), true
+	case *matter.Bitmap:
+		if entity.Type != nil {
+			return scalarTypeName(entity.Type), false
+		}
+	case *matter.TypeDef:
+		return g.typeName(entity.Type)
+	}
+	if dataType.BaseType == types.BaseDataTypeCustom {
+		return "", false
+	}
+	return scalarTypeName(dataType), false
+}
+
+func (g *generator) reference(entity types.Entity) {
+	if entity.Parent() == g.cluster {
+		return
+	}
+	if _, ok := g.rendered[entity]; ok {
+		return
+	}
+	for _, p := range g.pending {
+		if p == entity {
+			return
+		}
+	}
+	g.pending = append(g.pending, entity)
+}
+
+func scalarTypeName(dataType *types.DataType) string {
+	switch dataType.BaseType {
+	case types.BaseDataTypeBoolean:
+		return "bool"
+	case types.BaseDataTypeSingle:
+		return "float"
+	case types.BaseDataTypeDouble:
+		return "double"
+	case types.BaseDataTypeString:
+		return "string"
+	case types.BaseDataTypeOctStr, types.BaseDataTypeIPAddress, types.BaseDataTypeIPv4Address, types.BaseDataTypeIPv6Address,
+		types.BaseDataTypeIPv6Prefix, types.BaseDataTypeHardwareAddress, types.BaseDataTypeMessageID:
+		return "bytes"
+	}
+	min := types.Min(dataType.BaseType, types.NullabilityNonNull)
+	signed := min.IsNegative()
+	size := dataType.Size()
+	switch {
+	case signed && size > 0 && size <= 4:
+		return "sint32"
+	case signed:
+		return "sint64"
+	case size > 0 && size <= 4:
+		return "uint32"
+	default:
+		return "uint64"
+	}
+}
+
+func writeComment(s *strings.Builder, comment string) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return
+	}
+	for _, line := range strings.Split(comment, "\n") {
+		fmt.Fprintf(s, "// %s\n", strings.TrimSpace(line))
+	}
+}
+
+func packageName(pkg string, cluster *matter.Cluster) string {
+	name := text.ToIDLSnakeCase(matter.Case(cluster.Name))
+	if pkg == "" {
+		return name
+	}
+	return pkg + "." + name
+}
+
+// messageName names the message of a command; requests are suffixed with Request and responses with Response, so
+// they do not collide with structs
+func messageName(cmd *matter.Command) string {
+	name := matter.Case(cmd.Name)
+	if cmd.Direction == matter.InterfaceClient {
+		return matter.WithSuffix(name, "Response")
+	}
+	return matter.WithSuffix(name, "Request")
+}
@@ -0,0 +1,163 @@
+package proto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestScalarTypeName(t *testing.T) {
+	tests := []struct {
+		baseType types.BaseDataType
+		expected string
+	}{
+		{types.BaseDataTypeBoolean, "bool"},
+		{types.BaseDataTypeInt8, "sint32"},
+		{types.BaseDataTypeInt32, "sint32"},
+		{types.BaseDataTypeInt40, "sint64"},
+		{types.BaseDataTypeUInt24, "uint32"},
+		{types.BaseDataTypeUInt40, "uint64"},
+		{types.BaseDataTypeTemperature, "sint32"},
+		{types.BaseDataTypeEnergy, "sint64"},
+		{types.BaseDataTypeEpochMicroseconds, "uint64"},
+		{types.BaseDataTypeSingle, "float"},
+		{types.BaseDataTypeDouble, "double"},
+		{types.BaseDataTypeString, "string"},
+		{types.BaseDataTypeIPv6Prefix, "bytes"},
+		{types.BaseDataTypeHardwareAddress, "bytes"},
+	}
+	for _, test := range tests {
+		dataType := types.NewDataType(test.baseType, types.DataTypeRankScalar)
+		if name := scalarTypeName(dataType); name != test.expected {
+			t.Errorf("expected %s to be a %s, got %s", dataType.Name, test.expected, name)
+		}
+	}
+}
+
+// TestField checks the field numbers and labels of fields; numbers are one more than the field ID, and IDs which
+// would land on a number Protocol Buffers reserves, or past the largest it allows, are left out
+func TestField(t *testing.T) {
+	uint8Type := types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankScalar)
+	mode := matter.NewStruct(nil, nil)
+	mode.Name = "ModeOptionStruct"
+	modeType := &types.DataType{BaseType: types.BaseDataTypeCustom, Name: mode.Name, Entity: mode}
+	levels := matter.NewBitmap(nil, nil)
+	levels.Name = "LevelBitmap"
+	levels.Type = types.NewDataType(types.BaseDataTypeMap16, types.DataTypeRankScalar)
+	percent := matter.NewTypeDef(nil, nil)
+	percent.Name = "percent100ths"
+	percent.Type = types.NewDataType(types.BaseDataTypeUInt16, types.DataTypeRankScalar)
+
+	tests := []struct {
+		field    *matter.Field
+		expected string
+	}{
+		{&matter.Field{ID: matter.NewNumber(0), Name: "CurrentLevel", Type: uint8Type, Conformance: conformance.ParseConformance("M")}, "  uint32 current_level = 1;\n"},
+		{&matter.Field{ID: matter.NewNumber(1), Name: "OnLevel", Type: uint8Type, Quality: matter.QualityNullable, Conformance: conformance.ParseConformance("M")}, "  optional uint32 on_level = 2;\n"},
+		{&matter.Field{ID: matter.NewNumber(2), Name: "MinLevel", Type: uint8Type, Conformance: conformance.ParseConformance("LT")}, "  optional uint32 min_level = 3;\n"},
+		{&matter.Field{ID: matter.NewNumber(3), Name: "Options", Type: &types.DataType{BaseType: types.BaseDataTypeCustom, Name: levels.Name, Entity: levels}, Conformance: conformance.ParseConformance("M")}, "  uint32 options = 4;\n"},
+		{&matter.Field{ID: matter.NewNumber(4), Name: "Remaining", Type: &types.DataType{BaseType: types.BaseDataTypeCustom, Name: percent.Name, Entity: percent}, Conformance: conformance.ParseConformance("M")}, "  uint32 remaining = 5;\n"},
+		// Messages already have presence, so are never optional
+		{&matter.Field{ID: matter.NewNumber(5), Name: "Mode", Type: modeType, Quality: matter.QualityNullable, Conformance: conformance.ParseConformance("O")}, "  ModeOptionStruct mode = 6;\n"},
+		{&matter.Field{ID: matter.NewNumber(6), Name: "Modes", Type: &types.DataType{BaseType: types.BaseDataTypeList, Name: "list", EntryType: modeType}, Conformance: conformance.ParseConformance("O")}, "  repeated ModeOptionStruct modes = 7;\n"},
+		{&matter.Field{ID: matter.NewNumber(18998), Name: "LastUsable", Type: uint8Type, Conformance: conformance.ParseConformance("M")}, "  uint32 last_usable = 18999;\n"},
+		{&matter.Field{ID: matter.NewNumber(18999), Name: "FirstReserved", Type: uint8Type, Conformance: conformance.ParseConformance("M")}, "  // first_reserved omitted: ID 0x4A37 can not be used as a field number\n"},
+		{&matter.Field{ID: matter.NewNumber(19998), Name: "LastReserved", Type: uint8Type, Conformance: conformance.ParseConformance("M")}, "  // last_reserved omitted: ID 0x4E1E can not be used as a field number\n"},
+		{&matter.Field{ID: matter.NewNumber(19999), Name: "PastReserved", Type: uint8Type, Conformance: conformance.ParseConformance("M")}, "  uint32 past_reserved = 20000;\n"},
+		{&matter.Field{ID: matter.NewNumber(1<<29 - 1), Name: "TooLarge", Type: uint8Type, Conformance: conformance.ParseConformance("M")}, "  // too_large omitted: ID 0x1FFFFFFF can not be used as a field number\n"},
+		{&matter.Field{ID: matter.InvalidID, Name: "NoID", Type: uint8Type, Conformance: conformance.ParseConformance("M")}, "  // no_id omitted: no field ID\n"},
+		{&matter.Field{ID: matter.NewNumber(7), Name: "Mystery", Type: types.NewCustomDataType("MysteryType", types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")}, "  // mystery omitted: unresolved data type\n"},
+	}
+	for _, test := range tests {
+		g := &generator{cluster: matter.NewCluster(nil), rendered: make(map[types.Entity]struct{})}
+		g.field(test.field, "")
+		if out := g.out.String(); out != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.field.Name, test.expected, out)
+		}
+	}
+}
+
+// TestEnum checks that enums whose values don't include zero, or only include it in a value which is left out, start
+// with the placeholder proto3 requires
+func TestEnum(t *testing.T) {
+	tests := []struct {
+		values   matter.EnumValueSet
+		expected string
+	}{
+		{
+			values:   matter.EnumValueSet{{Name: "Off", Value: matter.NewNumber(0), Conformance: conformance.ParseConformance("M")}, {Name: "On", Value: matter.NewNumber(1), Conformance: conformance.ParseConformance("M")}},
+			expected: "\nenum StartUpOnOffEnum {\n  START_UP_ON_OFF_ENUM_OFF = 0x0000;\n  START_UP_ON_OFF_ENUM_ON = 0x0001;\n}\n",
+		},
+		{
+			values:   matter.EnumValueSet{{Name: "Off", Value: matter.NewNumber(0), Conformance: conformance.ParseConformance("X")}, {Name: "Toggle", Value: matter.NewNumber(2), Conformance: conformance.ParseConformance("M")}},
+			expected: "\nenum StartUpOnOffEnum {\n  START_UP_ON_OFF_ENUM_UNSPECIFIED = 0;\n  START_UP_ON_OFF_ENUM_TOGGLE = 0x0002;\n}\n",
+		},
+	}
+	for _, test := range tests {
+		e := matter.NewEnum(nil, nil)
+		e.Name = "StartUpOnOffEnum"
+		e.Values = test.values
+		g := &generator{rendered: make(map[types.Entity]struct{})}
+		g.enum(e)
+		if out := g.out.String(); out != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, out)
+		}
+	}
+}
+
+func TestRenderCluster(t *testing.T) {
+	cluster := matter.NewCluster(nil)
+	cluster.Name = "Mode Select"
+	cluster.ID = matter.NewNumber(0x0050)
+	revision := matter.NewRevision(cluster, nil)
+	revision.Number = matter.NewNumber(2)
+	cluster.Revisions = matter.Revisions{revision}
+
+	// A global struct referred to by the cluster, and by another global struct, is rendered once at the end
+	tag := matter.NewStruct(nil, nil)
+	tag.Name = "SemanticTagStruct"
+	tag.Fields = matter.FieldSet{{ID: matter.NewNumber(0), Name: "Value", Type: types.NewDataType(types.BaseDataTypeEnum8, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")}}
+	tagType := &types.DataType{BaseType: types.BaseDataTypeCustom, Name: tag.Name, Entity: tag}
+	option := matter.NewStruct(nil, nil)
+	option.Name = "ModeOptionStruct"
+	option.Fields = matter.FieldSet{
+		{ID: matter.NewNumber(0), Name: "Label", Type: types.NewDataType(types.BaseDataTypeString, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")},
+		{ID: matter.NewNumber(1), Name: "SemanticTags", Type: &types.DataType{BaseType: types.BaseDataTypeList, Name: "list", EntryType: tagType}, Conformance: conformance.ParseConformance("M")},
+	}
+
+	change := matter.NewCommand(nil, cluster)
+	change.Name = "ChangeToMode"
+	change.ID = matter.NewNumber(0x00)
+	change.Direction = matter.InterfaceServer
+	change.Fields = matter.FieldSet{{ID: matter.NewNumber(0), Name: "NewMode", Type: types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")}}
+	response := matter.NewCommand(nil, cluster)
+	response.Name = "ChangeToModeResponse"
+	response.ID = matter.NewNumber(0x01)
+	response.Direction = matter.InterfaceClient
+	cluster.Commands = append(cluster.Commands, change, response)
+
+	cluster.Attributes = matter.FieldSet{
+		{ID: matter.NewNumber(0x0001), Name: "SupportedModes", Type: &types.DataType{BaseType: types.BaseDataTypeList, Name: "list", EntryType: &types.DataType{BaseType: types.BaseDataTypeCustom, Name: option.Name, Entity: option}}, Conformance: conformance.ParseConformance("M")},
+		{ID: matter.NewNumber(0x0002), Name: "SemanticTag", Type: tagType, Conformance: conformance.ParseConformance("M")},
+		{ID: matter.NewNumber(0x0003), Name: "StartUpMode", Type: types.NewDataType(types.BaseDataTypeUInt8, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("X")},
+	}
+
+	out := RenderCluster(cluster, "matter.clusters")
+	for _, expected := range []string{
+		"package matter.clusters.mode_select;\n\nimport \"matter/options.proto\";\n\noption (matter.cluster_id) = 0x0050;\noption (matter.cluster_revision) = 2;\n",
+		"message ChangeToModeRequest {\n  option (matter.command_id) = 0x0000;\n  uint32 new_mode = 1;\n}\n",
+		"message ChangeToModeResponse {\n  option (matter.command_id) = 0x0001;\n}\n",
+		"message ModeSelectAttributes {\n  repeated ModeOptionStruct supported_modes = 2 [(matter.attribute_id) = 0x0001];\n  SemanticTagStruct semantic_tag = 3 [(matter.attribute_id) = 0x0002];\n}\n",
+		"}\n\nmessage ModeOptionStruct {\n  string label = 1;\n  repeated SemanticTagStruct semantic_tags = 2;\n}\n\nmessage SemanticTagStruct {\n  uint32 value = 1;\n}\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected output to contain %q; got:\n%s", expected, out)
+		}
+	}
+	if strings.Count(out, "message SemanticTagStruct") != 1 || strings.Contains(out, "ChangeToModeResponseResponse") || strings.Contains(out, "start_up_mode") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
@@ -0,0 +1,55 @@
+package proto
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/internal/text"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+type Options struct {
+	ProtoRoot    string `default:"proto" help:"where to place the .proto files" group:"Protocol Buffers:"`
+	ProtoPackage string `default:"matter.clusters" help:"package prefix of the generated files; each cluster is placed in its own package under it" group:"Protocol Buffers:"`
+}
+
+type Renderer struct {
+	spec    *spec.Specification
+	options Options
+}
+
+func NewRenderer(spec *spec.Specification, options Options) *Renderer {
+	return &Renderer{spec: spec, options: options}
+}
+
+func (r *Renderer) Name() string {
+	return "Rendering Protocol Buffers"
+}
+
+func (r *Renderer) Process(cxt context.Context, input *pipeline.Data[*asciidoc.Document], index int32, total int32) (outputs []*pipeline.Data[string], extra []*pipeline.Data[*asciidoc.Document], err error) {
+	for _, e := range r.spec.EntitiesForDocument(input.Content) {
+		var clusters []*matter.Cluster
+		switch e := e.(type) {
+		case *matter.Cluster:
+			clusters = append(clusters, e)
+		case *matter.ClusterGroup:
+			clusters = append(clusters, e.Clusters...)
+		}
+		for _, c := range clusters {
+			outputs = append(outputs, pipeline.NewData(r.protoPath(c), RenderCluster(c, r.options.ProtoPackage)))
+		}
+	}
+	return
+}
+
+// Options returns the file declaring the custom options which generated files import
+func (r *Renderer) Options() *pipeline.Data[string] {
+	return pipeline.NewData(filepath.Join(r.options.ProtoRoot, filepath.FromSlash(OptionsPath)), OptionsFile)
+}
+
+func (r *Renderer) protoPath(cluster *matter.Cluster) string {
+	return filepath.Join(r.options.ProtoRoot, text.ToIDLSnakeCase(matter.Case(cluster.Name))+".proto")
+}
@@ -15,12 +15,11 @@ func TestCompareProvisional_FileBased_Cases(t *testing.T) {
 	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
 
 	cxt := context.Background()
-	baseRoot := "testdata/base"
-	headRoot := "testdata/head"
+	options := spec.PullRequestOptions{BaseRoot: "testdata/base", HeadRoot: "testdata/head"}
 
 	processingOptions := pipeline.ProcessingOptions{}
 
-	violations, err := provisional.Pipeline(cxt, baseRoot, headRoot, nil, processingOptions, nil)
+	violations, err := provisional.Pipeline(cxt, options, nil, processingOptions, nil)
 	if err != nil {
 		t.Fatalf("Pipeline failed: %v", err)
 	}
@@ -10,12 +10,12 @@ import (
 	"github.com/project-chip/alchemy/matter/spec"
 )
 
-func Pipeline(cxt context.Context, baseRoot string, headRoot string, docPaths []string, pipelineOptions pipeline.ProcessingOptions, writer files.Writer[string]) (violations map[string][]spec.Violation, err error) {
-	specs, err := spec.LoadSpecPullRequest(cxt, baseRoot, headRoot, pipelineOptions)
-
+func Pipeline(cxt context.Context, options spec.PullRequestOptions, docPaths []string, pipelineOptions pipeline.ProcessingOptions, writer files.Writer[string]) (violations map[string][]spec.Violation, err error) {
+	specs, cleanup, err := spec.LoadSpecPullRequest(cxt, options, pipelineOptions)
 	if err != nil {
 		return nil, err
 	}
+	defer cleanup()
 
 	return ProcessSpecs(cxt, &specs, pipelineOptions, writer)
 }
@@ -0,0 +1,122 @@
+package provisional_test
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/provisional"
+)
+
+// TestPatchAtRef checks that the patch made for head read at a git ref is made against head's files, rather than
+// against the working tree or files which have already been removed
+func TestPatchAtRef(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
+
+	cxt := context.Background()
+	processingOptions := pipeline.ProcessingOptions{}
+
+	headRoot, err := filepath.Abs("testdata/head")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := provisionalPatch(t, cxt, spec.PullRequestOptions{BaseRoot: "testdata/base", HeadRoot: headRoot})
+	if expected == "" {
+		t.Fatal("expected a provisional patch for testdata")
+	}
+
+	// Commit head, then leave base in the working tree, so that only the worktree of the ref has head's files
+	repo := t.TempDir()
+	copyDir(t, "testdata/head", repo)
+	git(t, repo, "init", "-q")
+	git(t, repo, "add", "-A")
+	git(t, repo, "commit", "-q", "-m", "head")
+	git(t, repo, "tag", "head")
+	copyDir(t, "testdata/base", repo)
+
+	var specs spec.SpecPullRequest
+	var cleanup func()
+	specs, cleanup, err = spec.LoadSpecPullRequest(cxt, spec.PullRequestOptions{BaseRoot: "testdata/base", HeadRoot: repo, HeadRef: "head"}, processingOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if specs.HeadRoot == repo {
+		t.Fatalf("expected head to be read from a worktree")
+	}
+	var patch bytes.Buffer
+	writer := files.NewPatcher[string]("Generating patch file...", &patch)
+	writer.Root = specs.HeadRoot
+	_, err = provisional.ProcessSpecs(cxt, &specs, processingOptions, writer)
+	cleanup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch.String() != expected {
+		t.Errorf("expected the patch made at a ref to match the patch made from a directory; got:\n%s\nexpected:\n%s", patch.String(), expected)
+	}
+	if _, err = os.Stat(specs.HeadRoot); !os.IsNotExist(err) {
+		t.Errorf("expected the worktree to be removed by cleanup, got %v", err)
+	}
+}
+
+func provisionalPatch(t *testing.T, cxt context.Context, options spec.PullRequestOptions) string {
+	t.Helper()
+	specs, cleanup, err := spec.LoadSpecPullRequest(cxt, options, pipeline.ProcessingOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+	var patch bytes.Buffer
+	writer := files.NewPatcher[string]("Generating patch file...", &patch)
+	writer.Root = specs.HeadRoot
+	_, err = provisional.ProcessSpecs(cxt, &specs, pipeline.ProcessingOptions{}, writer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return patch.String()
+}
+
+func copyDir(t *testing.T, from string, to string) {
+	t.Helper()
+	err := filepath.WalkDir(from, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(to, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, b, 0o644)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+}
@@ -1,6 +1,7 @@
 package provisional
 
 import (
+	"encoding/json"
 	"log/slog"
 
 	"github.com/project-chip/alchemy/matter"
@@ -48,9 +49,13 @@ func (s State) String() string {
 	}
 }
 
-func IsProvisional(spec *spec.Specification, entity types.Entity) bool {
-	is := Check(spec, entity, entity)
-	switch is {
+func (s State) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// IsProvisional returns true if the state makes an entity provisional
+func (s State) IsProvisional() bool {
+	switch s {
 	case StateAllClustersProvisional,
 		StateAllDataTypeReferencesProvisional,
 		StateExplicitlyProvisional:
@@ -60,6 +65,10 @@ func IsProvisional(spec *spec.Specification, entity types.Entity) bool {
 	}
 }
 
+func IsProvisional(spec *spec.Specification, entity types.Entity) bool {
+	return Check(spec, entity, entity).IsProvisional()
+}
+
 func Check(spec *spec.Specification, entity types.Entity, originalEntity types.Entity) State {
 	if conformance.IsProvisional(matter.EntityConformance(entity)) {
 		// This is explicitly marked provisional
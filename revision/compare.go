@@ -0,0 +1,81 @@
+package revision
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/project-chip/alchemy/changelog"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func compareRevisions(base *spec.Specification, head *spec.Specification) (violations map[string][]spec.Violation) {
+	violations = make(map[string][]spec.Violation)
+	for id, headCluster := range head.ClustersByID {
+		baseCluster, ok := base.ClustersByID[id]
+		if !ok {
+			continue
+		}
+		changes := changelog.CompareCluster(baseCluster, headCluster)
+		checkRevisions(violations, headCluster, baseCluster.Revisions, headCluster.Revisions, changes)
+	}
+	for id, headDeviceType := range head.DeviceTypesByID {
+		baseDeviceType, ok := base.DeviceTypesByID[id]
+		if !ok {
+			continue
+		}
+		changes := changelog.CompareDeviceType(baseDeviceType, headDeviceType)
+		checkRevisions(violations, headDeviceType, baseDeviceType.Revisions, headDeviceType.Revisions, changes)
+	}
+	return
+}
+
+// checkRevisions flags entities which changed without a new revision, and new revisions which have no change to
+// describe
+func checkRevisions(violations map[string][]spec.Violation, entity types.Entity, baseRevisions matter.Revisions, headRevisions matter.Revisions, changes []*changelog.Change) {
+	added := newRevisions(baseRevisions, headRevisions)
+	switch {
+	case len(changes) > 0 && len(added) == 0:
+		slog.Error("Revision not incremented", matter.LogEntity("entity", entity), slog.Int("changes", len(changes)))
+		v := spec.Violation{Entity: entity, Type: spec.ViolationRevision}
+		descriptions := make([]string, len(changes))
+		for i, c := range changes {
+			descriptions[i] = c.String()
+		}
+		v.Text = fmt.Sprintf("changed without a new revision: %s", strings.Join(descriptions, ", "))
+		v.Path, v.Line = entity.Origin()
+		if r := headRevisions.MostRecent(); r != nil {
+			v.Path, v.Line = r.Origin()
+		}
+		violations[v.Path] = append(violations[v.Path], v)
+	case len(changes) == 0 && len(added) > 0:
+		for _, r := range added {
+			slog.Error("Revision added without changes", matter.LogEntity("entity", entity), slog.String("revision", r.Number.IntString()))
+			v := spec.Violation{Entity: entity, Type: spec.ViolationRevision}
+			v.Text = fmt.Sprintf("revision %s added without any change to %s", r.Number.IntString(), matter.EntityName(entity))
+			v.Path, v.Line = r.Origin()
+			violations[v.Path] = append(violations[v.Path], v)
+		}
+	}
+}
+
+// newRevisions returns the revisions in head whose numbers do not appear in base
+func newRevisions(base matter.Revisions, head matter.Revisions) (added matter.Revisions) {
+	numbers := make(map[uint64]struct{}, len(base))
+	for _, r := range base {
+		if r.Number.Valid() {
+			numbers[r.Number.Value()] = struct{}{}
+		}
+	}
+	for _, r := range head {
+		if !r.Number.Valid() {
+			continue
+		}
+		if _, ok := numbers[r.Number.Value()]; !ok {
+			added = append(added, r)
+		}
+	}
+	return
+}
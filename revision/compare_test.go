@@ -0,0 +1,86 @@
+package revision
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestCompareRevisions(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(c *matter.Cluster)
+		revision bool
+		expected string
+	}{
+		{name: "unchanged"},
+		{name: "changed with revision", change: makeOptional, revision: true},
+		{name: "changed without revision", change: makeOptional, expected: "changed without a new revision: modified attribute OnTime"},
+		{name: "added without revision", change: addAttribute, expected: "changed without a new revision: added attribute OffTime"},
+		{name: "description without revision", change: func(c *matter.Cluster) { c.Description = "Reworded" }},
+		{name: "revision without change", revision: true, expected: "revision 2 added without any change to Sample"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newSpec(sampleCluster())
+			headCluster := sampleCluster()
+			if tt.change != nil {
+				tt.change(headCluster)
+			}
+			if tt.revision {
+				r := matter.NewRevision(headCluster, nil)
+				r.Number = matter.NewNumber(2)
+				headCluster.Revisions = append(headCluster.Revisions, r)
+			}
+			violations := compareRevisions(base, newSpec(headCluster))
+			var texts []string
+			for _, vs := range violations {
+				for _, v := range vs {
+					if !v.Type.Has(spec.ViolationRevision) {
+						t.Errorf("unexpected violation type %s", v.Type)
+					}
+					texts = append(texts, v.Text)
+				}
+			}
+			if got := strings.Join(texts, "\n"); got != tt.expected {
+				t.Errorf("expected violations %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func sampleCluster() *matter.Cluster {
+	c := matter.NewCluster(nil)
+	c.ID = matter.NewNumber(0xFFF1)
+	c.Name = "Sample"
+	r := matter.NewRevision(c, nil)
+	r.Number = matter.NewNumber(1)
+	c.Revisions = matter.Revisions{r}
+	a := matter.NewAttribute(nil, c)
+	a.ID = matter.NewNumber(0)
+	a.Name = "OnTime"
+	a.Type = types.NewDataType(types.BaseDataTypeUInt16, types.DataTypeRankScalar)
+	a.Conformance = conformance.Set{&conformance.Mandatory{}}
+	c.Attributes = matter.FieldSet{a}
+	return c
+}
+
+func makeOptional(c *matter.Cluster) {
+	c.Attributes[0].Conformance = conformance.Set{&conformance.Optional{}}
+}
+
+func addAttribute(c *matter.Cluster) {
+	a := matter.NewAttribute(nil, c)
+	a.ID = matter.NewNumber(1)
+	a.Name = "OffTime"
+	a.Type = types.NewDataType(types.BaseDataTypeUInt16, types.DataTypeRankScalar)
+	c.Attributes = append(c.Attributes, a)
+}
+
+func newSpec(cluster *matter.Cluster) *spec.Specification {
+	return &spec.Specification{ClustersByID: map[uint64]*matter.Cluster{cluster.ID.Value(): cluster}}
+}
@@ -0,0 +1,50 @@
+package revision
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+func Pipeline(cxt context.Context, options spec.PullRequestOptions, pipelineOptions pipeline.ProcessingOptions) (violations map[string][]spec.Violation, err error) {
+	specs, cleanup, err := spec.LoadSpecPullRequest(cxt, options, pipelineOptions)
+	if err != nil {
+		return
+	}
+	defer cleanup()
+
+	violations = ProcessComparison(&specs)
+	return
+}
+
+// ProcessComparison checks that every cluster and device type which changed between base and head has a new
+// revision, and that every new revision accompanies a change
+func ProcessComparison(specs *spec.SpecPullRequest) (violations map[string][]spec.Violation) {
+	slog.Info("Comparing revisions of head and base")
+	v1 := compareRevisions(specs.Base, specs.Head)
+
+	slog.Info("Comparing revisions of head and base (in-progress)")
+	v2 := compareRevisions(specs.BaseInProgress, specs.HeadInProgress)
+
+	// Changes outside of in-progress show up in both comparisons, so only report them once
+	for path, vs := range v2 {
+		for _, v := range vs {
+			if !containsViolation(v1[path], v) {
+				v1[path] = append(v1[path], v)
+			}
+		}
+	}
+	violations = v1
+	return
+}
+
+func containsViolation(vs []spec.Violation, v spec.Violation) bool {
+	for _, ov := range vs {
+		if ov.Line == v.Line && ov.Text == v.Text {
+			return true
+		}
+	}
+	return false
+}
@@ -49,12 +49,10 @@ func (sp *Renderer) Process(cxt context.Context, input *pipeline.Data[*asciidoc.
 	doc := input.Content
 	path := doc.Path
 
-	library, ok := sp.spec.LibraryForDocument(doc)
-	if !ok {
-		err = fmt.Errorf("unable to find library for doc %s", doc.Path.Relative)
-		return
+	errata := sp.spec.Errata.Get(doc.Path.Relative).TestPlan
+	if library, ok := sp.spec.LibraryForDocument(doc); ok {
+		errata = library.ErrataForPath(doc.Path.Relative).TestPlan
 	}
-	errata := library.ErrataForPath(doc.Path.Relative).TestPlan
 
 	entities := sp.spec.EntitiesForDocument(doc)
 
@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/asciidoc/parse"
+)
+
+// Inputs the parser is known not to support, so they have no parsed document to encode
+var codecUnparseable = map[string]struct{}{
+	// The TSV data is included from a file, so the parser can't count the columns
+	"asciidoctor/tables_test_should_not_drop_trailing_empty_cell_in_tsv_data_when_loaded_from_an_include_file.adoc": {},
+}
+
+func TestDocumentCodec(t *testing.T) {
+	paths, err := filepath.Glob("asciidoctor/*.adoc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	local, err := filepath.Glob("*.adoc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths = append(paths, local...)
+	for _, path := range paths {
+		in, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("error reading %s: %v", path, err)
+		}
+		doc, err := parse.Raw(asciidoc.Path{}, in)
+		if _, ok := codecUnparseable[filepath.ToSlash(path)]; ok {
+			if err == nil {
+				t.Errorf("%s is listed as unparseable, but parsed", path)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("error parsing %s: %v", path, err)
+			continue
+		}
+		var encoded bytes.Buffer
+		err = parse.EncodeDocument(&encoded, doc)
+		if err != nil {
+			t.Errorf("error encoding %s: %v", path, err)
+			continue
+		}
+		decoded, err := parse.DecodeDocument(bytes.NewReader(encoded.Bytes()), asciidoc.Path{})
+		if err != nil {
+			t.Errorf("error decoding %s: %v", path, err)
+			continue
+		}
+		if !decoded.Equals(doc) {
+			t.Errorf("decoded document does not match original for %s", path)
+			continue
+		}
+		// Positions and raw text aren't compared by Equals, but do affect the encoding
+		var reencoded bytes.Buffer
+		err = parse.EncodeDocument(&reencoded, decoded)
+		if err != nil {
+			t.Errorf("error re-encoding %s: %v", path, err)
+			continue
+		}
+		if !bytes.Equal(encoded.Bytes(), reencoded.Bytes()) {
+			t.Errorf("re-encoded document does not match original encoding for %s", path)
+		}
+	}
+}
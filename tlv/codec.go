@@ -0,0 +1,303 @@
+package tlv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// Decode reads a single TLV element, which is usually an anonymous structure, from b; trailing bytes are an error
+func Decode(b []byte) (*Element, error) {
+	d := &decoder{b: b}
+	el, err := d.element()
+	if err != nil {
+		return nil, err
+	}
+	if el.Type == ElementTypeEndOfContainer {
+		return nil, fmt.Errorf("unexpected end of container at offset 0")
+	}
+	if d.offset != len(b) {
+		return nil, fmt.Errorf("%d unexpected trailing bytes at offset %d", len(b)-d.offset, d.offset)
+	}
+	return el, nil
+}
+
+type decoder struct {
+	b      []byte
+	offset int
+}
+
+func (d *decoder) read(n int) ([]byte, error) {
+	if n < 0 || d.offset+n > len(d.b) {
+		return nil, fmt.Errorf("unexpected end of data at offset %d: wanted %d bytes, %d remain", d.offset, n, len(d.b)-d.offset)
+	}
+	b := d.b[d.offset : d.offset+n]
+	d.offset += n
+	return b, nil
+}
+
+func (d *decoder) uint(width int) (uint64, error) {
+	b, err := d.read(width)
+	if err != nil {
+		return 0, err
+	}
+	switch width {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(b)), nil
+	case 8:
+		return binary.LittleEndian.Uint64(b), nil
+	}
+	return 0, fmt.Errorf("invalid width: %d", width)
+}
+
+func (d *decoder) element() (el *Element, err error) {
+	start := d.offset
+	var control []byte
+	control, err = d.read(1)
+	if err != nil {
+		return
+	}
+	el = &Element{Type: ElementType(control[0] & 0x1F)}
+	el.Tag, err = d.tag(TagControl(control[0] >> 5))
+	if err != nil {
+		return
+	}
+	switch {
+	case el.Type.IsSigned():
+		var u uint64
+		u, err = d.uint(el.Type.width())
+		if err != nil {
+			return
+		}
+		shift := 64 - 8*el.Type.width()
+		el.Value = int64(u<<shift) >> shift
+	case el.Type.IsUnsigned():
+		el.Value, err = d.uint(el.Type.width())
+	case el.Type.IsBoolean():
+		el.Value = el.Type == ElementTypeTrue
+	case el.Type == ElementTypeFloat:
+		var u uint64
+		u, err = d.uint(4)
+		el.Value = math.Float32frombits(uint32(u))
+	case el.Type == ElementTypeDouble:
+		var u uint64
+		u, err = d.uint(8)
+		el.Value = math.Float64frombits(u)
+	case el.Type.IsUTF8String(), el.Type.IsOctetString():
+		var length uint64
+		length, err = d.uint(el.Type.width())
+		if err != nil {
+			return
+		}
+		if length > uint64(len(d.b)) {
+			err = fmt.Errorf("string length %d at offset %d exceeds data", length, start)
+			return
+		}
+		var b []byte
+		b, err = d.read(int(length))
+		if err != nil {
+			return
+		}
+		if el.Type.IsOctetString() {
+			el.Value = append([]byte(nil), b...)
+		} else if !utf8.Valid(b) {
+			err = fmt.Errorf("invalid UTF-8 string at offset %d", start)
+		} else {
+			el.Value = string(b)
+		}
+	case el.Type == ElementTypeNull:
+	case el.Type.IsContainer():
+		for {
+			var member *Element
+			member, err = d.element()
+			if err != nil {
+				return
+			}
+			if member.Type == ElementTypeEndOfContainer {
+				break
+			}
+			if el.Type == ElementTypeStructure && member.Tag.IsAnonymous() {
+				err = fmt.Errorf("anonymous member of structure at offset %d", start)
+				return
+			}
+			if el.Type == ElementTypeArray && !member.Tag.IsAnonymous() {
+				err = fmt.Errorf("tagged member of array at offset %d", start)
+				return
+			}
+			el.Elements = append(el.Elements, member)
+		}
+	case el.Type == ElementTypeEndOfContainer:
+		if !el.Tag.IsAnonymous() {
+			err = fmt.Errorf("tagged end of container at offset %d", start)
+		}
+	default:
+		err = fmt.Errorf("unknown element type 0x%02X at offset %d", uint8(el.Type), start)
+	}
+	return
+}
+
+func (d *decoder) tag(control TagControl) (tag Tag, err error) {
+	tag.Control = control
+	var u uint64
+	switch control {
+	case TagControlAnonymous:
+	case TagControlContextSpecific:
+		u, err = d.uint(1)
+		tag.Number = uint32(u)
+	case TagControlCommonProfile2, TagControlImplicitProfile2:
+		u, err = d.uint(2)
+		tag.Number = uint32(u)
+	case TagControlCommonProfile4, TagControlImplicitProfile4:
+		u, err = d.uint(4)
+		tag.Number = uint32(u)
+	case TagControlFullyQualified6, TagControlFullyQualified8:
+		u, err = d.uint(2)
+		if err != nil {
+			return
+		}
+		tag.VendorID = uint16(u)
+		u, err = d.uint(2)
+		if err != nil {
+			return
+		}
+		tag.ProfileNumber = uint16(u)
+		if control == TagControlFullyQualified6 {
+			u, err = d.uint(2)
+		} else {
+			u, err = d.uint(4)
+		}
+		tag.Number = uint32(u)
+	}
+	return
+}
+
+// Encode writes an element in its shortest form; integers and string lengths use the fewest bytes which hold them
+func Encode(el *Element) ([]byte, error) {
+	return appendElement(nil, el)
+}
+
+func appendElement(b []byte, el *Element) ([]byte, error) {
+	et := el.Type
+	switch v := el.Value.(type) {
+	case int64:
+		et = NewSigned(el.Tag, v).Type
+	case uint64:
+		et = NewUnsigned(el.Tag, v).Type
+	case string:
+		et = NewUTF8String(el.Tag, v).Type
+	case []byte:
+		et = NewOctetString(el.Tag, v).Type
+	}
+	tagControl, err := tagControl(el.Tag)
+	if err != nil {
+		return nil, err
+	}
+	b = append(b, byte(tagControl)<<5|byte(et))
+	b = appendTag(b, tagControl, el.Tag)
+	switch {
+	case et.IsSigned():
+		v, ok := el.Value.(int64)
+		if !ok {
+			return nil, fmt.Errorf("expected int64 value for signed element, got %T", el.Value)
+		}
+		b = appendUint(b, uint64(v), et.width())
+	case et.IsUnsigned():
+		v, ok := el.Value.(uint64)
+		if !ok {
+			return nil, fmt.Errorf("expected uint64 value for unsigned element, got %T", el.Value)
+		}
+		b = appendUint(b, v, et.width())
+	case et.IsBoolean(), et == ElementTypeNull:
+	case et == ElementTypeFloat:
+		v, ok := el.Value.(float32)
+		if !ok {
+			return nil, fmt.Errorf("expected float32 value for float element, got %T", el.Value)
+		}
+		b = appendUint(b, uint64(math.Float32bits(v)), 4)
+	case et == ElementTypeDouble:
+		v, ok := el.Value.(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected float64 value for double element, got %T", el.Value)
+		}
+		b = appendUint(b, math.Float64bits(v), 8)
+	case et.IsUTF8String():
+		v := el.Value.(string)
+		b = appendUint(b, uint64(len(v)), et.width())
+		b = append(b, v...)
+	case et.IsOctetString():
+		v := el.Value.([]byte)
+		b = appendUint(b, uint64(len(v)), et.width())
+		b = append(b, v...)
+	case et.IsContainer():
+		for _, member := range el.Elements {
+			b, err = appendElement(b, member)
+			if err != nil {
+				return nil, err
+			}
+		}
+		b = append(b, byte(ElementTypeEndOfContainer))
+	default:
+		return nil, fmt.Errorf("can not encode element type %s", et)
+	}
+	return b, nil
+}
+
+func tagControl(tag Tag) (TagControl, error) {
+	switch tag.Control {
+	case TagControlAnonymous:
+		return tag.Control, nil
+	case TagControlContextSpecific:
+		if tag.Number > math.MaxUint8 {
+			return 0, fmt.Errorf("context tag %d out of range", tag.Number)
+		}
+		return tag.Control, nil
+	case TagControlCommonProfile2, TagControlCommonProfile4:
+		if tag.Number > math.MaxUint16 {
+			return TagControlCommonProfile4, nil
+		}
+		return TagControlCommonProfile2, nil
+	case TagControlImplicitProfile2, TagControlImplicitProfile4:
+		if tag.Number > math.MaxUint16 {
+			return TagControlImplicitProfile4, nil
+		}
+		return TagControlImplicitProfile2, nil
+	case TagControlFullyQualified6, TagControlFullyQualified8:
+		if tag.Number > math.MaxUint16 {
+			return TagControlFullyQualified8, nil
+		}
+		return TagControlFullyQualified6, nil
+	}
+	return 0, fmt.Errorf("invalid tag control: %d", tag.Control)
+}
+
+func appendTag(b []byte, control TagControl, tag Tag) []byte {
+	switch control {
+	case TagControlContextSpecific:
+		b = append(b, byte(tag.Number))
+	case TagControlCommonProfile2, TagControlImplicitProfile2:
+		b = appendUint(b, uint64(tag.Number), 2)
+	case TagControlCommonProfile4, TagControlImplicitProfile4:
+		b = appendUint(b, uint64(tag.Number), 4)
+	case TagControlFullyQualified6:
+		b = appendUint(b, uint64(tag.VendorID), 2)
+		b = appendUint(b, uint64(tag.ProfileNumber), 2)
+		b = appendUint(b, uint64(tag.Number), 2)
+	case TagControlFullyQualified8:
+		b = appendUint(b, uint64(tag.VendorID), 2)
+		b = appendUint(b, uint64(tag.ProfileNumber), 2)
+		b = appendUint(b, uint64(tag.Number), 4)
+	}
+	return b
+}
+
+func appendUint(b []byte, v uint64, width int) []byte {
+	for i := 0; i < width; i++ {
+		b = append(b, byte(v>>(8*i)))
+	}
+	return b
+}
@@ -0,0 +1,245 @@
+package tlv
+
+import "fmt"
+
+type ElementType uint8
+
+const (
+	ElementTypeInt8 ElementType = iota
+	ElementTypeInt16
+	ElementTypeInt32
+	ElementTypeInt64
+	ElementTypeUInt8
+	ElementTypeUInt16
+	ElementTypeUInt32
+	ElementTypeUInt64
+	ElementTypeFalse
+	ElementTypeTrue
+	ElementTypeFloat
+	ElementTypeDouble
+	ElementTypeUTF8String1
+	ElementTypeUTF8String2
+	ElementTypeUTF8String4
+	ElementTypeUTF8String8
+	ElementTypeOctetString1
+	ElementTypeOctetString2
+	ElementTypeOctetString4
+	ElementTypeOctetString8
+	ElementTypeNull
+	ElementTypeStructure
+	ElementTypeArray
+	ElementTypeList
+	ElementTypeEndOfContainer
+)
+
+func (et ElementType) IsSigned() bool {
+	return et <= ElementTypeInt64
+}
+
+func (et ElementType) IsUnsigned() bool {
+	return et >= ElementTypeUInt8 && et <= ElementTypeUInt64
+}
+
+func (et ElementType) IsBoolean() bool {
+	return et == ElementTypeFalse || et == ElementTypeTrue
+}
+
+func (et ElementType) IsUTF8String() bool {
+	return et >= ElementTypeUTF8String1 && et <= ElementTypeUTF8String8
+}
+
+func (et ElementType) IsOctetString() bool {
+	return et >= ElementTypeOctetString1 && et <= ElementTypeOctetString8
+}
+
+func (et ElementType) IsContainer() bool {
+	return et == ElementTypeStructure || et == ElementTypeArray || et == ElementTypeList
+}
+
+// width is the number of bytes in the value of an integer or float, or in the length of a string
+func (et ElementType) width() int {
+	switch et {
+	case ElementTypeFloat:
+		return 4
+	case ElementTypeDouble:
+		return 8
+	case ElementTypeInt8, ElementTypeInt16, ElementTypeInt32, ElementTypeInt64:
+		return 1 << (et - ElementTypeInt8)
+	case ElementTypeUInt8, ElementTypeUInt16, ElementTypeUInt32, ElementTypeUInt64:
+		return 1 << (et - ElementTypeUInt8)
+	case ElementTypeUTF8String1, ElementTypeUTF8String2, ElementTypeUTF8String4, ElementTypeUTF8String8:
+		return 1 << (et - ElementTypeUTF8String1)
+	case ElementTypeOctetString1, ElementTypeOctetString2, ElementTypeOctetString4, ElementTypeOctetString8:
+		return 1 << (et - ElementTypeOctetString1)
+	}
+	return 0
+}
+
+func (et ElementType) String() string {
+	switch {
+	case et.IsSigned():
+		return "signed"
+	case et.IsUnsigned():
+		return "unsigned"
+	case et.IsBoolean():
+		return "boolean"
+	case et == ElementTypeFloat, et == ElementTypeDouble:
+		return "float"
+	case et.IsUTF8String():
+		return "utf8-string"
+	case et.IsOctetString():
+		return "octet-string"
+	}
+	switch et {
+	case ElementTypeNull:
+		return "null"
+	case ElementTypeStructure:
+		return "structure"
+	case ElementTypeArray:
+		return "array"
+	case ElementTypeList:
+		return "list"
+	case ElementTypeEndOfContainer:
+		return "end-of-container"
+	}
+	return fmt.Sprintf("unknown(0x%02X)", uint8(et))
+}
+
+type TagControl uint8
+
+const (
+	TagControlAnonymous TagControl = iota
+	TagControlContextSpecific
+	TagControlCommonProfile2
+	TagControlCommonProfile4
+	TagControlImplicitProfile2
+	TagControlImplicitProfile4
+	TagControlFullyQualified6
+	TagControlFullyQualified8
+)
+
+type Tag struct {
+	Control       TagControl
+	VendorID      uint16
+	ProfileNumber uint16
+	Number        uint32
+}
+
+func AnonymousTag() Tag {
+	return Tag{Control: TagControlAnonymous}
+}
+
+func ContextTag(number uint8) Tag {
+	return Tag{Control: TagControlContextSpecific, Number: uint32(number)}
+}
+
+func (t Tag) IsAnonymous() bool {
+	return t.Control == TagControlAnonymous
+}
+
+func (t Tag) IsContext() bool {
+	return t.Control == TagControlContextSpecific
+}
+
+func (t Tag) String() string {
+	switch t.Control {
+	case TagControlAnonymous:
+		return "anonymous"
+	case TagControlContextSpecific:
+		return fmt.Sprintf("%d", t.Number)
+	case TagControlCommonProfile2, TagControlCommonProfile4:
+		return fmt.Sprintf("common:%d", t.Number)
+	case TagControlImplicitProfile2, TagControlImplicitProfile4:
+		return fmt.Sprintf("implicit:%d", t.Number)
+	default:
+		return fmt.Sprintf("0x%04X:0x%04X:%d", t.VendorID, t.ProfileNumber, t.Number)
+	}
+}
+
+// Element is a single TLV element; Value holds an int64, uint64, bool, float32, float64, string or []byte, and is nil
+// for nulls and containers, whose members are held in Elements
+type Element struct {
+	Tag      Tag
+	Type     ElementType
+	Value    any
+	Elements []*Element
+}
+
+func (e *Element) Member(tag Tag) *Element {
+	for _, m := range e.Elements {
+		if m.Tag == tag {
+			return m
+		}
+	}
+	return nil
+}
+
+func NewSigned(tag Tag, v int64) *Element {
+	et := ElementTypeInt64
+	switch {
+	case v >= -1<<7 && v < 1<<7:
+		et = ElementTypeInt8
+	case v >= -1<<15 && v < 1<<15:
+		et = ElementTypeInt16
+	case v >= -1<<31 && v < 1<<31:
+		et = ElementTypeInt32
+	}
+	return &Element{Tag: tag, Type: et, Value: v}
+}
+
+func NewUnsigned(tag Tag, v uint64) *Element {
+	et := ElementTypeUInt64
+	switch {
+	case v < 1<<8:
+		et = ElementTypeUInt8
+	case v < 1<<16:
+		et = ElementTypeUInt16
+	case v < 1<<32:
+		et = ElementTypeUInt32
+	}
+	return &Element{Tag: tag, Type: et, Value: v}
+}
+
+func NewBoolean(tag Tag, v bool) *Element {
+	if v {
+		return &Element{Tag: tag, Type: ElementTypeTrue, Value: true}
+	}
+	return &Element{Tag: tag, Type: ElementTypeFalse, Value: false}
+}
+
+func NewFloat(tag Tag, v float32) *Element {
+	return &Element{Tag: tag, Type: ElementTypeFloat, Value: v}
+}
+
+func NewDouble(tag Tag, v float64) *Element {
+	return &Element{Tag: tag, Type: ElementTypeDouble, Value: v}
+}
+
+func NewUTF8String(tag Tag, v string) *Element {
+	return &Element{Tag: tag, Type: ElementTypeUTF8String1 + lengthType(len(v)), Value: v}
+}
+
+func NewOctetString(tag Tag, v []byte) *Element {
+	return &Element{Tag: tag, Type: ElementTypeOctetString1 + lengthType(len(v)), Value: v}
+}
+
+func NewNull(tag Tag) *Element {
+	return &Element{Tag: tag, Type: ElementTypeNull}
+}
+
+func NewContainer(tag Tag, et ElementType, elements ...*Element) *Element {
+	return &Element{Tag: tag, Type: et, Elements: elements}
+}
+
+func lengthType(length int) ElementType {
+	switch {
+	case length < 1<<8:
+		return 0
+	case length < 1<<16:
+		return 1
+	case uint64(length) < 1<<32:
+		return 2
+	default:
+		return 3
+	}
+}
@@ -0,0 +1,569 @@
+package tlv
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// Object is a decoded structure whose members keep the order in which they were encoded
+type Object []Member
+
+type Member struct {
+	Name  string
+	Value any
+}
+
+func (o Object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		name, err := json.Marshal(m.Name)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(name)
+		b.WriteByte(':')
+		value, err := json.Marshal(m.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling %s: %w", m.Name, err)
+		}
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// DecodeFields decodes a TLV structure whose members are described by fields, such as the payload of a command or
+// an event, naming each member after its field
+func DecodeFields(b []byte, fields matter.FieldSet) (Object, error) {
+	el, err := Decode(b)
+	if err != nil {
+		return nil, err
+	}
+	return decodeStructure(el, fields, false)
+}
+
+// DecodeStruct decodes a TLV structure holding an instance of s
+func DecodeStruct(b []byte, s *matter.Struct) (Object, error) {
+	el, err := Decode(b)
+	if err != nil {
+		return nil, err
+	}
+	return decodeStructure(el, s.Fields, s.FabricScoping == matter.FabricScopingScoped)
+}
+
+// DecodeValue decodes a single TLV element holding a value of dataType, such as the value of an attribute
+func DecodeValue(b []byte, dataType *types.DataType) (any, error) {
+	el, err := Decode(b)
+	if err != nil {
+		return nil, err
+	}
+	return decodeValue(el, dataType)
+}
+
+// Generic converts an element to JSON-compatible values without a model; structure members are named after their tags
+func Generic(el *Element) any {
+	switch {
+	case el.Type == ElementTypeStructure:
+		o := make(Object, 0, len(el.Elements))
+		for _, m := range el.Elements {
+			o = append(o, Member{Name: m.Tag.String(), Value: Generic(m)})
+		}
+		return o
+	case el.Type == ElementTypeArray || el.Type == ElementTypeList:
+		list := make([]any, 0, len(el.Elements))
+		for _, m := range el.Elements {
+			list = append(list, Generic(m))
+		}
+		return list
+	case el.Type.IsOctetString():
+		return hex.EncodeToString(el.Value.([]byte))
+	}
+	return el.Value
+}
+
+func decodeStructure(el *Element, fields matter.FieldSet, fabricScoped bool) (Object, error) {
+	if el.Type != ElementTypeStructure {
+		return nil, fmt.Errorf("expected structure, got %s", el.Type)
+	}
+	o := make(Object, 0, len(el.Elements))
+	for _, m := range el.Elements {
+		if !m.Tag.IsContext() {
+			o = append(o, Member{Name: m.Tag.String(), Value: Generic(m)})
+			continue
+		}
+		field := fieldByID(fields, uint64(m.Tag.Number))
+		if field == nil && fabricScoped && m.Tag.Number == matter.FabricIndexFieldID {
+			field = fabricIndexField()
+		}
+		if field == nil {
+			o = append(o, Member{Name: m.Tag.String(), Value: Generic(m)})
+			continue
+		}
+		value, err := decodeValue(m, field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Name, err)
+		}
+		o = append(o, Member{Name: field.Name, Value: value})
+	}
+	return o, nil
+}
+
+func decodeValue(el *Element, dataType *types.DataType) (any, error) {
+	if el.Type == ElementTypeNull {
+		return nil, nil
+	}
+	if dataType == nil {
+		return Generic(el), nil
+	}
+	if dataType.IsArray() {
+		if el.Type != ElementTypeArray && el.Type != ElementTypeList {
+			return nil, fmt.Errorf("expected array, got %s", el.Type)
+		}
+		list := make([]any, 0, len(el.Elements))
+		for i, m := range el.Elements {
+			v, err := decodeValue(m, dataType.EntryType)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	}
+	switch entity := dataType.Entity.(type) {
+	case *matter.Struct:
+		return decodeStructure(el, entity.Fields, entity.FabricScoping == matter.FabricScopingScoped)
+	case *matter.Enum:
+		if !el.Type.IsUnsigned() {
+			return nil, fmt.Errorf("expected unsigned integer for enum %s, got %s", entity.Name, el.Type)
+		}
+		v := el.Value.(uint64)
+		for _, ev := range entity.Values {
+			if ev.Value.Valid() && ev.Value.Value() == v {
+				return ev.Name, nil
+			}
+		}
+		return v, nil
+	case *matter.TypeDef:
+		return decodeValue(el, entity.Type)
+	case *matter.Bitmap:
+		if !el.Type.IsUnsigned() {
+			return nil, fmt.Errorf("expected unsigned integer for bitmap %s, got %s", entity.Name, el.Type)
+		}
+		return el.Value, nil
+	}
+	kind := valueKindOf(dataType.BaseType)
+	if kind == valueKindUnknown {
+		return Generic(el), nil
+	}
+	if kind != valueKindOfElement(el.Type) {
+		return nil, fmt.Errorf("expected %s for %s, got %s", kind, dataType.Name, el.Type)
+	}
+	if kind == valueKindOctetString {
+		return hex.EncodeToString(el.Value.([]byte)), nil
+	}
+	return el.Value, nil
+}
+
+// EncodeFields encodes a JSON object as a TLV structure whose members are described by fields; members may be named
+// by field name or ID
+func EncodeFields(data []byte, fields matter.FieldSet) ([]byte, error) {
+	v, err := unmarshalJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	el, err := encodeStructure(AnonymousTag(), v, fields, false)
+	if err != nil {
+		return nil, err
+	}
+	return Encode(el)
+}
+
+// EncodeStruct encodes a JSON object as a TLV structure holding an instance of s
+func EncodeStruct(data []byte, s *matter.Struct) ([]byte, error) {
+	v, err := unmarshalJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	el, err := encodeStructure(AnonymousTag(), v, s.Fields, s.FabricScoping == matter.FabricScopingScoped)
+	if err != nil {
+		return nil, err
+	}
+	return Encode(el)
+}
+
+// EncodeValue encodes a JSON value as a single TLV element holding a value of dataType
+func EncodeValue(data []byte, dataType *types.DataType) ([]byte, error) {
+	v, err := unmarshalJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	el, err := encodeValue(AnonymousTag(), v, dataType)
+	if err != nil {
+		return nil, err
+	}
+	return Encode(el)
+}
+
+func unmarshalJSON(data []byte) (v any, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err = decoder.Decode(&v)
+	if err != nil {
+		err = fmt.Errorf("error parsing JSON: %w", err)
+	}
+	return
+}
+
+func encodeStructure(tag Tag, v any, fields matter.FieldSet, fabricScoped bool) (*Element, error) {
+	o, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected object, got %s", jsonType(v))
+	}
+	el := NewContainer(tag, ElementTypeStructure)
+	present := make(map[*matter.Field]struct{}, len(o))
+	for name, value := range o {
+		field := fieldByName(fields, name)
+		if field == nil && fabricScoped && (strings.EqualFold(name, "FabricIndex") || name == strconv.Itoa(matter.FabricIndexFieldID)) {
+			field = fabricIndexField()
+		}
+		if field == nil {
+			return nil, fmt.Errorf("unknown field %s", name)
+		}
+		if !field.ID.Valid() || field.ID.Value() > math.MaxUint8 {
+			return nil, fmt.Errorf("field %s has no valid ID", field.Name)
+		}
+		if value == nil && !field.Quality.Has(matter.QualityNullable) {
+			return nil, fmt.Errorf("%s: null value for field which is not nullable", field.Name)
+		}
+		member, err := encodeValue(ContextTag(uint8(field.ID.Value())), value, field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.Name, err)
+		}
+		present[field] = struct{}{}
+		el.Elements = append(el.Elements, member)
+	}
+	for _, field := range fields {
+		if _, ok := present[field]; !ok && conformance.IsMandatory(field.Conformance) {
+			return nil, fmt.Errorf("missing mandatory field %s", field.Name)
+		}
+	}
+	slices.SortFunc(el.Elements, func(a, b *Element) int {
+		return int(a.Tag.Number) - int(b.Tag.Number)
+	})
+	return el, nil
+}
+
+func encodeValue(tag Tag, v any, dataType *types.DataType) (*Element, error) {
+	if v == nil {
+		return NewNull(tag), nil
+	}
+	if dataType == nil {
+		return encodeGeneric(tag, v)
+	}
+	if dataType.IsArray() {
+		list, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected array, got %s", jsonType(v))
+		}
+		el := NewContainer(tag, ElementTypeArray)
+		for i, entry := range list {
+			member, err := encodeValue(AnonymousTag(), entry, dataType.EntryType)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			el.Elements = append(el.Elements, member)
+		}
+		return el, nil
+	}
+	switch entity := dataType.Entity.(type) {
+	case *matter.Struct:
+		return encodeStructure(tag, v, entity.Fields, entity.FabricScoping == matter.FabricScopingScoped)
+	case *matter.Enum:
+		if name, ok := v.(string); ok {
+			for _, ev := range entity.Values {
+				if strings.EqualFold(ev.Name, name) && ev.Value.Valid() {
+					return NewUnsigned(tag, ev.Value.Value()), nil
+				}
+			}
+		}
+		u, err := parseUnsigned(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for enum %s: %w", entity.Name, err)
+		}
+		return NewUnsigned(tag, u), nil
+	case *matter.TypeDef:
+		return encodeValue(tag, v, entity.Type)
+	case *matter.Bitmap:
+		u, err := parseUnsigned(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for bitmap %s: %w", entity.Name, err)
+		}
+		return NewUnsigned(tag, u), nil
+	}
+	switch valueKindOf(dataType.BaseType) {
+	case valueKindUnsigned:
+		u, err := parseUnsigned(v)
+		if err != nil {
+			return nil, err
+		}
+		return NewUnsigned(tag, u), nil
+	case valueKindSigned:
+		i, err := parseSigned(v)
+		if err != nil {
+			return nil, err
+		}
+		return NewSigned(tag, i), nil
+	case valueKindBoolean:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected boolean, got %s", jsonType(v))
+		}
+		return NewBoolean(tag, b), nil
+	case valueKindFloat:
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("expected number, got %s", jsonType(v))
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return nil, err
+		}
+		if dataType.BaseType == types.BaseDataTypeSingle {
+			return NewFloat(tag, float32(f)), nil
+		}
+		return NewDouble(tag, f), nil
+	case valueKindUTF8String:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %s", jsonType(v))
+		}
+		return NewUTF8String(tag, s), nil
+	case valueKindOctetString:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected hex string, got %s", jsonType(v))
+		}
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex string: %w", err)
+		}
+		return NewOctetString(tag, b), nil
+	}
+	return encodeGeneric(tag, v)
+}
+
+// encodeGeneric encodes a value without a model; object members must be named by their context tag
+func encodeGeneric(tag Tag, v any) (*Element, error) {
+	switch v := v.(type) {
+	case nil:
+		return NewNull(tag), nil
+	case bool:
+		return NewBoolean(tag, v), nil
+	case json.Number:
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return NewUnsigned(tag, u), nil
+		}
+		if i, err := v.Int64(); err == nil {
+			return NewSigned(tag, i), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return NewDouble(tag, f), nil
+	case string:
+		return NewUTF8String(tag, v), nil
+	case []any:
+		el := NewContainer(tag, ElementTypeArray)
+		for _, entry := range v {
+			member, err := encodeGeneric(AnonymousTag(), entry)
+			if err != nil {
+				return nil, err
+			}
+			el.Elements = append(el.Elements, member)
+		}
+		return el, nil
+	case map[string]any:
+		el := NewContainer(tag, ElementTypeStructure)
+		for name, value := range v {
+			number, err := strconv.ParseUint(name, 0, 8)
+			if err != nil {
+				return nil, fmt.Errorf("member %s is not a context tag", name)
+			}
+			member, err := encodeGeneric(ContextTag(uint8(number)), value)
+			if err != nil {
+				return nil, err
+			}
+			el.Elements = append(el.Elements, member)
+		}
+		slices.SortFunc(el.Elements, func(a, b *Element) int {
+			return int(a.Tag.Number) - int(b.Tag.Number)
+		})
+		return el, nil
+	}
+	return nil, fmt.Errorf("unexpected JSON value: %T", v)
+}
+
+func parseUnsigned(v any) (uint64, error) {
+	switch v := v.(type) {
+	case json.Number:
+		return strconv.ParseUint(v.String(), 10, 64)
+	case string:
+		return strconv.ParseUint(v, 0, 64)
+	}
+	return 0, fmt.Errorf("expected unsigned integer, got %s", jsonType(v))
+}
+
+func parseSigned(v any) (int64, error) {
+	switch v := v.(type) {
+	case json.Number:
+		return strconv.ParseInt(v.String(), 10, 64)
+	case string:
+		return strconv.ParseInt(v, 0, 64)
+	}
+	return 0, fmt.Errorf("expected integer, got %s", jsonType(v))
+}
+
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func fieldByID(fields matter.FieldSet, id uint64) *matter.Field {
+	for _, f := range fields {
+		if f.ID.Valid() && f.ID.Value() == id {
+			return f
+		}
+	}
+	return nil
+}
+
+func fieldByName(fields matter.FieldSet, name string) *matter.Field {
+	for _, f := range fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, name) {
+			return f
+		}
+	}
+	if id, err := strconv.ParseUint(name, 0, 64); err == nil {
+		return fieldByID(fields, id)
+	}
+	return nil
+}
+
+func fabricIndexField() *matter.Field {
+	field := matter.NewField(nil, nil, types.EntityTypeStructField)
+	field.ID = matter.NewNumber(matter.FabricIndexFieldID)
+	field.Name = "FabricIndex"
+	field.Type = types.NewDataType(types.BaseDataTypeFabricIndex, types.DataTypeRankScalar)
+	return field
+}
+
+type valueKind uint8
+
+const (
+	valueKindUnknown valueKind = iota
+	valueKindUnsigned
+	valueKindSigned
+	valueKindBoolean
+	valueKindFloat
+	valueKindUTF8String
+	valueKindOctetString
+)
+
+func (vk valueKind) String() string {
+	switch vk {
+	case valueKindUnsigned:
+		return "unsigned integer"
+	case valueKindSigned:
+		return "signed integer"
+	case valueKindBoolean:
+		return "boolean"
+	case valueKindFloat:
+		return "float"
+	case valueKindUTF8String:
+		return "UTF-8 string"
+	case valueKindOctetString:
+		return "octet string"
+	}
+	return "unknown"
+}
+
+func valueKindOf(baseType types.BaseDataType) valueKind {
+	switch baseType {
+	case types.BaseDataTypeBoolean:
+		return valueKindBoolean
+	case types.BaseDataTypeSingle, types.BaseDataTypeDouble:
+		return valueKindFloat
+	case types.BaseDataTypeString:
+		return valueKindUTF8String
+	case types.BaseDataTypeOctStr, types.BaseDataTypeMessageID, types.BaseDataTypeIPAddress, types.BaseDataTypeIPv4Address,
+		types.BaseDataTypeIPv6Address, types.BaseDataTypeIPv6Prefix, types.BaseDataTypeHardwareAddress:
+		return valueKindOctetString
+	case types.BaseDataTypeInt8, types.BaseDataTypeInt16, types.BaseDataTypeInt24, types.BaseDataTypeInt32,
+		types.BaseDataTypeInt40, types.BaseDataTypeInt48, types.BaseDataTypeInt56, types.BaseDataTypeInt64,
+		types.BaseDataTypeTemperature, types.BaseDataTypeTemperatureDifference, types.BaseDataTypeSignedTemperature,
+		types.BaseDataTypeAmperage, types.BaseDataTypeVoltage, types.BaseDataTypePower, types.BaseDataTypeEnergy,
+		types.BaseDataTypeApparentPower, types.BaseDataTypeApparentEnergy, types.BaseDataTypeReactivePower,
+		types.BaseDataTypeReactiveEnergy, types.BaseDataTypeMoney:
+		return valueKindSigned
+	case types.BaseDataTypeEnum8, types.BaseDataTypeEnum16, types.BaseDataTypePriority, types.BaseDataTypeStatus,
+		types.BaseDataTypeEndpointID, types.BaseDataTypeUnsignedTemperature, types.BaseDataTypeNamespaceID,
+		types.BaseDataTypeTag, types.BaseDataTypeSubjectID, types.BaseDataTypeIeeeAddress,
+		types.BaseDataTypeTimeOfDay, types.BaseDataTypeDate:
+		return valueKindUnsigned
+	}
+	if baseType.IsUnsigned() && baseType != types.BaseDataTypeBoolean {
+		return valueKindUnsigned
+	}
+	return valueKindUnknown
+}
+
+func valueKindOfElement(et ElementType) valueKind {
+	switch {
+	case et.IsUnsigned():
+		return valueKindUnsigned
+	case et.IsSigned():
+		return valueKindSigned
+	case et.IsBoolean():
+		return valueKindBoolean
+	case et == ElementTypeFloat, et == ElementTypeDouble:
+		return valueKindFloat
+	case et.IsUTF8String():
+		return valueKindUTF8String
+	case et.IsOctetString():
+		return valueKindOctetString
+	}
+	return valueKindUnknown
+}
@@ -0,0 +1,143 @@
+package tlv
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestCodec(t *testing.T) {
+	tests := []struct {
+		hex     string
+		element *Element
+	}{
+		{"08", NewBoolean(AnonymousTag(), false)},
+		{"002a", NewSigned(AnonymousTag(), 42)},
+		{"00ef", NewSigned(AnonymousTag(), -17)},
+		{"01a601", NewSigned(AnonymousTag(), 422)},
+		{"042a", NewUnsigned(AnonymousTag(), 42)},
+		{"0c0648656c6c6f21", NewUTF8String(AnonymousTag(), "Hello!")},
+		{"1003000102", NewOctetString(AnonymousTag(), []byte{0, 1, 2})},
+		{"14", NewNull(AnonymousTag())},
+		{"0a00000000", NewFloat(AnonymousTag(), 0)},
+		{"1518", NewContainer(AnonymousTag(), ElementTypeStructure)},
+		{"152401 2a18", NewContainer(AnonymousTag(), ElementTypeStructure, NewUnsigned(ContextTag(1), 42))},
+		{"16000000010002 18", NewContainer(AnonymousTag(), ElementTypeArray, NewSigned(AnonymousTag(), 0), NewSigned(AnonymousTag(), 1), NewSigned(AnonymousTag(), 2))},
+		{"c4f1ffedde01002a", NewUnsigned(Tag{Control: TagControlFullyQualified6, VendorID: 0xFFF1, ProfileNumber: 0xDEED, Number: 1}, 42)},
+	}
+	for _, test := range tests {
+		b, err := hex.DecodeString(stripSpaces(test.hex))
+		if err != nil {
+			t.Fatal(err)
+		}
+		el, err := Decode(b)
+		if err != nil {
+			t.Errorf("error decoding %s: %v", test.hex, err)
+			continue
+		}
+		encoded, err := Encode(test.element)
+		if err != nil {
+			t.Errorf("error encoding %s: %v", test.hex, err)
+			continue
+		}
+		if !bytes.Equal(encoded, b) {
+			t.Errorf("expected %x, got %x", b, encoded)
+		}
+		reencoded, err := Encode(el)
+		if err != nil || !bytes.Equal(reencoded, b) {
+			t.Errorf("decoded %s did not round trip: %x %v", test.hex, reencoded, err)
+		}
+	}
+
+	for _, invalid := range []string{"", "04", "1524012a", "0c05414243", "18", "042a2a", "16240100 18"} {
+		b, _ := hex.DecodeString(stripSpaces(invalid))
+		if _, err := Decode(b); err == nil {
+			t.Errorf("expected error decoding %q", invalid)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	enum := matter.NewEnum(nil, nil)
+	enum.Name = "EffectEnum"
+	value := matter.NewEnumValue(nil, enum)
+	value.Name = "Blink"
+	value.Value = matter.NewNumber(1)
+	enum.Values = append(enum.Values, value)
+
+	target := matter.NewStruct(nil, nil)
+	target.Name = "TargetStruct"
+	target.FabricScoping = matter.FabricScopingScoped
+	target.Fields = matter.FieldSet{newField(0, "Node", types.NewDataType(types.BaseDataTypeNodeID, types.DataTypeRankScalar), false)}
+
+	targets := types.NewCustomDataType("TargetStruct", types.DataTypeRankList)
+	targets.EntryType.Entity = target
+	effect := types.NewCustomDataType("EffectEnum", types.DataTypeRankScalar)
+	effect.Entity = enum
+
+	fields := matter.FieldSet{
+		newField(0, "Effect", effect, false),
+		newField(1, "Label", types.NewDataType(types.BaseDataTypeString, types.DataTypeRankScalar), false),
+		newField(2, "Offset", types.NewDataType(types.BaseDataTypeInt16, types.DataTypeRankScalar), true),
+		newField(3, "Key", types.NewDataType(types.BaseDataTypeOctStr, types.DataTypeRankScalar), false),
+		newField(4, "Targets", targets, false),
+	}
+	fields[2].Quality = matter.QualityNullable
+
+	payload := "15 240001 2c01026869 3402 300302abcd 3604 15 2500e803 24fe01 18 18 18"
+	b, _ := hex.DecodeString(stripSpaces(payload))
+	o, err := DecodeFields(b, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j, err := json.Marshal(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"Effect":"Blink","Label":"hi","Offset":null,"Key":"abcd","Targets":[{"Node":1000,"FabricIndex":1}]}`
+	if string(j) != expected {
+		t.Errorf("expected %s, got %s", expected, j)
+	}
+
+	encoded, err := EncodeFields(j, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, b) {
+		t.Errorf("expected %x, got %x", b, encoded)
+	}
+
+	for _, invalid := range []string{
+		`{"Effect":1,"Label":"hi","Key":"","Targets":[],"Bogus":1}`,
+		`{"Effect":1,"Label":null,"Key":"","Targets":[]}`,
+		`{"Effect":1,"Key":"","Targets":[]}`,
+		`{"Effect":1,"Label":"hi","Key":"zz","Targets":[]}`,
+	} {
+		if _, err := EncodeFields([]byte(invalid), fields); err == nil {
+			t.Errorf("expected error encoding %s", invalid)
+		}
+	}
+}
+
+func newField(id uint64, name string, dataType *types.DataType, optional bool) *matter.Field {
+	field := matter.NewField(nil, nil, types.EntityTypeCommandField)
+	field.ID = matter.NewNumber(id)
+	field.Name = name
+	field.Type = dataType
+	if optional {
+		field.Conformance = conformance.Set{&conformance.Optional{}}
+	} else {
+		field.Conformance = conformance.Set{&conformance.Mandatory{}}
+	}
+	return field
+}
+
+func stripSpaces(s string) string {
+	return strings.ReplaceAll(s, " ", "")
+}
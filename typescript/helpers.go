@@ -0,0 +1,365 @@
+package typescript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
+	"github.com/mailgun/raymond/v2"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// commentHelper renders a description as a JSDoc comment, indented by indent; long descriptions are wrapped
+func commentHelper(description string, indent string) raymond.SafeString {
+	words := strings.Fields(strings.ReplaceAll(description, "*/", "*\\/"))
+	if len(words) == 0 {
+		return ""
+	}
+	var lines []string
+	var line strings.Builder
+	for _, word := range words {
+		if line.Len() > 0 && line.Len()+len(word) > 100 {
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+		if line.Len() > 0 {
+			line.WriteRune(' ')
+		}
+		line.WriteString(word)
+	}
+	lines = append(lines, line.String())
+	var comment strings.Builder
+	if len(lines) == 1 {
+		fmt.Fprintf(&comment, "%s/** %s */\n", indent, lines[0])
+		return raymond.SafeString(comment.String())
+	}
+	fmt.Fprintf(&comment, "%s/**\n", indent)
+	for _, l := range lines {
+		fmt.Fprintf(&comment, "%s * %s\n", indent, l)
+	}
+	fmt.Fprintf(&comment, "%s */\n", indent)
+	return raymond.SafeString(comment.String())
+}
+
+func idHelper(value any) raymond.SafeString {
+	var id *matter.Number
+	switch value := value.(type) {
+	case matter.Number:
+		id = &value
+	case *matter.Number:
+		id = value
+	}
+	if !id.Valid() {
+		return "undefined"
+	}
+	return raymond.SafeString(id.HexString())
+}
+
+func typeNameHelper(name string) raymond.SafeString {
+	return raymond.SafeString(matter.Case(name))
+}
+
+func propertyNameHelper(name string) raymond.SafeString {
+	return raymond.SafeString(identifier(strcase.ToLowerCamel(matter.Case(name))))
+}
+
+func memberNameHelper(name string) raymond.SafeString {
+	return raymond.SafeString(identifier(matter.Case(name)))
+}
+
+// identifier quotes names which are not valid TypeScript identifiers, such as those starting with a digit
+func identifier(name string) string {
+	for i, r := range name {
+		if r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+		return fmt.Sprintf("%q", name)
+	}
+	if name == "" {
+		return `""`
+	}
+	return name
+}
+
+func fieldTypeHelper(value any) raymond.SafeString {
+	field, ok := deref[matter.Field](value)
+	if !ok {
+		return "unknown"
+	}
+	t := typeScriptType(field.Type)
+	if field.Quality.Has(matter.QualityNullable) {
+		t += " | null"
+	}
+	return raymond.SafeString(t)
+}
+
+func tsTypeHelper(value any) raymond.SafeString {
+	dataType, _ := deref[types.DataType](value)
+	return raymond.SafeString(typeScriptType(dataType))
+}
+
+// typeScriptType returns the TypeScript type of a Matter data type; integers which may not fit in a double are
+// typed as number or bigint
+func typeScriptType(dataType *types.DataType) string {
+	if dataType == nil {
+		return "unknown"
+	}
+	if dataType.IsArray() {
+		entry := typeScriptType(dataType.EntryType)
+		if strings.Contains(entry, " ") {
+			entry = "(" + entry + ")"
+		}
+		return entry + "[]"
+	}
+	switch entity := dataType.Entity.(type) {
+	case *matter.Enum:
+		return matter.Case(entity.Name)
+	case *matter.Struct:
+		return matter.Case(entity.Name)
+	case *matter.TypeDef:
+		return matter.Case(entity.Name)
+	case *matter.Bitmap:
+		if entity.Type != nil {
+			return scalarType(entity.Type)
+		}
+		return "number"
+	}
+	if dataType.BaseType == types.BaseDataTypeCustom {
+		return "unknown"
+	}
+	return scalarType(dataType)
+}
+
+func scalarType(dataType *types.DataType) string {
+	switch dataType.BaseType {
+	case types.BaseDataTypeBoolean:
+		return "boolean"
+	case types.BaseDataTypeString:
+		return "string"
+	case types.BaseDataTypeOctStr, types.BaseDataTypeIPAddress, types.BaseDataTypeIPv4Address, types.BaseDataTypeIPv6Address,
+		types.BaseDataTypeIPv6Prefix, types.BaseDataTypeHardwareAddress, types.BaseDataTypeMessageID:
+		return "Uint8Array"
+	case types.BaseDataTypeSingle, types.BaseDataTypeDouble:
+		return "number"
+	}
+	// Doubles hold integers of up to 53 bits exactly
+	if size := dataType.Size(); size > 0 && size <= 6 {
+		return "number"
+	}
+	return "number | bigint"
+}
+
+// dataTypeHelper renders the name of a data type as the spec gives it
+func dataTypeHelper(value any) raymond.SafeString {
+	dataType, _ := deref[types.DataType](value)
+	return raymond.SafeString(dataTypeName(dataType))
+}
+
+func dataTypeName(dataType *types.DataType) string {
+	if dataType == nil {
+		return "unknown"
+	}
+	if dataType.IsArray() {
+		return "list[" + dataTypeName(dataType.EntryType) + "]"
+	}
+	if dataType.Entity != nil {
+		if name := matter.EntityName(dataType.Entity); name != "" {
+			return name
+		}
+	}
+	return dataType.Name
+}
+
+func ifOptionalHelper(c conformance.Set, options *raymond.Options) string {
+	if !conformance.IsMandatory(c) {
+		return options.Fn()
+	}
+	return options.Inverse()
+}
+
+func bitNameHelper(value any) raymond.SafeString {
+	bit := asBit(value)
+	if bit == nil {
+		return raymond.SafeString(fmt.Sprintf("unexpected bit type: %T", value))
+	}
+	return memberNameHelper(bit.Name())
+}
+
+func bitMaskHelper(value any) raymond.SafeString {
+	bit := asBit(value)
+	if bit == nil {
+		return raymond.SafeString(fmt.Sprintf("0 /* unexpected bit type: %T */", value))
+	}
+	mask, err := bit.Mask()
+	if err != nil {
+		return raymond.SafeString(fmt.Sprintf("0 /* error converting bitmap mask: %v */", err))
+	}
+	return raymond.SafeString(fmt.Sprintf("0x%X", mask))
+}
+
+func bitSummaryHelper(value any) string {
+	bit := asBit(value)
+	if bit == nil {
+		return ""
+	}
+	return bit.Summary()
+}
+
+// deref recovers an entity, which the template engine passes by value when resolving a path, and by reference
+// otherwise
+func deref[T any](value any) (*T, bool) {
+	switch value := value.(type) {
+	case T:
+		return &value, true
+	case *T:
+		return value, value != nil
+	}
+	return nil, false
+}
+
+// asBit recovers the bit of a bitmap or feature map, which the template engine passes by value
+func asBit(value any) matter.Bit {
+	switch value := value.(type) {
+	case matter.BitmapBit:
+		return &value
+	case matter.Feature:
+		return &value
+	case matter.Bit:
+		return value
+	}
+	return nil
+}
+
+var qualityNames = []struct {
+	quality matter.Quality
+	name    string
+}{
+	{matter.QualityNullable, "nullable"},
+	{matter.QualityNonVolatile, "nonVolatile"},
+	{matter.QualityFixed, "fixed"},
+	{matter.QualityScene, "scene"},
+	{matter.QualityReportable, "reportable"},
+	{matter.QualityChangedOmitted, "changedOmitted"},
+	{matter.QualityDiagnostics, "diagnostics"},
+	{matter.QualitySingleton, "singleton"},
+	{matter.QualityLargeMessage, "largeMessage"},
+	{matter.QualitySourceAttribution, "sourceAttribution"},
+	{matter.QualityAtomicWrite, "atomicWrite"},
+	{matter.QualityQuieterReporting, "quieterReporting"},
+}
+
+func qualitiesHelper(q matter.Quality) raymond.SafeString {
+	var names []string
+	for _, qn := range qualityNames {
+		if q.Has(qn.quality) {
+			names = append(names, fmt.Sprintf("%q", qn.name))
+		}
+	}
+	return raymond.SafeString("[" + strings.Join(names, ", ") + "]")
+}
+
+// jsonHelper renders a value as JSON, which TypeScript accepts as an object literal
+func jsonHelper(value any) raymond.SafeString {
+	if c, ok := value.(conformance.Set); ok && c == nil {
+		return "[]"
+	}
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(value)
+	if err != nil {
+		return raymond.SafeString(fmt.Sprintf("undefined /* error marshaling JSON: %v */", err))
+	}
+	return raymond.SafeString(strings.TrimSpace(b.String()))
+}
+
+func requestNameHelper(value any) raymond.SafeString {
+	command, ok := deref[matter.Command](value)
+	if !ok {
+		return ""
+	}
+	return raymond.SafeString(matter.WithSuffix(matter.Case(command.Name), "Request"))
+}
+
+func responseNameHelper(value any) raymond.SafeString {
+	command, ok := deref[matter.Command](value)
+	if !ok {
+		return ""
+	}
+	return raymond.SafeString(matter.WithSuffix(matter.Case(command.Name), "Response"))
+}
+
+// responseTypeHelper renders the type a request resolves to; requests without a response command resolve to void
+func responseTypeHelper(value any) raymond.SafeString {
+	command, ok := deref[matter.Command](value)
+	if ok && command.Response != nil {
+		if response, ok := command.Response.Entity.(*matter.Command); ok {
+			return responseNameHelper(response)
+		}
+	}
+	return "void"
+}
+
+func eventNameHelper(value any) raymond.SafeString {
+	event, ok := deref[matter.Event](value)
+	if !ok {
+		return ""
+	}
+	return raymond.SafeString(matter.WithSuffix(matter.Case(event.Name), "Event"))
+}
+
+func ifHasFieldsHelper(fields matter.FieldSet, options *raymond.Options) string {
+	for _, f := range fields {
+		if matter.IsIncluded(f.Conformance) {
+			return options.Fn()
+		}
+	}
+	return options.Inverse()
+}
+
+func includedFieldsHelper(fields matter.FieldSet, options *raymond.Options) raymond.SafeString {
+	var result strings.Builder
+	for _, f := range fields {
+		if matter.IsIncluded(f.Conformance) {
+			result.WriteString(options.FnWith(f))
+		}
+	}
+	return raymond.SafeString(result.String())
+}
+
+func includedValuesHelper(values matter.EnumValueSet, options *raymond.Options) raymond.SafeString {
+	var result strings.Builder
+	for _, v := range values {
+		if v.Value.Valid() && matter.IsIncluded(v.Conformance) {
+			result.WriteString(options.FnWith(v))
+		}
+	}
+	return raymond.SafeString(result.String())
+}
+
+func includedBitsHelper(bits matter.BitSet, options *raymond.Options) raymond.SafeString {
+	var result strings.Builder
+	for _, b := range matter.IncludedBits(bits) {
+		result.WriteString(options.FnWith(b))
+	}
+	return raymond.SafeString(result.String())
+}
+
+// ifImplicitFabricIndexHelper renders its block for fabric-scoped structs which do not declare their FabricIndex field
+func ifImplicitFabricIndexHelper(value any, options *raymond.Options) string {
+	s, ok := deref[matter.Struct](value)
+	if !ok || s.FabricScoping != matter.FabricScopingScoped {
+		return options.Inverse()
+	}
+	for _, f := range s.Fields {
+		if f.ID.Valid() && f.ID.Value() == matter.FabricIndexFieldID {
+			return options.Inverse()
+		}
+	}
+	return options.Fn()
+}
@@ -0,0 +1,180 @@
+package typescript
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/mailgun/raymond/v2"
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type Options struct {
+	TypeScriptRoot string `default:"typescript" name:"typescript-root" help:"where to place the TypeScript modules" group:"TypeScript:"`
+	TemplateRoot   string `default:"" help:"the root of your local template files; if not specified, Alchemy will use an internal copy" group:"TypeScript:"`
+}
+
+type Renderer struct {
+	spec    *spec.Specification
+	options Options
+}
+
+func NewRenderer(spec *spec.Specification, options Options) *Renderer {
+	return &Renderer{spec: spec, options: options}
+}
+
+func (r *Renderer) Name() string {
+	return "Rendering TypeScript"
+}
+
+func (r *Renderer) Process(cxt context.Context, input *pipeline.Data[*asciidoc.Document], index int32, total int32) (outputs []*pipeline.Data[string], extra []*pipeline.Data[*asciidoc.Document], err error) {
+	var t *raymond.Template
+	for _, e := range r.spec.EntitiesForDocument(input.Content) {
+		var clusters []*matter.Cluster
+		switch e := e.(type) {
+		case *matter.Cluster:
+			clusters = append(clusters, e)
+		case *matter.ClusterGroup:
+			clusters = append(clusters, e.Clusters...)
+		}
+		for _, c := range clusters {
+			if t == nil {
+				t, err = r.loadTemplate()
+				if err != nil {
+					return
+				}
+			}
+			var result string
+			result, err = t.Exec(clusterContext(c))
+			if err != nil {
+				err = fmt.Errorf("failed rendering TypeScript for %s: %w", input.Path, err)
+				return
+			}
+			outputs = append(outputs, pipeline.NewData(r.modulePath(c), result))
+		}
+	}
+	return
+}
+
+func (r *Renderer) modulePath(cluster *matter.Cluster) string {
+	return filepath.Join(r.options.TypeScriptRoot, matter.Case(cluster.Name)+".ts")
+}
+
+// clusterContext gathers the entities of a cluster which the module declares; global data types are declared in
+// each module which refers to them, so that modules stand alone
+func clusterContext(cluster *matter.Cluster) map[string]any {
+	c := &collector{cluster: cluster, seen: make(map[types.Entity]struct{})}
+	for _, en := range cluster.Enums {
+		c.add(en)
+	}
+	for _, bm := range cluster.Bitmaps {
+		c.add(bm)
+	}
+	for _, s := range cluster.Structs {
+		c.add(s)
+	}
+	for _, td := range cluster.TypeDefs {
+		c.add(td)
+	}
+
+	var attributes matter.FieldSet
+	for _, a := range cluster.Attributes {
+		if matter.IsIncluded(a.Conformance) {
+			attributes = append(attributes, a)
+			c.addType(a.Type)
+		}
+	}
+	var requests, responses []*matter.Command
+	for _, cmd := range cluster.Commands {
+		if !matter.IsIncluded(cmd.Conformance) {
+			continue
+		}
+		if cmd.Direction == matter.InterfaceClient {
+			responses = append(responses, cmd)
+		} else {
+			requests = append(requests, cmd)
+		}
+		for _, f := range cmd.Fields {
+			c.addType(f.Type)
+		}
+	}
+	var events []*matter.Event
+	for _, e := range cluster.Events {
+		if !matter.IsIncluded(e.Conformance) {
+			continue
+		}
+		events = append(events, e)
+		for _, f := range e.Fields {
+			c.addType(f.Type)
+		}
+	}
+
+	tc := map[string]any{
+		"cluster":    cluster,
+		"enums":      c.enums,
+		"bitmaps":    c.bitmaps,
+		"structs":    c.structs,
+		"typedefs":   c.typeDefs,
+		"attributes": attributes,
+		"requests":   requests,
+		"responses":  responses,
+		"events":     events,
+	}
+	if cluster.Features != nil && len(cluster.Features.Bits) > 0 {
+		tc["features"] = matter.IncludedBits(cluster.Features.Bits)
+	}
+	if r := cluster.Revisions.MostRecent(); r != nil && r.Number.Valid() {
+		tc["revision"] = r.Number.IntString()
+	}
+	return tc
+}
+
+type collector struct {
+	cluster  *matter.Cluster
+	seen     map[types.Entity]struct{}
+	enums    []*matter.Enum
+	bitmaps  []*matter.Bitmap
+	structs  []*matter.Struct
+	typeDefs []*matter.TypeDef
+}
+
+func (c *collector) add(entity types.Entity) {
+	if _, ok := c.seen[entity]; ok {
+		return
+	}
+	c.seen[entity] = struct{}{}
+	switch entity := entity.(type) {
+	case *matter.Enum:
+		c.enums = append(c.enums, entity)
+	case *matter.Bitmap:
+		c.bitmaps = append(c.bitmaps, entity)
+	case *matter.Struct:
+		c.structs = append(c.structs, entity)
+		for _, f := range entity.Fields {
+			if matter.IsIncluded(f.Conformance) {
+				c.addType(f.Type)
+			}
+		}
+	case *matter.TypeDef:
+		c.typeDefs = append(c.typeDefs, entity)
+		c.addType(entity.Type)
+	}
+}
+
+func (c *collector) addType(dataType *types.DataType) {
+	if dataType == nil {
+		return
+	}
+	if dataType.IsArray() {
+		c.addType(dataType.EntryType)
+		return
+	}
+	switch dataType.Entity.(type) {
+	case *matter.Enum, *matter.Bitmap, *matter.Struct, *matter.TypeDef:
+		c.add(dataType.Entity)
+	}
+}
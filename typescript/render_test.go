@@ -0,0 +1,148 @@
+package typescript
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestCommentHelper(t *testing.T) {
+	long := strings.Repeat("word ", 30)
+	tests := []struct {
+		description string
+		indent      string
+		expected    string
+	}{
+		{"", "", ""},
+		{"Locks the door", "  ", "  /** Locks the door */\n"},
+		// A description can't end the comment early
+		{"Matches a/*/b */ and more", "", "/** Matches a/*\\/b *\\/ and more */\n"},
+		{long, "", "/**\n * " + strings.TrimSpace(strings.Repeat("word ", 20)) + "\n * " + strings.TrimSpace(strings.Repeat("word ", 10)) + "\n */\n"},
+	}
+	for _, test := range tests {
+		if comment := string(commentHelper(test.description, test.indent)); comment != test.expected {
+			t.Errorf("expected comment on %q to be %q, got %q", test.description, test.expected, comment)
+		}
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"LockState": "LockState",
+		"_private":  "_private",
+		"2.4GHz":    `"2.4GHz"`,
+		"Sub-GHz":   `"Sub-GHz"`,
+		"":          `""`,
+	}
+	for name, expected := range tests {
+		if id := identifier(name); id != expected {
+			t.Errorf("expected %q to be written as %s, got %s", name, expected, id)
+		}
+	}
+}
+
+// TestFieldType checks the TypeScript types of fields; integers wider than a double can hold exactly may be bigints
+func TestFieldType(t *testing.T) {
+	lockState := matter.NewEnum(nil, nil)
+	lockState.Name = "LockStateEnum"
+	alarms := matter.NewBitmap(nil, nil)
+	alarms.Name = "AlarmMaskBitmap"
+	alarms.Type = types.NewDataType(types.BaseDataTypeMap64, types.DataTypeRankScalar)
+
+	tests := []struct {
+		dataType *types.DataType
+		nullable bool
+		expected string
+	}{
+		{types.NewDataType(types.BaseDataTypeUInt48, types.DataTypeRankScalar), false, "number"},
+		{types.NewDataType(types.BaseDataTypeUInt56, types.DataTypeRankScalar), false, "number | bigint"},
+		{types.NewDataType(types.BaseDataTypeEpochMicroseconds, types.DataTypeRankScalar), true, "number | bigint | null"},
+		{types.NewDataType(types.BaseDataTypeUInt64, types.DataTypeRankList), false, "(number | bigint)[]"},
+		{types.NewDataType(types.BaseDataTypeOctStr, types.DataTypeRankList), true, "Uint8Array[] | null"},
+		{&types.DataType{BaseType: types.BaseDataTypeCustom, Name: lockState.Name, Entity: lockState}, true, "LockStateEnum | null"},
+		{&types.DataType{BaseType: types.BaseDataTypeCustom, Name: alarms.Name, Entity: alarms}, false, "number | bigint"},
+		{types.NewCustomDataType("MysteryType", types.DataTypeRankScalar), false, "unknown"},
+	}
+	for _, test := range tests {
+		field := &matter.Field{Type: test.dataType}
+		if test.nullable {
+			field.Quality = matter.QualityNullable
+		}
+		if ts := string(fieldTypeHelper(field)); ts != test.expected {
+			t.Errorf("expected %s to be %s, got %s", dataTypeName(test.dataType), test.expected, ts)
+		}
+	}
+}
+
+func TestRenderCluster(t *testing.T) {
+	cluster := matter.NewCluster(nil)
+	cluster.Name = "Group Key Management"
+	cluster.ID = matter.NewNumber(0x003F)
+
+	groupKeyMap := matter.NewStruct(nil, cluster)
+	groupKeyMap.Name = "GroupKeyMapStruct"
+	groupKeyMap.FabricScoping = matter.FabricScopingScoped
+	groupKeyMap.Fields = matter.FieldSet{
+		{ID: matter.NewNumber(1), Name: "GroupId", Type: types.NewDataType(types.BaseDataTypeGroupID, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")},
+		{ID: matter.NewNumber(2), Name: "GroupKeySetID", Type: types.NewDataType(types.BaseDataTypeUInt16, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")},
+	}
+	cluster.Structs = append(cluster.Structs, groupKeyMap)
+
+	cluster.Attributes = matter.FieldSet{
+		{ID: matter.NewNumber(0x0000), Name: "GroupKeyMap", Type: &types.DataType{BaseType: types.BaseDataTypeList, Name: "list", EntryType: &types.DataType{BaseType: types.BaseDataTypeCustom, Name: groupKeyMap.Name, Entity: groupKeyMap}}, Quality: matter.QualityNonVolatile | matter.QualityFixed, Conformance: conformance.ParseConformance("M")},
+		{ID: matter.NewNumber(0x0003), Name: "MaxGroupKeysPerFabric", Type: types.NewDataType(types.BaseDataTypeUInt16, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("X")},
+	}
+
+	readResponse := matter.NewCommand(nil, cluster)
+	readResponse.Name = "KeySetReadResponse"
+	readResponse.ID = matter.NewNumber(0x02)
+	readResponse.Direction = matter.InterfaceClient
+	read := matter.NewCommand(nil, cluster)
+	read.Name = "KeySetRead"
+	read.ID = matter.NewNumber(0x01)
+	read.Direction = matter.InterfaceServer
+	read.Response = &types.DataType{Name: readResponse.Name, Entity: readResponse}
+	read.Fields = matter.FieldSet{{ID: matter.NewNumber(0), Name: "GroupKeySetID", Type: types.NewDataType(types.BaseDataTypeUInt16, types.DataTypeRankScalar), Conformance: conformance.ParseConformance("M")}}
+	readAll := matter.NewCommand(nil, cluster)
+	readAll.Name = "KeySetReadAllIndices"
+	readAll.ID = matter.NewNumber(0x04)
+	readAll.Direction = matter.InterfaceServer
+	cluster.Commands = append(cluster.Commands, read, readResponse, readAll)
+
+	r := NewRenderer(nil, Options{})
+	tmpl, err := r.loadTemplate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := tmpl.Exec(clusterContext(cluster))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"export const ClusterId = 0x003F;\nexport const ClusterName = \"Group Key Management\";\n",
+		"export const AttributeIds = {\n  GroupKeyMap: 0x0000,\n} as const;\n",
+		"export const CommandIds = {\n  KeySetRead: 0x0001,\n  KeySetReadAllIndices: 0x0004,\n  KeySetReadResponse: 0x0002,\n} as const;\n",
+		"export interface GroupKeyMapStruct {\n  groupId: number;\n  groupKeySetId: number;\n  fabricIndex: number;\n}\n",
+		"export interface Attributes {\n  groupKeyMap: GroupKeyMapStruct[];\n}\n",
+		"    type: \"list[GroupKeyMapStruct]\",\n",
+		"    quality: [\"nonVolatile\", \"fixed\"],\n",
+		"export interface KeySetReadRequest {\n  groupKeySetId: number;\n}\n",
+		"export interface KeySetReadResponse {\n}\n",
+		"  keySetRead(request: KeySetReadRequest): Promise<KeySetReadResponse>;\n",
+		"  keySetReadAllIndices(): Promise<void>;\n",
+		"    response: \"KeySetReadResponse\",\n",
+		"    response: undefined,\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected output to contain %q; got:\n%s", expected, out)
+		}
+	}
+	for _, unexpected := range []string{"MaxGroupKeysPerFabric", "KeySetReadAllIndicesRequest", "KeySetReadResponseResponse", "EventIds"} {
+		if strings.Contains(out, unexpected) {
+			t.Errorf("expected output not to contain %q; got:\n%s", unexpected, out)
+		}
+	}
+}
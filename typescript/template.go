@@ -0,0 +1,61 @@
+package typescript
+
+import (
+	"embed"
+	"log/slog"
+
+	"github.com/mailgun/raymond/v2"
+	"github.com/project-chip/alchemy/internal/handlebars"
+	"github.com/project-chip/alchemy/internal/pipeline"
+)
+
+//go:embed templates
+var templateFiles embed.FS
+
+var template pipeline.Once[*raymond.Template]
+
+func (r *Renderer) loadTemplate() (*raymond.Template, error) {
+	t, err := template.Do(func() (*raymond.Template, error) {
+
+		ov := handlebars.NewOverlay(r.options.TemplateRoot, templateFiles, "templates")
+		err := ov.Flush()
+		if err != nil {
+			slog.Error("Error flushing embedded templates", slog.Any("error", err))
+		}
+		t, err := handlebars.LoadTemplate("{{> module}}", ov)
+		if err != nil {
+			return nil, err
+		}
+
+		handlebars.RegisterCommonHelpers(t)
+
+		t.RegisterHelper("comment", commentHelper)
+		t.RegisterHelper("id", idHelper)
+		t.RegisterHelper("typeName", typeNameHelper)
+		t.RegisterHelper("propertyName", propertyNameHelper)
+		t.RegisterHelper("fieldType", fieldTypeHelper)
+		t.RegisterHelper("tsType", tsTypeHelper)
+		t.RegisterHelper("ifOptional", ifOptionalHelper)
+		t.RegisterHelper("memberName", memberNameHelper)
+		t.RegisterHelper("bitName", bitNameHelper)
+		t.RegisterHelper("bitMask", bitMaskHelper)
+		t.RegisterHelper("bitSummary", bitSummaryHelper)
+		t.RegisterHelper("dataType", dataTypeHelper)
+		t.RegisterHelper("qualities", qualitiesHelper)
+		t.RegisterHelper("json", jsonHelper)
+		t.RegisterHelper("requestName", requestNameHelper)
+		t.RegisterHelper("responseName", responseNameHelper)
+		t.RegisterHelper("responseType", responseTypeHelper)
+		t.RegisterHelper("eventName", eventNameHelper)
+		t.RegisterHelper("ifHasFields", ifHasFieldsHelper)
+		t.RegisterHelper("includedFields", includedFieldsHelper)
+		t.RegisterHelper("includedValues", includedValuesHelper)
+		t.RegisterHelper("includedBits", includedBitsHelper)
+		t.RegisterHelper("ifImplicitFabricIndex", ifImplicitFabricIndexHelper)
+		return t, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t.Clone(), nil
+}
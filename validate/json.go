@@ -0,0 +1,12 @@
+package validate
+
+import (
+	"encoding/json"
+	"io"
+)
+
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
@@ -0,0 +1,70 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+// AnyErrorType is the key in an exit code policy which matches all error types
+const AnyErrorType = "*"
+
+// ExitPolicy maps error types to the process exit code they should produce
+type ExitPolicy struct {
+	codes       map[spec.ErrorType]int
+	defaultCode int
+}
+
+func NewExitPolicy(codes map[string]int) (policy ExitPolicy, err error) {
+	policy.codes = make(map[spec.ErrorType]int)
+	for name, code := range codes {
+		if code < 0 || code > 125 {
+			err = fmt.Errorf("invalid exit code for \"%s\": %d", name, code)
+			return
+		}
+		if name == AnyErrorType {
+			policy.defaultCode = code
+			continue
+		}
+		et, ok := spec.ParseErrorType(name)
+		if !ok {
+			err = fmt.Errorf("unknown error type in exit code policy: \"%s\"", name)
+			return
+		}
+		policy.codes[et] = code
+	}
+	return
+}
+
+// ExitCode returns the highest exit code produced by any finding in the report
+func (ep ExitPolicy) ExitCode(r *Report) (code int) {
+	for _, f := range r.Findings {
+		fc, ok := ep.codes[f.ErrorType]
+		if !ok {
+			fc = ep.defaultCode
+		}
+		code = max(code, fc)
+	}
+	return
+}
+
+func (ep ExitPolicy) Check(r *Report) error {
+	code := ep.ExitCode(r)
+	if code == 0 {
+		return nil
+	}
+	return &ExitError{Code: code, Count: len(r.Findings)}
+}
+
+type ExitError struct {
+	Code  int
+	Count int
+}
+
+func (ee *ExitError) Error() string {
+	return fmt.Sprintf("validation failed with %d errors", ee.Count)
+}
+
+func (ee *ExitError) ExitCode() int {
+	return ee.Code
+}
@@ -0,0 +1,54 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+func TestExitPolicy(t *testing.T) {
+	report := &Report{Findings: []Finding{
+		{ErrorType: spec.ErrorTypeDuplicateEntityID},
+		{ErrorType: spec.ErrorTypeInvalidConformance},
+	}}
+
+	tests := []struct {
+		name     string
+		codes    map[string]int
+		expected int
+	}{
+		{name: "empty", codes: nil, expected: 0},
+		{name: "any", codes: map[string]int{"*": 1}, expected: 1},
+		{name: "single", codes: map[string]int{"duplicate-entity-id": 3}, expected: 3},
+		{name: "highest", codes: map[string]int{"duplicate-entity-id": 3, "invalid-conformance": 5}, expected: 5},
+		{name: "override", codes: map[string]int{"*": 2, "unknown-custom-data-type": 7}, expected: 2},
+		{name: "unmatched", codes: map[string]int{"unknown-custom-data-type": 7}, expected: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy, err := NewExitPolicy(test.codes)
+			if err != nil {
+				t.Fatalf("failed creating exit policy: %v", err)
+			}
+			if code := policy.ExitCode(report); code != test.expected {
+				t.Errorf("unexpected exit code: expected %d, got %d", test.expected, code)
+			}
+		})
+	}
+}
+
+func TestExitPolicyUnknownType(t *testing.T) {
+	_, err := NewExitPolicy(map[string]int{"not-a-real-error": 1})
+	if err == nil {
+		t.Errorf("expected error for unknown error type")
+	}
+}
+
+func TestErrorTypeNames(t *testing.T) {
+	for _, et := range spec.ErrorTypes() {
+		parsed, ok := spec.ParseErrorType(et.String())
+		if !ok || parsed != et {
+			t.Errorf("error type %d does not round-trip through name \"%s\"", et, et.String())
+		}
+	}
+}
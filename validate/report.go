@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+type Report struct {
+	Root     string    `json:"root,omitempty"`
+	Findings []Finding `json:"findings"`
+}
+
+type Finding struct {
+	RuleID    string         `json:"ruleId"`
+	ErrorType spec.ErrorType `json:"-"`
+	Code      uint16         `json:"errorType"`
+	Message   string         `json:"message"`
+	Path      string         `json:"path,omitempty"`
+	Line      int            `json:"line,omitempty"`
+	Entity    *Entity        `json:"entity,omitempty"`
+}
+
+type Entity struct {
+	Type    types.EntityType `json:"type"`
+	Name    string           `json:"name,omitempty"`
+	ID      string           `json:"id,omitempty"`
+	Cluster string           `json:"cluster,omitempty"`
+}
+
+func NewReport(s *spec.Specification) *Report {
+	r := &Report{Root: s.Root, Findings: make([]Finding, 0, len(s.Errors))}
+	for _, e := range s.Errors {
+		r.Findings = append(r.Findings, newFinding(s.Root, e))
+	}
+	slices.SortStableFunc(r.Findings, func(a Finding, b Finding) int {
+		return cmp.Or(cmp.Compare(a.Path, b.Path), cmp.Compare(a.Line, b.Line), cmp.Compare(a.RuleID, b.RuleID), cmp.Compare(a.Message, b.Message))
+	})
+	return r
+}
+
+func newFinding(root string, e spec.Error) (f Finding) {
+	f.ErrorType = e.Type()
+	f.RuleID = f.ErrorType.String()
+	f.Code = uint16(f.ErrorType)
+	f.Message = e.Error()
+	f.Path, f.Line = e.Origin()
+	f.Path = spec.RelativePath(root, f.Path)
+	if f.Line < 0 {
+		f.Line = 0
+	}
+	if entity := spec.ErrorEntity(e); entity != nil {
+		f.Entity = newEntity(entity)
+	}
+	return
+}
+
+func newEntity(entity types.Entity) *Entity {
+	e := &Entity{Type: entity.EntityType()}
+	switch entity := entity.(type) {
+	case *matter.ClusterRequirement:
+		e.Name = entity.ClusterName
+		e.ID = entity.ClusterID.ValidHexString()
+	case *matter.ElementRequirement:
+		e.Name = entity.Name
+		e.Cluster = entity.ClusterName
+	case *matter.DeviceTypeRequirement:
+		e.Name = entity.DeviceTypeName
+		e.ID = entity.DeviceTypeID.ValidHexString()
+	case *matter.ConditionRequirement:
+		e.Name = entity.ConditionName
+	default:
+		e.Name = matter.EntityName(entity)
+		switch entity.(type) {
+		case *matter.Cluster, *matter.Field, *matter.Event, *matter.Command, *matter.DeviceType, *matter.Namespace:
+			e.ID = matter.EntityID(entity).ValidHexString()
+		}
+		if cluster := matter.EntityCluster(entity); cluster != nil && cluster != entity {
+			e.Cluster = cluster.Name
+		}
+	}
+	return e
+}
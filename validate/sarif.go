@@ -0,0 +1,152 @@
+package validate
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/project-chip/alchemy/config"
+	"github.com/project-chip/alchemy/matter/spec"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifBaseID  = "SPECROOT"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult                    `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name,omitempty"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+func (r *Report) WriteSARIF(w io.Writer) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "alchemy",
+				Version:        config.Version(),
+				InformationURI: "https://github.com/project-chip/alchemy",
+			},
+		},
+		Results: make([]sarifResult, 0, len(r.Findings)),
+	}
+	if r.Root != "" {
+		run.OriginalURIBaseIDs = map[string]sarifArtifactLocation{
+			sarifBaseID: {URI: fileURI(r.Root)},
+		}
+	}
+	ruleIndex := make(map[spec.ErrorType]int)
+	for _, et := range spec.ErrorTypes() {
+		ruleIndex[et] = len(run.Tool.Driver.Rules)
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               et.String(),
+			Name:             strcase.ToCamel(et.String()),
+			ShortDescription: sarifMessage{Text: strings.ReplaceAll(et.String(), "-", " ")},
+		})
+	}
+	for _, f := range r.Findings {
+		result := sarifResult{
+			RuleID:    f.RuleID,
+			RuleIndex: ruleIndex[f.ErrorType],
+			Level:     "error",
+			Message:   sarifMessage{Text: f.Message},
+		}
+		var location sarifLocation
+		if f.Path != "" {
+			location.PhysicalLocation = &sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: f.Path, URIBaseID: sarifBaseID},
+			}
+			if f.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: f.Line}
+			}
+		}
+		if f.Entity != nil {
+			ll := sarifLogicalLocation{Name: f.Entity.Name, Kind: f.Entity.Type.String()}
+			if f.Entity.Cluster != "" && f.Entity.Name != "" {
+				ll.FullyQualifiedName = f.Entity.Cluster + "." + f.Entity.Name
+			}
+			location.LogicalLocations = append(location.LogicalLocations, ll)
+		}
+		if location.PhysicalLocation != nil || len(location.LogicalLocations) > 0 {
+			result.Locations = append(result.Locations, location)
+		}
+		run.Results = append(run.Results, result)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}})
+}
+
+func fileURI(root string) string {
+	root = strings.ReplaceAll(root, "\\", "/")
+	if !strings.HasPrefix(root, "/") {
+		root = "/" + root
+	}
+	if !strings.HasSuffix(root, "/") {
+		root += "/"
+	}
+	return "file://" + root
+}
@@ -11,13 +11,18 @@ import (
 	"github.com/project-chip/alchemy/matter/types"
 )
 
-func readAttribute(d *xml.Decoder, e xml.StartElement, c *matter.Cluster) (attr *matter.Field, err error) {
+func readAttribute(d *xml.Decoder, e xml.StartElement, c *matter.Cluster) (attr *matter.Field, define string, err error) {
 	attr = matter.NewAttribute(nil, c)
 	attr.Access = matter.DefaultAccess(types.EntityTypeAttribute)
 	err = readFieldAttributes(e, attr, "attribute")
 	if err != nil {
 		return
 	}
+	for _, a := range e.Attr {
+		if a.Name.Local == "define" {
+			define = a.Value
+		}
+	}
 	for {
 		var tok xml.Token
 		tok, err = d.Token()
@@ -35,13 +40,15 @@ func readAttribute(d *xml.Decoder, e xml.StartElement, c *matter.Cluster) (attr
 			case "description":
 				attr.Name, err = readSimpleElement(d, t.Name.Local)
 			case "quality":
-				c.Quality, err = parseQuality(d, t)
+				var q matter.Quality
+				q, err = parseQuality(d, t)
+				attr.Quality |= q
 			default:
 				if isConformanceElement(t) {
 					var cs conformance.Conformance
 					cs, err = parseConformance(d, t)
 					if err == nil {
-						attr.Conformance = append(attr.Conformance, cs)
+						attr.Conformance = conformanceSet(cs)
 					}
 				} else {
 					err = fmt.Errorf("unexpected attribute level element: %s", t.Name.Local)
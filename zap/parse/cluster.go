@@ -9,8 +9,10 @@ import (
 	"github.com/project-chip/alchemy/matter"
 )
 
-func readCluster(path string, d *xml.Decoder, e xml.StartElement) (cluster *matter.Cluster, err error) {
-	cluster = &matter.Cluster{}
+func (sp *ZapParser) readCluster(path string, d *xml.Decoder, e xml.StartElement) (cluster *matter.Cluster, err error) {
+	cluster = matter.NewCluster(nil)
+	cluster.Hierarchy = "Base"
+	defines := make(map[string]*matter.Field)
 	for _, a := range e.Attr {
 		switch a.Name.Local {
 		case "singleton":
@@ -34,9 +36,13 @@ func readCluster(path string, d *xml.Decoder, e xml.StartElement) (cluster *matt
 			switch t.Name.Local {
 			case "attribute":
 				var attribute *matter.Field
-				attribute, err = readAttribute(d, t, cluster)
+				var define string
+				attribute, define, err = readAttribute(d, t, cluster)
 				if err == nil {
 					cluster.Attributes = append(cluster.Attributes, attribute)
+					if define != "" {
+						defines[define] = attribute
+					}
 				}
 			case "client":
 				err = readClient(d, t)
@@ -56,7 +62,7 @@ func readCluster(path string, d *xml.Decoder, e xml.StartElement) (cluster *matt
 				_, err = readSimpleElement(d, t.Name.Local)
 			case "event":
 				var event *matter.Event
-				event, err = readEvent(path, d, t)
+				event, err = readEvent(path, d, t, cluster)
 				if err == nil {
 					cluster.Events = append(cluster.Events, event)
 				}
@@ -64,11 +70,13 @@ func readCluster(path string, d *xml.Decoder, e xml.StartElement) (cluster *matt
 				cluster.Name, err = readSimpleElement(d, t.Name.Local)
 			case "command":
 				var command *matter.Command
-				command, err = readCommand(path, d, t)
+				command, err = readCommand(path, d, t, cluster)
 				if err == nil {
 					cluster.Commands = append(cluster.Commands, command)
 				}
-			case "globalAttribute", "global":
+			case "globalAttribute":
+				err = readGlobalAttribute(d, t, cluster)
+			case "global":
 				err = Ignore(d, t.Name.Local)
 			case "tag":
 				_, err = readTag(d, t)
@@ -95,6 +103,9 @@ func readCluster(path string, d *xml.Decoder, e xml.StartElement) (cluster *matt
 						}
 					}
 				}
+				sp.lock.Lock()
+				sp.attributeDefines[cluster] = defines
+				sp.lock.Unlock()
 				return
 			default:
 				err = fmt.Errorf("unexpected cluster end element: %s", t.Name.Local)
@@ -148,3 +159,54 @@ func readClusterCode(d *xml.Decoder, e xml.StartElement) (id *matter.Number, err
 		}
 	}
 }
+
+func readGlobalAttribute(d *xml.Decoder, e xml.StartElement, cluster *matter.Cluster) (err error) {
+	var code, value string
+	for _, a := range e.Attr {
+		switch a.Name.Local {
+		case "code":
+			code = a.Value
+		case "value":
+			value = a.Value
+		case "side":
+		default:
+			return fmt.Errorf("unexpected globalAttribute attribute: %s", a.Name.Local)
+		}
+	}
+	id := matter.ParseNumber(code)
+	if id.Valid() && id.Value() == 0xFFFD {
+		revision := matter.NewRevision(cluster, nil)
+		revision.Number = matter.ParseNumber(value)
+		cluster.Revisions = append(cluster.Revisions, revision)
+	}
+	for {
+		var tok xml.Token
+		tok, err = d.Token()
+		if tok == nil || err == io.EOF {
+			return fmt.Errorf("EOF before end of globalAttribute")
+		} else if err != nil {
+			return
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if isConformanceElement(t) {
+				_, err = parseConformance(d, t)
+			} else {
+				err = fmt.Errorf("unexpected globalAttribute level element: %s", t.Name.Local)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "globalAttribute":
+				return
+			default:
+				err = fmt.Errorf("unexpected globalAttribute end element: %s", t.Name.Local)
+			}
+		case xml.CharData, xml.Comment:
+		default:
+			err = fmt.Errorf("unexpected globalAttribute level type: %T", t)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
@@ -11,8 +11,9 @@ import (
 	"github.com/project-chip/alchemy/matter/types"
 )
 
-func readCommand(path string, d *xml.Decoder, e xml.StartElement) (c *matter.Command, err error) {
-	c = &matter.Command{Access: matter.DefaultAccess(types.EntityTypeCommand)}
+func readCommand(path string, d *xml.Decoder, e xml.StartElement, parent types.Entity) (c *matter.Command, err error) {
+	c = matter.NewCommand(nil, parent)
+	c.Access = matter.DefaultAccess(types.EntityTypeCommand)
 	var optional, isFabricScoped, disableDefaultResponse string
 	for _, a := range e.Attr {
 		switch a.Name.Local {
@@ -87,10 +88,14 @@ func readCommand(path string, d *xml.Decoder, e xml.StartElement) (c *matter.Com
 				c.Quality, err = parseQuality(d, t)
 			case "arg":
 				var f *matter.Field
-				f, err = readField(path, d, t, types.EntityTypeCommand, "arg", c)
+				f, err = readField(path, d, t, types.EntityTypeCommandField, "arg", c)
 				if err != nil {
 					slog.Warn("error reading command field", slog.Any("error", err))
 				} else {
+					if !f.ID.Valid() {
+						// Command arguments are identified by their position
+						f.ID = matter.NewNumber(uint64(len(c.Fields)))
+					}
 					c.Fields = append(c.Fields, f)
 				}
 			default:
@@ -98,7 +103,7 @@ func readCommand(path string, d *xml.Decoder, e xml.StartElement) (c *matter.Com
 					var cs conformance.Conformance
 					cs, err = parseConformance(d, t)
 					if err == nil {
-						c.Conformance = append(c.Conformance, cs)
+						c.Conformance = conformanceSet(cs)
 					}
 				} else {
 					err = fmt.Errorf("unexpected command level element: %s", t.Name.Local)
@@ -30,7 +30,7 @@ func (sp *ZapParser) readConfigurator(path string, d *xml.Decoder) (entities []t
 			switch t.Name.Local {
 			case "cluster":
 				var cluster *matter.Cluster
-				cluster, err = readCluster(path, d, t)
+				cluster, err = sp.readCluster(path, d, t)
 				if err == nil {
 					clusters[cluster.ID.Value()] = cluster
 					entities = append(entities, cluster)
@@ -42,6 +42,9 @@ func (sp *ZapParser) readConfigurator(path string, d *xml.Decoder) (entities []t
 				var clusterIDs []*matter.Number
 				en, clusterIDs, err = sp.readEnum(d, t)
 				if err == nil {
+					if len(clusterIDs) == 0 {
+						entities = append(entities, en)
+					}
 					for _, cid := range clusterIDs {
 						enums[cid.Value()] = append(enums[cid.Value()], en)
 					}
@@ -51,6 +54,9 @@ func (sp *ZapParser) readConfigurator(path string, d *xml.Decoder) (entities []t
 				var clusterIDs []*matter.Number
 				s, clusterIDs, err = sp.readStruct(path, d, t)
 				if err == nil {
+					if len(clusterIDs) == 0 {
+						entities = append(entities, s)
+					}
 					for _, cid := range clusterIDs {
 						structs[cid.Value()] = append(structs[cid.Value()], s)
 					}
@@ -63,12 +69,25 @@ func (sp *ZapParser) readConfigurator(path string, d *xml.Decoder) (entities []t
 					if bitmap.Name == "Feature" {
 						features = &matter.Features{Bitmap: *bitmap}
 					} else {
+						if len(clusterIDs) == 0 {
+							entities = append(entities, bitmap)
+						}
 						for _, cid := range clusterIDs {
 							bitmaps[cid.Value()] = append(bitmaps[cid.Value()], bitmap)
 						}
 					}
 				}
-			case "accessControl", "atomic", "clusterExtension", "global", "deviceType":
+			case "deviceType":
+				var deviceType *matter.DeviceType
+				deviceType, err = readDeviceType(d, t)
+				if err == nil {
+					if deviceType.ID.Valid() && (deviceType.ID.Value()&utilityDevicesMask) == utilityDevicesMask {
+						// Skip the all clusters app, etc.
+						break
+					}
+					entities = append(entities, deviceType)
+				}
+			case "accessControl", "atomic", "clusterExtension", "global":
 				err = Ignore(d, t.Name.Local)
 			default:
 				err = fmt.Errorf("unexpected configurator level element: %s", t.Name.Local)
@@ -106,8 +125,12 @@ func (sp *ZapParser) readConfigurator(path string, d *xml.Decoder) (entities []t
 						sp.lock.Unlock()
 					}
 				}
-				for _, c := range clusters {
-					c.Features = features
+				if features != nil {
+					for _, c := range clusters {
+						if c.Features == nil {
+							c.Features = features
+						}
+					}
 				}
 				return
 			default:
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"strconv"
 
 	"github.com/project-chip/alchemy/matter/conformance"
 	"github.com/shopspring/decimal"
@@ -18,6 +19,7 @@ func isConformanceElement(e xml.StartElement) bool {
 		"provisionalConform",
 		"deprecateConform",
 		"describedConform",
+		"obsoleteConform",
 		"otherwiseConform":
 		return true
 	default:
@@ -37,7 +39,7 @@ func parseConformance(d *xml.Decoder, e xml.StartElement) (c conformance.Conform
 		c = &conformance.Disallowed{}
 	case "provisionalConform":
 		err = parseStandalone(d, e, e.Name.Local)
-		c = &conformance.Disallowed{}
+		c = &conformance.Provisional{}
 	case "deprecateConform":
 		err = parseStandalone(d, e, e.Name.Local)
 		c = &conformance.Deprecated{}
@@ -59,6 +61,14 @@ func parseConformance(d *xml.Decoder, e xml.StartElement) (c conformance.Conform
 	return
 }
 
+// conformanceSet wraps a parsed conformance in a set, unless it is already one (as otherwiseConform is)
+func conformanceSet(c conformance.Conformance) conformance.Set {
+	if cs, ok := c.(conformance.Set); ok {
+		return cs
+	}
+	return conformance.Set{c}
+}
+
 func parseOptionalConformance(d *xml.Decoder, e xml.StartElement) (c *conformance.Optional, err error) {
 	var exp conformance.Expression
 	for {
@@ -83,6 +93,7 @@ func parseOptionalConformance(d *xml.Decoder, e xml.StartElement) (c *conformanc
 			switch t.Name.Local {
 			case "optionalConform":
 				c = &conformance.Optional{Expression: exp}
+				c.Choice, err = parseChoice(e)
 				return
 			default:
 				err = fmt.Errorf("unexpected optionalConform end element: %s", t.Name.Local)
@@ -97,6 +108,56 @@ func parseOptionalConformance(d *xml.Decoder, e xml.StartElement) (c *conformanc
 	}
 }
 
+// parseChoice reads the choice of an optionalConform element, with the same min, max and more attributes the data
+// model XML uses
+func parseChoice(e xml.StartElement) (*conformance.Choice, error) {
+	var set, min, max string
+	var more bool
+	for _, a := range e.Attr {
+		switch a.Name.Local {
+		case "choice":
+			set = a.Value
+		case "min":
+			min = a.Value
+		case "max":
+			max = a.Value
+		case "more":
+			more = a.Value == "true"
+		}
+	}
+	if set == "" {
+		return nil, nil
+	}
+	choice := &conformance.Choice{Set: set}
+	var minVal, maxVal int64
+	var err error
+	if min != "" {
+		minVal, err = strconv.ParseInt(min, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid choice min %q: %w", min, err)
+		}
+	}
+	if max != "" {
+		maxVal, err = strconv.ParseInt(max, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid choice max %q: %w", max, err)
+		}
+	}
+	switch {
+	case min != "" && max != "" && minVal == maxVal:
+		if minVal != 1 {
+			choice.Limit = &conformance.ChoiceExactLimit{Limit: minVal}
+		}
+	case min != "" && max != "":
+		choice.Limit = &conformance.ChoiceRangeLimit{Min: minVal, Max: maxVal}
+	case min != "" && more:
+		choice.Limit = &conformance.ChoiceMinLimit{Min: minVal}
+	case max != "":
+		choice.Limit = &conformance.ChoiceMaxLimit{Max: maxVal}
+	}
+	return choice, nil
+}
+
 func parseMandatoryConformance(d *xml.Decoder, e xml.StartElement) (c *conformance.Mandatory, err error) {
 	var exp conformance.Expression
 	for {
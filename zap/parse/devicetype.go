@@ -0,0 +1,313 @@
+package parse
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/conformance"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+var utilityDevicesMask uint64 = 0xFF000000
+
+func readDeviceType(d *xml.Decoder, e xml.StartElement) (deviceType *matter.DeviceType, err error) {
+	deviceType = matter.NewDeviceType(nil)
+	for {
+		var tok xml.Token
+		tok, err = d.Token()
+		if tok == nil || err == io.EOF {
+			return nil, fmt.Errorf("EOF before end of deviceType")
+		} else if err != nil {
+			return
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "typeName":
+				var name string
+				name, err = readSimpleElement(d, t.Name.Local)
+				if err == nil {
+					deviceType.Name = strings.TrimPrefix(name, "Matter ")
+				}
+			case "deviceId":
+				var id string
+				id, err = readSimpleElement(d, t.Name.Local)
+				if err == nil {
+					deviceType.ID = matter.ParseNumber(id)
+				}
+			case "revision":
+				var rev string
+				rev, err = readSimpleElement(d, t.Name.Local)
+				if err == nil {
+					revision := matter.NewRevision(deviceType, nil)
+					revision.Number = matter.ParseNumber(rev)
+					deviceType.Revisions = append(deviceType.Revisions, revision)
+				}
+			case "class":
+				deviceType.Class, err = readSimpleElement(d, t.Name.Local)
+			case "scope":
+				deviceType.Scope, err = readSimpleElement(d, t.Name.Local)
+			case "superset":
+				deviceType.SupersetOf, err = readSimpleElement(d, t.Name.Local)
+			case "name", "domain", "profileId":
+				_, err = readSimpleElement(d, t.Name.Local)
+			case "clusters":
+				err = readDeviceTypeClusters(d, t, deviceType)
+			case "endpointComposition", "channels":
+				err = Ignore(d, t.Name.Local)
+			default:
+				err = fmt.Errorf("unexpected deviceType level element: %s", t.Name.Local)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "deviceType":
+				return
+			default:
+				err = fmt.Errorf("unexpected deviceType end element: %s", t.Name.Local)
+			}
+		case xml.CharData, xml.Comment:
+		default:
+			err = fmt.Errorf("unexpected deviceType level type: %T", t)
+		}
+		if err != nil {
+			err = fmt.Errorf("error parsing deviceType: %w", err)
+			return
+		}
+	}
+}
+
+func readDeviceTypeClusters(d *xml.Decoder, e xml.StartElement, deviceType *matter.DeviceType) (err error) {
+	for {
+		var tok xml.Token
+		tok, err = d.Token()
+		if tok == nil || err == io.EOF {
+			return fmt.Errorf("EOF before end of clusters")
+		} else if err != nil {
+			return
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "include":
+				err = readClusterInclude(d, t, deviceType)
+			default:
+				err = fmt.Errorf("unexpected clusters level element: %s", t.Name.Local)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "clusters":
+				return
+			default:
+				err = fmt.Errorf("unexpected clusters end element: %s", t.Name.Local)
+			}
+		case xml.CharData, xml.Comment:
+		default:
+			err = fmt.Errorf("unexpected clusters level type: %T", t)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func readClusterInclude(d *xml.Decoder, e xml.StartElement, deviceType *matter.DeviceType) (err error) {
+	var clusterName string
+	var client, server, clientLocked, serverLocked bool
+	for _, a := range e.Attr {
+		switch a.Name.Local {
+		case "cluster":
+			clusterName = a.Value
+		case "client":
+			client, err = strconv.ParseBool(a.Value)
+		case "server":
+			server, err = strconv.ParseBool(a.Value)
+		case "clientLocked":
+			clientLocked, err = strconv.ParseBool(a.Value)
+		case "serverLocked":
+			serverLocked, err = strconv.ParseBool(a.Value)
+		default:
+			err = fmt.Errorf("unexpected include attribute: %s", a.Name.Local)
+		}
+		if err != nil {
+			return
+		}
+	}
+	addClusterRequirement := func(set bool, locked bool, iface matter.Interface) {
+		var c conformance.Conformance
+		switch {
+		case set:
+			c = &conformance.Mandatory{}
+		case !locked:
+			c = &conformance.Optional{}
+		default:
+			// Locked off; the device type does not allow this side of the cluster
+			return
+		}
+		cr := matter.NewClusterRequirement(deviceType, nil)
+		cr.ClusterID = matter.InvalidID
+		cr.ClusterName = clusterName
+		cr.Interface = iface
+		cr.Conformance = conformance.Set{c}
+		deviceType.ClusterRequirements = append(deviceType.ClusterRequirements, cr)
+	}
+	addClusterRequirement(server, serverLocked, matter.InterfaceServer)
+	addClusterRequirement(client, clientLocked, matter.InterfaceClient)
+
+	addElementRequirement := func(element types.EntityType, name string, cs conformance.Set) {
+		er := matter.NewElementRequirement(deviceType, nil)
+		er.ClusterID = matter.InvalidID
+		er.ClusterName = clusterName
+		er.Element = element
+		er.Name = name
+		er.Conformance = cs
+		deviceType.ElementRequirements = append(deviceType.ElementRequirements, &er)
+	}
+	for {
+		var tok xml.Token
+		tok, err = d.Token()
+		if tok == nil || err == io.EOF {
+			return fmt.Errorf("EOF before end of include")
+		} else if err != nil {
+			return
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "requireAttribute":
+				var name string
+				name, err = readSimpleElement(d, t.Name.Local)
+				if err == nil {
+					addElementRequirement(types.EntityTypeAttribute, strings.TrimSpace(name), conformance.Set{&conformance.Mandatory{}})
+				}
+			case "requireCommand":
+				var name string
+				name, err = readSimpleElement(d, t.Name.Local)
+				if err == nil {
+					addElementRequirement(types.EntityTypeCommand, strings.TrimSpace(name), conformance.Set{&conformance.Mandatory{}})
+				}
+			case "requireEvent":
+				var name string
+				name, err = readSimpleElement(d, t.Name.Local)
+				if err == nil {
+					addElementRequirement(types.EntityTypeEvent, strings.TrimSpace(name), conformance.Set{&conformance.Mandatory{}})
+				}
+			case "features":
+				err = readFeatureRequirements(d, t, addElementRequirement)
+			default:
+				err = fmt.Errorf("unexpected include level element: %s", t.Name.Local)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "include":
+				return
+			default:
+				err = fmt.Errorf("unexpected include end element: %s", t.Name.Local)
+			}
+		case xml.CharData, xml.Comment:
+		default:
+			err = fmt.Errorf("unexpected include level type: %T", t)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func readFeatureRequirements(d *xml.Decoder, e xml.StartElement, add func(element types.EntityType, name string, cs conformance.Set)) (err error) {
+	for {
+		var tok xml.Token
+		tok, err = d.Token()
+		if tok == nil || err == io.EOF {
+			return fmt.Errorf("EOF before end of features")
+		} else if err != nil {
+			return
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "feature":
+				var code string
+				var cs conformance.Set
+				code, cs, err = readFeatureRequirement(d, t)
+				if err == nil {
+					add(types.EntityTypeFeature, code, cs)
+				}
+			default:
+				err = fmt.Errorf("unexpected features level element: %s", t.Name.Local)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "features":
+				return
+			default:
+				err = fmt.Errorf("unexpected features end element: %s", t.Name.Local)
+			}
+		case xml.CharData, xml.Comment:
+		default:
+			err = fmt.Errorf("unexpected features level type: %T", t)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func readFeatureRequirement(d *xml.Decoder, e xml.StartElement) (code string, cs conformance.Set, err error) {
+	var name string
+	for _, a := range e.Attr {
+		switch a.Name.Local {
+		case "code":
+			code = a.Value
+		case "name":
+			name = a.Value
+		default:
+			err = fmt.Errorf("unexpected feature attribute: %s", a.Name.Local)
+			return
+		}
+	}
+	if code == "" {
+		code = name
+	}
+	for {
+		var tok xml.Token
+		tok, err = d.Token()
+		if tok == nil || err == io.EOF {
+			err = fmt.Errorf("EOF before end of feature")
+		}
+		if err != nil {
+			return
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if isConformanceElement(t) {
+				var c conformance.Conformance
+				c, err = parseConformance(d, t)
+				if err == nil {
+					cs = conformanceSet(c)
+				}
+			} else {
+				err = fmt.Errorf("unexpected feature level element: %s", t.Name.Local)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "feature":
+				if len(cs) == 0 {
+					cs = conformance.Set{&conformance.Mandatory{}}
+				}
+				return
+			default:
+				err = fmt.Errorf("unexpected feature end element: %s", t.Name.Local)
+			}
+		case xml.CharData, xml.Comment:
+		default:
+			err = fmt.Errorf("unexpected feature level type: %T", t)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
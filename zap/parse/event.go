@@ -11,8 +11,9 @@ import (
 	"github.com/project-chip/alchemy/matter/types"
 )
 
-func readEvent(path string, d *xml.Decoder, e xml.StartElement) (event *matter.Event, err error) {
-	event = &matter.Event{Access: matter.DefaultAccess(types.EntityTypeEvent)}
+func readEvent(path string, d *xml.Decoder, e xml.StartElement, parent types.Entity) (event *matter.Event, err error) {
+	event = matter.NewEvent(nil, parent)
+	event.Access = matter.DefaultAccess(types.EntityTypeEvent)
 	var optional, isFabricSensitive string
 	for _, a := range e.Attr {
 		switch a.Name.Local {
@@ -63,7 +64,7 @@ func readEvent(path string, d *xml.Decoder, e xml.StartElement) (event *matter.E
 				event.Description, err = readSimpleElement(d, t.Name.Local)
 			case "field":
 				var field *matter.Field
-				field, err = readField(path, d, t, types.EntityTypeEvent, "field", event)
+				field, err = readField(path, d, t, types.EntityTypeEventField, "field", event)
 				if err != nil {
 					slog.Warn("error reading event field", slog.Any("error", err))
 				} else {
@@ -74,7 +75,7 @@ func readEvent(path string, d *xml.Decoder, e xml.StartElement) (event *matter.E
 					var cs conformance.Conformance
 					cs, err = parseConformance(d, t)
 					if err == nil {
-						event.Conformance = append(event.Conformance, cs)
+						event.Conformance = conformanceSet(cs)
 					}
 				} else {
 					err = fmt.Errorf("unexpected event level element: %s", t.Name.Local)
@@ -92,7 +92,7 @@ func readFeature(path string, d *xml.Decoder, e xml.StartElement) (feature *matt
 			case "feature":
 				var cs conformance.Set
 				if con != nil {
-					cs = append(cs, con)
+					cs = conformanceSet(con)
 				}
 				feature = matter.NewFeature(nil, bit, name, code, summary, cs)
 				return
@@ -33,13 +33,15 @@ func readField(path string, d *xml.Decoder, e xml.StartElement, entityType types
 		case xml.StartElement:
 			switch t.Name.Local {
 			case "quality":
-				field.Quality, err = parseQuality(d, t)
+				var q matter.Quality
+				q, err = parseQuality(d, t)
+				field.Quality |= q
 			default:
 				if isConformanceElement(t) {
 					var cs conformance.Conformance
 					cs, err = parseConformance(d, t)
 					if err == nil {
-						field.Conformance = append(field.Conformance, cs)
+						field.Conformance = conformanceSet(cs)
 					}
 				} else {
 					err = fmt.Errorf("unexpected %s level element: %s", name, t.Name.Local)
@@ -133,6 +135,9 @@ func readFieldAttributes(e xml.StartElement, field *matter.Field, name string) (
 			return fmt.Errorf("unexpected %s attribute: %s", name, a.Name.Local)
 		}
 	}
+	if field.ID == nil {
+		field.ID = matter.InvalidID
+	}
 	if optional != "true" {
 		field.Conformance = conformance.Set{&conformance.Mandatory{}}
 	} else {
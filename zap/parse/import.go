@@ -0,0 +1,81 @@
+package parse
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/project-chip/alchemy/asciidoc"
+	"github.com/project-chip/alchemy/internal/files"
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter/spec"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+// Import parses the ZAP templates in an SDK checkout and builds a specification from them. Each
+// template file is represented by an empty document, which downstream renderers can use in place of
+// the AsciiDoc document they would normally be given.
+func Import(cxt context.Context, sdkRoot string, processingOptions pipeline.ProcessingOptions, options ...spec.BuilderOption) (specification *spec.Specification, docs spec.DocSet, err error) {
+	var paths pipeline.Paths
+	paths, err = pipeline.Start(cxt, Targeter(sdkRoot))
+	if err != nil {
+		return
+	}
+
+	var templates pipeline.FileSet
+	templates, err = pipeline.Parallel(cxt, processingOptions, files.NewReader("Reading ZAP templates"), paths)
+	if err != nil {
+		return
+	}
+
+	parser := NewZapParser()
+	var parsed pipeline.Map[string, *pipeline.Data[[]types.Entity]]
+	parsed, err = pipeline.Parallel(cxt, processingOptions, parser, templates)
+	if err != nil {
+		return
+	}
+	parser.ResolveReferences()
+
+	entities := make(map[*asciidoc.Document][]types.Entity, parsed.Size())
+	docs = spec.NewDocSet()
+	parsed.Range(func(path string, data *pipeline.Data[[]types.Entity]) bool {
+		if len(data.Content) == 0 {
+			return true
+		}
+		var p asciidoc.Path
+		p, err = asciidoc.NewPath(path, sdkRoot)
+		if err != nil {
+			return false
+		}
+		doc := &asciidoc.Document{Path: p}
+		entities[doc] = data.Content
+		docs.Store(p.Absolute, pipeline.NewData(p.Absolute, doc))
+		return true
+	})
+	if err != nil {
+		return
+	}
+
+	specification, err = spec.Import(cxt, sdkRoot, nil, nil, entities, options...)
+	return
+}
+
+// Targeter returns the paths of the data model ZAP templates in an SDK checkout
+func Targeter(sdkRoot string) pipeline.Targeter {
+	return func(cxt context.Context) (paths []string, err error) {
+		templateRoot := filepath.Join(sdkRoot, "src/app/zap-templates/zcl/data-model/chip")
+		var entries []os.DirEntry
+		entries, err = os.ReadDir(templateRoot)
+		if err != nil {
+			return
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasSuffix(e.Name(), ".xml") {
+				continue
+			}
+			paths = append(paths, filepath.Join(templateRoot, e.Name()))
+		}
+		return
+	}
+}
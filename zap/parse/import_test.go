@@ -0,0 +1,166 @@
+package parse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/project-chip/alchemy/internal/pipeline"
+	"github.com/project-chip/alchemy/matter"
+	"github.com/project-chip/alchemy/matter/types"
+)
+
+func TestImport(t *testing.T) {
+	specification, _, err := Import(context.Background(), "testdata/sdk", pipeline.ProcessingOptions{Serial: true, NoProgress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	onOff, ok := specification.ClustersByID[0x0006]
+	if !ok {
+		t.Fatalf("expected On/Off cluster")
+	}
+	levelControl, ok := specification.ClustersByID[0x0008]
+	if !ok {
+		t.Fatalf("expected Level Control cluster")
+	}
+	if r := onOff.Revisions.MostRecent(); r == nil || r.Number.Value() != 6 {
+		t.Errorf("expected On/Off revision 6 from the ClusterRevision global attribute, got %v", r)
+	}
+	if len(onOff.Features.Bits) != 2 {
+		t.Errorf("expected 2 On/Off features, got %d", len(onOff.Features.Bits))
+	}
+	startUp := findField(onOff.Attributes, "StartUpOnOff")
+	if startUp == nil || !startUp.Quality.Has(matter.QualityNonVolatile) || !startUp.Quality.Has(matter.QualityNullable) {
+		t.Errorf("expected StartUpOnOff to be nullable and non-volatile, got %v", startUp)
+	}
+
+	for _, c := range []struct {
+		entity      types.Entity
+		conformance string
+	}{
+		{findFeature(onOff, "OFFONLY"), "P, O.a"},
+		{findCommand(onOff.Commands, "On"), "!OFFONLY"},
+		{findField(levelControl.Attributes, "StartUpCurrentLevel"), "P"},
+		{findField(levelControl.Attributes, "OnOffTransitionTime"), "P, O"},
+	} {
+		if got := matter.EntityConformance(c.entity).ASCIIDocString(); got != c.conformance {
+			t.Errorf("expected conformance %q for %s, got %q", c.conformance, matter.EntityName(c.entity), got)
+		}
+	}
+
+	// A data type listed for more than one cluster belongs to each of them
+	for _, c := range []*matter.Cluster{onOff, levelControl} {
+		if !hasEnum(c, "ModeEnum") {
+			t.Errorf("expected ModeEnum on cluster %s", c.Name)
+		}
+	}
+	// A data type with no cluster is global
+	var global bool
+	for e := range specification.GlobalObjects {
+		if s, ok := e.(*matter.Struct); ok && s.Name == "AtomicAttributeStatusStruct" {
+			global = true
+		}
+	}
+	if !global {
+		t.Errorf("expected AtomicAttributeStatusStruct to be a global data type")
+	}
+
+	offWithEffect := findCommand(onOff.Commands, "OffWithEffect")
+	if offWithEffect == nil || len(offWithEffect.Fields) != 1 || offWithEffect.Fields[0].ID.Value() != 0 {
+		t.Errorf("expected OffWithEffect to have one field with ID 0, got %v", offWithEffect)
+	}
+
+	if _, ok := specification.DeviceTypesByID[0xFFF10003]; ok {
+		t.Errorf("expected the all clusters app device type to be skipped")
+	}
+	light, ok := specification.DeviceTypesByID[0x0101]
+	if !ok {
+		t.Fatalf("expected Dimmable Light device type")
+	}
+	if light.Name != "Dimmable Light" {
+		t.Errorf("expected device type name without Matter prefix, got %q", light.Name)
+	}
+	requirements := make(map[string]string)
+	for _, cr := range light.ClusterRequirements {
+		requirements[cr.ClusterName+" "+cr.Interface.String()] = cr.Conformance.ASCIIDocString()
+	}
+	expectedRequirements := map[string]string{
+		// Set and locked
+		"On/Off server": "M",
+		// Unset and unlocked on both sides; the ZAP renderer writes optional requirements this way
+		"Level Control server": "O",
+		"Level Control client": "O",
+	}
+	if len(requirements) != len(expectedRequirements) {
+		t.Errorf("expected cluster requirements %v, got %v", expectedRequirements, requirements)
+	}
+	for name, c := range expectedRequirements {
+		if requirements[name] != c {
+			t.Errorf("expected %s requirement %q, got %q", name, c, requirements[name])
+		}
+	}
+
+	elements := make(map[string]*matter.ElementRequirement)
+	for _, er := range light.ElementRequirements {
+		elements[er.ClusterName+" "+er.Element.String()+" "+er.Name] = er
+	}
+	for _, key := range []string{
+		// Attributes are required by their defines, and mapped back to their names
+		"On/Off attribute OnOff",
+		"Level Control attribute CurrentLevel",
+		"On/Off command Off",
+		"On/Off feature LT",
+	} {
+		er, ok := elements[key]
+		if !ok {
+			t.Errorf("expected element requirement %s; got %v", key, keys(elements))
+			continue
+		}
+		if er.ClusterID.Value() != onOff.ID.Value() && er.ClusterID.Value() != levelControl.ID.Value() {
+			t.Errorf("expected element requirement %s to be resolved to a cluster ID, got %s", key, er.ClusterID.HexString())
+		}
+	}
+}
+
+func findField(fields matter.FieldSet, name string) *matter.Field {
+	for _, f := range fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+func findCommand(commands matter.CommandSet, name string) *matter.Command {
+	for _, c := range commands {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func findFeature(c *matter.Cluster, code string) *matter.Feature {
+	for f := range c.Features.FeatureBits() {
+		if f.Code == code {
+			return f
+		}
+	}
+	return nil
+}
+
+func hasEnum(c *matter.Cluster, name string) bool {
+	for _, e := range c.Enums {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func keys(m map[string]*matter.ElementRequirement) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return
+}
@@ -7,7 +7,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
-	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/project-chip/alchemy/internal/pipeline"
@@ -22,6 +22,9 @@ type ZapParser struct {
 	bitmapReferences  map[uint64][]*matter.Bitmap
 	enumReferences    map[uint64][]*matter.Enum
 	structReferences  map[uint64][]*matter.Struct
+
+	deviceTypes      []*matter.DeviceType
+	attributeDefines map[*matter.Cluster]map[string]*matter.Field
 }
 
 func NewZapParser() *ZapParser {
@@ -30,6 +33,7 @@ func NewZapParser() *ZapParser {
 		bitmapReferences:  make(map[uint64][]*matter.Bitmap),
 		enumReferences:    make(map[uint64][]*matter.Enum),
 		structReferences:  make(map[uint64][]*matter.Struct),
+		attributeDefines:  make(map[*matter.Cluster]map[string]*matter.Field),
 	}
 }
 
@@ -38,9 +42,6 @@ func (sp *ZapParser) Name() string {
 }
 
 func (sp *ZapParser) Process(cxt context.Context, input *pipeline.Data[[]byte], index int32, total int32) (outputs []*pipeline.Data[[]types.Entity], extras []*pipeline.Data[[]byte], err error) {
-	if filepath.Base(input.Path) == "matter-devices.xml" {
-		return
-	}
 	d := xml.NewDecoder(bytes.NewReader(input.Content))
 	var entities []types.Entity
 	for {
@@ -72,6 +73,8 @@ func (sp *ZapParser) Process(cxt context.Context, input *pipeline.Data[[]byte],
 							}
 						case *matter.Cluster:
 							sp.clusterReferences[e.ID.Value()] = e
+						case *matter.DeviceType:
+							sp.deviceTypes = append(sp.deviceTypes, e)
 						}
 					}
 					sp.lock.Unlock()
@@ -90,14 +93,24 @@ func (sp *ZapParser) Process(cxt context.Context, input *pipeline.Data[[]byte],
 	}
 }
 
+// ResolveReferences attaches data types to clusters defined in other files, and maps the attribute
+// defines used by device type requirements to attribute names; it must be called after all templates are parsed
 func (sp *ZapParser) ResolveReferences() {
+	for cid, b := range sp.bitmapReferences {
+		c, ok := sp.clusterReferences[cid]
+		if !ok {
+			slog.Warn("unknown cluster reference for bitmap", "clusterId", cid)
+			continue
+		}
+		c.AddBitmaps(b...)
+	}
 	for cid, e := range sp.enumReferences {
 		c, ok := sp.clusterReferences[cid]
 		if !ok {
 			slog.Warn("unknown cluster reference for enum", "clusterId", cid)
 			continue
 		}
-		c.Enums = append(c.Enums, e...)
+		c.AddEnums(e...)
 	}
 	for cid, e := range sp.structReferences {
 		c, ok := sp.clusterReferences[cid]
@@ -105,7 +118,33 @@ func (sp *ZapParser) ResolveReferences() {
 			slog.Warn("unknown cluster reference for struct", "clusterId", cid)
 			continue
 		}
-		c.Structs = append(c.Structs, e...)
+		c.AddStructs(e...)
+	}
+	clustersByName := make(map[string]*matter.Cluster, len(sp.clusterReferences))
+	for _, c := range sp.clusterReferences {
+		clustersByName[strings.ToLower(c.Name)] = c
+	}
+	for _, dt := range sp.deviceTypes {
+		for _, cr := range dt.ClusterRequirements {
+			if c, ok := clustersByName[strings.ToLower(cr.ClusterName)]; ok {
+				cr.ClusterID = c.ID.Clone()
+				cr.ClusterName = c.Name
+			}
+		}
+		for _, er := range dt.ElementRequirements {
+			c, ok := clustersByName[strings.ToLower(er.ClusterName)]
+			if !ok {
+				continue
+			}
+			er.ClusterID = c.ID.Clone()
+			er.ClusterName = c.Name
+			if er.Element != types.EntityTypeAttribute {
+				continue
+			}
+			if a, ok := sp.attributeDefines[c][er.Name]; ok {
+				er.Name = a.Name
+			}
+		}
 	}
 }
 